@@ -0,0 +1,440 @@
+package clip
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// snapTolerance is the relative distance under which intersection points
+// are merged into a single arrangement vertex.
+const snapTolerance = 1e-10
+
+// segment is a directed input edge belonging to operand set 0 or 1.
+type segment struct {
+	a, b types.Point
+	set  int
+}
+
+// builder accumulates input edges and computes the boolean result.
+type builder struct {
+	segments []segment
+}
+
+func (b *builder) addPolygon(set int, p Polygon) {
+	b.addLoop(set, polygon.ReverseIfNeeded(p.Outer, true))
+	for _, h := range p.Holes {
+		b.addLoop(set, polygon.ReverseIfNeeded(h, false))
+	}
+}
+
+func (b *builder) addLoop(set int, loop []types.Point) {
+	if len(loop) < 3 {
+		return
+	}
+	for i := range loop {
+		a, c := loop[i], loop[(i+1)%len(loop)]
+		if a != c {
+			b.segments = append(b.segments, segment{a: a, b: c, set: set})
+		}
+	}
+}
+
+// arrEdge is an undirected arrangement edge between vertices v[0] < v[1].
+// winding holds, per set, the net number of input edges running from v[0]
+// to v[1]; it is the jump in winding number from the right side of the
+// edge to the left side.
+type arrEdge struct {
+	v       [2]int
+	winding [2]int
+}
+
+// arrangement is the planar subdivision induced by the input edges.
+type arrangement struct {
+	vertices []types.Point
+	edges    []arrEdge
+}
+
+// piece is a directed part of an input segment between arrangement
+// vertices.
+type piece struct {
+	v   [2]int
+	set int
+}
+
+// split marks a vertex at parameter t along a piece.
+type split struct {
+	t float64
+	v int
+}
+
+// build computes the arrangement, keeps the edges separating inside from
+// outside according to inside, and stitches them into polygons.
+func (b *builder) build(inside func(w [2]int) bool) []Polygon {
+	arr := b.arrange()
+
+	left, right := arr.windings()
+	var boundary []directedEdge
+	for i, e := range arr.edges {
+		l, r := inside(left[i]), inside(right[i])
+		switch {
+		case l && !r:
+			boundary = append(boundary, directedEdge{from: e.v[0], to: e.v[1]})
+		case !l && r:
+			boundary = append(boundary, directedEdge{from: e.v[1], to: e.v[0]})
+		}
+	}
+
+	return assemble(stitch(arr.vertices, boundary))
+}
+
+// arrange splits every input segment at its intersections with the others
+// and merges coincident pieces.
+//
+// Intersection points are rounded and merged by the vertex pool, so a
+// piece ending at one can cross another piece, or pass over a vertex, that
+// the input segment did not. The pieces are therefore split again until
+// none changes; later passes only split pieces within the snap tolerance
+// of an earlier split, so this settles after a few passes.
+func (b *builder) arrange() *arrangement {
+	pool := newVertexPool(b.segments)
+	pieces := make([]piece, 0, len(b.segments))
+	for _, s := range b.segments {
+		pieces = append(pieces, piece{v: [2]int{pool.id(s.a), pool.id(s.b)}, set: s.set})
+	}
+	for changed := true; changed; {
+		pieces, changed = pool.split(pieces)
+	}
+
+	arr := &arrangement{vertices: pool.points}
+	index := make(map[[2]int]int)
+	for _, p := range pieces {
+		from, to := p.v[0], p.v[1]
+		key, sign := [2]int{from, to}, 1
+		if from > to {
+			key, sign = [2]int{to, from}, -1
+		}
+		idx, ok := index[key]
+		if !ok {
+			idx = len(arr.edges)
+			index[key] = idx
+			arr.edges = append(arr.edges, arrEdge{v: key})
+		}
+		arr.edges[idx].winding[p.set] += sign
+	}
+
+	// Edges whose contributions cancel separate regions with equal winding
+	// numbers and never bound the result.
+	kept := arr.edges[:0]
+	for _, e := range arr.edges {
+		if e.winding != [2]int{} {
+			kept = append(kept, e)
+		}
+	}
+	arr.edges = kept
+	return arr
+}
+
+// split splits every piece at its intersections with the others and at the
+// vertices lying on it. It reports whether any piece was split.
+func (vp *vertexPool) split(pieces []piece) ([]piece, bool) {
+	at := func(p piece) (types.Point, types.Point) {
+		return vp.points[p.v[0]], vp.points[p.v[1]]
+	}
+
+	splits := make([][]split, len(pieces))
+	for i, p := range pieces {
+		splits[i] = []split{{0, p.v[0]}, {1, p.v[1]}}
+	}
+
+	order := make([]int, len(pieces))
+	for i := range order {
+		order[i] = i
+	}
+	minX := func(i int) float64 {
+		a, b := at(pieces[i])
+		return math.Min(a.X, b.X)
+	}
+	sort.Slice(order, func(i, j int) bool { return minX(order[i]) < minX(order[j]) })
+
+	for oi, i := range order {
+		ia, ib := at(pieces[i])
+		maxX := math.Max(ia.X, ib.X)
+		for _, j := range order[oi+1:] {
+			ja, jb := at(pieces[j])
+			if math.Min(ja.X, jb.X) > maxX {
+				break
+			}
+			if math.Min(ia.Y, ib.Y) > math.Max(ja.Y, jb.Y) || math.Min(ja.Y, jb.Y) > math.Max(ia.Y, ib.Y) {
+				continue
+			}
+
+			ok, t, u := robust.SegmentIntersect(ia, ib, ja, jb)
+			if !ok {
+				continue
+			}
+
+			if math.IsNaN(t) {
+				// Collinear overlap: each piece is split at the other's
+				// endpoints that fall inside it.
+				for k, p := range []types.Point{ja, jb} {
+					if t := param(ia, ib, p); t > 0 && t < 1 {
+						splits[i] = append(splits[i], split{t, pieces[j].v[k]})
+					}
+				}
+				for k, p := range []types.Point{ia, ib} {
+					if u := param(ja, jb, p); u > 0 && u < 1 {
+						splits[j] = append(splits[j], split{u, pieces[i].v[k]})
+					}
+				}
+				continue
+			}
+
+			var v int
+			switch {
+			case t <= 0:
+				v = pieces[i].v[0]
+			case t >= 1:
+				v = pieces[i].v[1]
+			case u <= 0:
+				v = pieces[j].v[0]
+			case u >= 1:
+				v = pieces[j].v[1]
+			default:
+				v = vp.id(types.Point{X: ia.X + t*(ib.X-ia.X), Y: ia.Y + t*(ib.Y-ia.Y)})
+			}
+			if t > 0 && t < 1 {
+				splits[i] = append(splits[i], split{t, v})
+			}
+			if u > 0 && u < 1 {
+				splits[j] = append(splits[j], split{u, v})
+			}
+		}
+	}
+
+	var out []piece
+	changed := false
+	for i, p := range pieces {
+		sp := splits[i]
+		if len(sp) > 2 {
+			sort.Slice(sp, func(a, b int) bool { return sp[a].t < sp[b].t })
+		}
+		for k := 1; k < len(sp); k++ {
+			from, to := sp[k-1].v, sp[k].v
+			if from == to {
+				continue
+			}
+			if from != p.v[0] || to != p.v[1] {
+				changed = true
+			}
+			out = append(out, piece{v: [2]int{from, to}, set: p.set})
+		}
+	}
+	return out, changed
+}
+
+// windings returns the winding numbers of both sets immediately left and
+// right of every edge, taking edge i as directed from v[0] to v[1].
+//
+// The faces of each connected component of the arrangement are traced with
+// a half-edge walk and numbered relative to the component's outer face:
+// crossing edge i from right to left adds its winding. The outer face's
+// winding is then the winding number of one of the component's vertices
+// with respect to the other components, counted with robust.Orient2D.
+func (arr *arrangement) windings() (left, right [][2]int) {
+	// Half-edge 2i runs v[0]->v[1] along edge i; 2i+1 is its twin.
+	outgoing := make([][]int, len(arr.vertices))
+	for i, e := range arr.edges {
+		outgoing[e.v[0]] = append(outgoing[e.v[0]], 2*i)
+		outgoing[e.v[1]] = append(outgoing[e.v[1]], 2*i+1)
+	}
+	for v, out := range outgoing {
+		arr.sortAround(v, out)
+	}
+	// slot[h] is the position of half-edge h around its origin.
+	slot := make([]int, 2*len(arr.edges))
+	for _, out := range outgoing {
+		for k, h := range out {
+			slot[h] = k
+		}
+	}
+
+	// Trace the faces, keeping each face on the left of its half-edges: the
+	// walk continues with the half-edge just clockwise of the twin.
+	face := make([]int, 2*len(arr.edges))
+	for h := range face {
+		face[h] = -1
+	}
+	var cycles [][]int
+	for h := range face {
+		if face[h] >= 0 {
+			continue
+		}
+		var cycle []int
+		for cur := h; face[cur] < 0; {
+			face[cur] = len(cycles)
+			cycle = append(cycle, cur)
+			twin := cur ^ 1
+			out := outgoing[arr.origin(twin)]
+			cur = out[(slot[twin]+len(out)-1)%len(out)]
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	// Visit components from their lowest, leftmost vertex. Every edge there
+	// points up or right, so the outer face is left of the last half-edge.
+	order := make([]int, len(arr.vertices))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		p, q := arr.vertices[order[i]], arr.vertices[order[j]]
+		if p.Y != q.Y {
+			return p.Y < q.Y
+		}
+		return p.X < q.X
+	})
+
+	wind := make([][2]int, len(cycles))
+	component := make([]int, len(arr.edges))
+	for i := range component {
+		component[i] = -1
+	}
+	for _, v := range order {
+		out := outgoing[v]
+		if len(out) == 0 || component[out[0]/2] >= 0 {
+			continue
+		}
+		c := out[0] / 2
+		outer := face[out[len(out)-1]]
+
+		seen := map[int]bool{outer: true}
+		queue := []int{outer}
+		for len(queue) > 0 {
+			f := queue[0]
+			queue = queue[1:]
+			for _, h := range cycles[f] {
+				component[h/2] = c
+				g := face[h^1]
+				if seen[g] {
+					continue
+				}
+				seen[g] = true
+				w := arr.edges[h/2].winding
+				for s := 0; s < 2; s++ {
+					if h%2 == 0 {
+						wind[g][s] = wind[f][s] - w[s]
+					} else {
+						wind[g][s] = wind[f][s] + w[s]
+					}
+				}
+				queue = append(queue, g)
+			}
+		}
+
+		base := arr.windingAt(arr.vertices[v], component, c)
+		for f := range seen {
+			for s := 0; s < 2; s++ {
+				wind[f][s] += base[s]
+			}
+		}
+	}
+
+	left = make([][2]int, len(arr.edges))
+	right = make([][2]int, len(arr.edges))
+	for i := range arr.edges {
+		left[i], right[i] = wind[face[2*i]], wind[face[2*i+1]]
+	}
+	return left, right
+}
+
+// origin returns the start vertex of half-edge h.
+func (arr *arrangement) origin(h int) int {
+	return arr.edges[h/2].v[h%2]
+}
+
+// sortAround orders the half-edges leaving v counter-clockwise, starting
+// from the direction of the positive x axis.
+func (arr *arrangement) sortAround(v int, out []int) {
+	p := arr.vertices[v]
+	lower := func(q types.Point) bool {
+		return q.Y < p.Y || (q.Y == p.Y && q.X < p.X)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := arr.vertices[arr.origin(out[i]^1)], arr.vertices[arr.origin(out[j]^1)]
+		if la, lb := lower(a), lower(b); la != lb {
+			return lb
+		}
+		return robust.Orient2D(p, a, b) > 0
+	})
+}
+
+// windingAt returns the winding numbers of p with respect to the edges that
+// do not belong to component c. p must not lie on any of those edges.
+func (arr *arrangement) windingAt(p types.Point, component []int, c int) [2]int {
+	var w [2]int
+	for i, e := range arr.edges {
+		if component[i] == c {
+			continue
+		}
+		a, b := arr.vertices[e.v[0]], arr.vertices[e.v[1]]
+		switch {
+		case a.Y <= p.Y && b.Y > p.Y && robust.Orient2D(a, b, p) > 0:
+			w[0] += e.winding[0]
+			w[1] += e.winding[1]
+		case b.Y <= p.Y && a.Y > p.Y && robust.Orient2D(a, b, p) < 0:
+			w[0] -= e.winding[0]
+			w[1] -= e.winding[1]
+		}
+	}
+	return w
+}
+
+// param returns the parameter of p along a-b if p lies on the segment's
+// line, or NaN otherwise.
+func param(a, b, p types.Point) float64 {
+	if robust.Orient2D(a, b, p) != 0 {
+		return math.NaN()
+	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+	return ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+}
+
+// vertexPool assigns arrangement vertex IDs, merging points closer than the
+// snap tolerance.
+type vertexPool struct {
+	points []types.Point
+	cells  map[[2]int64][]int
+	tol    float64
+}
+
+func newVertexPool(segs []segment) *vertexPool {
+	scale := 1.0
+	for _, s := range segs {
+		scale = math.Max(scale, math.Max(math.Max(math.Abs(s.a.X), math.Abs(s.a.Y)), math.Max(math.Abs(s.b.X), math.Abs(s.b.Y))))
+	}
+	return &vertexPool{cells: make(map[[2]int64][]int), tol: scale * snapTolerance}
+}
+
+func (vp *vertexPool) id(p types.Point) int {
+	cx, cy := int64(math.Floor(p.X/vp.tol)), int64(math.Floor(p.Y/vp.tol))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, id := range vp.cells[[2]int64{cx + dx, cy + dy}] {
+				q := vp.points[id]
+				if math.Abs(p.X-q.X) <= vp.tol && math.Abs(p.Y-q.Y) <= vp.tol {
+					return id
+				}
+			}
+		}
+	}
+
+	id := len(vp.points)
+	vp.points = append(vp.points, p)
+	key := [2]int64{cx, cy}
+	vp.cells[key] = append(vp.cells[key], id)
+	return id
+}
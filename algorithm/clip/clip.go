@@ -0,0 +1,161 @@
+// Package clip implements boolean operations on polygons with holes.
+//
+// Inputs are split at every intersection into a planar arrangement, each
+// arrangement edge is classified by the winding numbers on either side of
+// it, and the edges that separate the result from its complement are
+// stitched back into loops. Orientation and intersection tests use the
+// algorithm/robust predicates.
+//
+// Results are returned as polygons with counter-clockwise outers and
+// clockwise holes. Collinear vertices are removed, and regions that only
+// touch at a vertex are reported as separate loops.
+package clip
+
+import (
+	"math"
+
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Op selects a boolean operation.
+type Op int
+
+const (
+	// OpUnion keeps points inside either operand.
+	OpUnion Op = iota
+
+	// OpIntersection keeps points inside both operands.
+	OpIntersection
+
+	// OpDifference keeps points inside the subject but not the clip.
+	OpDifference
+
+	// OpXor keeps points inside exactly one operand.
+	OpXor
+)
+
+// FillRule decides which winding numbers count as inside when resolving
+// self-intersecting or overlapping loops.
+type FillRule int
+
+const (
+	// EvenOdd treats odd winding numbers as inside.
+	EvenOdd FillRule = iota
+
+	// NonZero treats any non-zero winding number as inside.
+	NonZero
+
+	// Positive treats positive winding numbers as inside.
+	Positive
+
+	// Negative treats negative winding numbers as inside.
+	Negative
+)
+
+// Polygon is an outer loop with optional holes.
+//
+// Input loops may use either orientation; outers are treated as
+// counter-clockwise and holes as clockwise.
+type Polygon struct {
+	Outer []types.Point
+	Holes [][]types.Point
+}
+
+// Area returns the area of the outer loop minus the area of the holes.
+func (p Polygon) Area() float64 {
+	area := math.Abs(polygon.SignedArea(p.Outer))
+	for _, h := range p.Holes {
+		area -= math.Abs(polygon.SignedArea(h))
+	}
+	return area
+}
+
+// Boolean applies op to two sets of polygons.
+//
+// Polygons within a set may overlap; each set covers the union of its
+// polygons.
+func Boolean(op Op, subject, clip []Polygon) []Polygon {
+	var b builder
+	for _, p := range subject {
+		b.addPolygon(0, p)
+	}
+	for _, p := range clip {
+		b.addPolygon(1, p)
+	}
+
+	return b.build(func(w [2]int) bool {
+		a, c := w[0] > 0, w[1] > 0
+		switch op {
+		case OpUnion:
+			return a || c
+		case OpIntersection:
+			return a && c
+		case OpDifference:
+			return a && !c
+		case OpXor:
+			return a != c
+		default:
+			return false
+		}
+	})
+}
+
+// Union returns the region covered by subject or clip.
+func Union(subject, clip []Polygon) []Polygon {
+	return Boolean(OpUnion, subject, clip)
+}
+
+// Intersection returns the region covered by both subject and clip.
+func Intersection(subject, clip []Polygon) []Polygon {
+	return Boolean(OpIntersection, subject, clip)
+}
+
+// Difference returns the region covered by subject but not clip.
+func Difference(subject, clip []Polygon) []Polygon {
+	return Boolean(OpDifference, subject, clip)
+}
+
+// Xor returns the region covered by exactly one of subject and clip.
+func Xor(subject, clip []Polygon) []Polygon {
+	return Boolean(OpXor, subject, clip)
+}
+
+// UnionAll merges overlapping or touching polygons.
+//
+// This is useful for combining perimeters before mesh.AddPerimeter, which
+// rejects perimeters that overlap one another.
+func UnionAll(polys []Polygon) []Polygon {
+	return Boolean(OpUnion, polys, nil)
+}
+
+// Simplify resolves self-intersecting and overlapping loops into simple
+// polygons using the given fill rule.
+//
+// Unlike Boolean, loop orientation is significant: counter-clockwise loops
+// add one to the winding number and clockwise loops subtract one.
+func Simplify(loops [][]types.Point, rule FillRule) []Polygon {
+	var b builder
+	for _, loop := range loops {
+		b.addLoop(0, loop)
+	}
+
+	return b.build(func(w [2]int) bool {
+		return rule.contains(w[0])
+	})
+}
+
+func (r FillRule) contains(winding int) bool {
+	switch r {
+	case EvenOdd:
+		return winding%2 != 0
+	case NonZero:
+		return winding != 0
+	case Positive:
+		return winding > 0
+	case Negative:
+		return winding < 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,246 @@
+package clip
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func square(x, y, size float64) []types.Point {
+	return []types.Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+}
+
+func totalArea(polys []Polygon) float64 {
+	area := 0.0
+	for _, p := range polys {
+		area += p.Area()
+	}
+	return area
+}
+
+func assertArea(t *testing.T, polys []Polygon, want float64) {
+	t.Helper()
+	if got := totalArea(polys); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected area %v, got %v (%+v)", want, got, polys)
+	}
+}
+
+func assertOrientation(t *testing.T, polys []Polygon) {
+	t.Helper()
+	for _, p := range polys {
+		if !polygon.IsCCW(p.Outer) {
+			t.Fatalf("expected counter-clockwise outer, got %v", p.Outer)
+		}
+		for _, h := range p.Holes {
+			if polygon.IsCCW(h) {
+				t.Fatalf("expected clockwise hole, got %v", h)
+			}
+		}
+	}
+}
+
+func TestOverlappingSquares(t *testing.T) {
+	a := []Polygon{{Outer: square(0, 0, 2)}}
+	b := []Polygon{{Outer: square(1, 1, 2)}}
+
+	tests := []struct {
+		op    Op
+		area  float64
+		polys int
+	}{
+		{OpUnion, 7, 1},
+		{OpIntersection, 1, 1},
+		{OpDifference, 3, 1},
+		{OpXor, 6, 2},
+	}
+	for _, tt := range tests {
+		got := Boolean(tt.op, a, b)
+		assertArea(t, got, tt.area)
+		assertOrientation(t, got)
+		if len(got) != tt.polys {
+			t.Fatalf("op %d: expected %d polygons, got %d", tt.op, tt.polys, len(got))
+		}
+	}
+
+	union := Union(a, b)
+	if len(union[0].Outer) != 8 || len(union[0].Holes) != 0 {
+		t.Fatalf("expected 8-vertex union outline, got %v", union[0].Outer)
+	}
+}
+
+func TestDifferenceCreatesHole(t *testing.T) {
+	got := Difference([]Polygon{{Outer: square(0, 0, 10)}}, []Polygon{{Outer: square(3, 3, 2)}})
+	if len(got) != 1 || len(got[0].Holes) != 1 {
+		t.Fatalf("expected one polygon with a hole, got %+v", got)
+	}
+	assertArea(t, got, 96)
+	assertOrientation(t, got)
+}
+
+func TestUnionFillsHole(t *testing.T) {
+	ring := Polygon{Outer: square(0, 0, 10), Holes: [][]types.Point{square(3, 3, 4)}}
+	got := Union([]Polygon{ring}, []Polygon{{Outer: square(2, 2, 6)}})
+	if len(got) != 1 || len(got[0].Holes) != 0 {
+		t.Fatalf("expected hole to be filled, got %+v", got)
+	}
+	assertArea(t, got, 100)
+
+	got = Intersection([]Polygon{ring}, []Polygon{{Outer: square(2, 2, 6)}})
+	assertArea(t, got, 36-16)
+}
+
+func TestUnionAllSharedEdges(t *testing.T) {
+	got := UnionAll([]Polygon{
+		{Outer: square(0, 0, 1)},
+		{Outer: square(1, 0, 1)},
+		{Outer: square(1, 1, 1)},
+		{Outer: square(0, 1, 1)},
+	})
+	if len(got) != 1 || len(got[0].Outer) != 4 || len(got[0].Holes) != 0 {
+		t.Fatalf("expected a single square, got %+v", got)
+	}
+	assertArea(t, got, 4)
+}
+
+func TestUnionTouchingAtVertex(t *testing.T) {
+	got := Union([]Polygon{{Outer: square(0, 0, 1)}}, []Polygon{{Outer: square(1, 1, 1)}})
+	if len(got) != 2 {
+		t.Fatalf("expected two separate loops, got %+v", got)
+	}
+	assertArea(t, got, 2)
+}
+
+func TestIdenticalAndDisjoint(t *testing.T) {
+	a := []Polygon{{Outer: square(0, 0, 1)}}
+	if got := Intersection(a, a); len(got) != 1 {
+		t.Fatalf("expected identical intersection, got %+v", got)
+	}
+	if got := Xor(a, a); len(got) != 0 {
+		t.Fatalf("expected empty xor, got %+v", got)
+	}
+	if got := Intersection(a, []Polygon{{Outer: square(5, 5, 1)}}); len(got) != 0 {
+		t.Fatalf("expected empty intersection, got %+v", got)
+	}
+}
+
+func TestClockwiseInputIsNormalized(t *testing.T) {
+	cw := polygon.ReverseIfNeeded(square(0, 0, 2), false)
+	got := Union([]Polygon{{Outer: cw}}, []Polygon{{Outer: square(1, 1, 2)}})
+	assertArea(t, got, 7)
+}
+
+func TestSimplifyBowtie(t *testing.T) {
+	bowtie := []types.Point{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}}
+	got := Simplify([][]types.Point{bowtie}, EvenOdd)
+	if len(got) != 2 {
+		t.Fatalf("expected two triangles, got %+v", got)
+	}
+	assertArea(t, got, 2)
+	assertOrientation(t, got)
+}
+
+func TestSimplifyFillRules(t *testing.T) {
+	// Two overlapping counter-clockwise squares: the overlap has winding 2.
+	loops := [][]types.Point{square(0, 0, 2), square(1, 1, 2)}
+	assertArea(t, Simplify(loops, NonZero), 7)
+	assertArea(t, Simplify(loops, EvenOdd), 6)
+	assertArea(t, Simplify(loops, Negative), 0)
+}
+
+func TestInclusionExclusion(t *testing.T) {
+	a := []Polygon{{Outer: []types.Point{{X: 0, Y: 0}, {X: 5, Y: 1}, {X: 2, Y: 4}}}}
+	b := []Polygon{{Outer: []types.Point{{X: 1, Y: -1}, {X: 4, Y: 3.5}, {X: 0.5, Y: 3}}}}
+
+	union := totalArea(Union(a, b))
+	inter := totalArea(Intersection(a, b))
+	sum := totalArea(a) + totalArea(b)
+	if math.Abs(union+inter-sum) > 1e-9 {
+		t.Fatalf("expected |A∪B| + |A∩B| = |A| + |B|, got %v + %v vs %v", union, inter, sum)
+	}
+	if math.Abs(totalArea(Xor(a, b))-(union-inter)) > 1e-9 {
+		t.Fatalf("expected xor area to equal union minus intersection")
+	}
+}
+
+func TestNestedComponents(t *testing.T) {
+	ring := Polygon{Outer: square(0, 0, 10), Holes: [][]types.Point{square(2, 2, 6)}}
+	island := []Polygon{{Outer: square(4, 4, 2)}, {Outer: square(20, 0, 1)}}
+
+	got := Union([]Polygon{ring}, island)
+	if len(got) != 3 {
+		t.Fatalf("expected three polygons, got %+v", got)
+	}
+	assertArea(t, got, 64+4+1)
+	assertOrientation(t, got)
+	assertArea(t, Intersection([]Polygon{ring}, island), 0)
+
+	// A clip polygon strictly inside the subject, touching nothing.
+	solid := []Polygon{{Outer: square(0, 0, 10)}}
+	assertArea(t, Intersection(solid, island), 4)
+	assertArea(t, Difference(solid, island), 96)
+	assertArea(t, Union(solid, island), 101)
+}
+
+func circle(cx, cy, r float64, n int) []types.Point {
+	out := make([]types.Point, n)
+	for i := range out {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		out[i] = types.Point{X: cx + r*math.Cos(a), Y: cy + r*math.Sin(a)}
+	}
+	return out
+}
+
+func BenchmarkUnionCircles(b *testing.B) {
+	a := []Polygon{{Outer: circle(0, 0, 10, 2000)}}
+	c := []Polygon{{Outer: circle(5, 0, 10, 2000)}}
+	for i := 0; i < b.N; i++ {
+		Union(a, c)
+	}
+}
+
+// assertPlanar fails unless the edges of arr meet only at shared vertices.
+func assertPlanar(t *testing.T, arr *arrangement) {
+	t.Helper()
+	for i, e := range arr.edges {
+		a, b := arr.vertices[e.v[0]], arr.vertices[e.v[1]]
+		for v, p := range arr.vertices {
+			if v != e.v[0] && v != e.v[1] && robust.PointOnSegment(p, a, b) {
+				t.Fatalf("vertex %v lies on edge %v-%v", p, a, b)
+			}
+		}
+		for _, f := range arr.edges[i+1:] {
+			c, d := arr.vertices[f.v[0]], arr.vertices[f.v[1]]
+			if e.v[0] == f.v[0] || e.v[0] == f.v[1] || e.v[1] == f.v[0] || e.v[1] == f.v[1] {
+				continue
+			}
+			if ok, _, _ := robust.SegmentIntersect(a, b, c, d); ok {
+				t.Fatalf("edges %v-%v and %v-%v cross", a, b, c, d)
+			}
+		}
+	}
+}
+
+func TestArrangementNearDegenerateCrossings(t *testing.T) {
+	// Thin triangles fanning out from nearly the same point cross each
+	// other many times close to it, where intersection points are rounded
+	// and merged.
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		var b builder
+		for i := 0; i < 24; i++ {
+			theta := rng.Float64() * math.Pi
+			dx, dy := math.Cos(theta), math.Sin(theta)
+			ox, oy := 1e-9*rng.NormFloat64(), 1e-9*rng.NormFloat64()
+			b.addLoop(i%2, []types.Point{
+				{X: ox - 10*dx, Y: oy - 10*dy},
+				{X: ox + 10*dx, Y: oy + 10*dy},
+				{X: ox + 10*dx - 1e-9*dy, Y: oy + 10*dy + 1e-9*dx},
+			})
+		}
+		assertPlanar(t, b.arrange())
+	}
+}
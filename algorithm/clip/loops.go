@@ -0,0 +1,142 @@
+package clip
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// directedEdge is a result boundary edge with the result on its left.
+type directedEdge struct {
+	from, to int
+}
+
+// stitch links boundary edges into closed loops.
+//
+// At a vertex with several outgoing edges the walk takes the one reached
+// first when turning clockwise from the incoming edge, which keeps the
+// result on the left and splits loops that touch at a vertex.
+func stitch(vertices []types.Point, edges []directedEdge) [][]types.Point {
+	outgoing := make(map[int][]int)
+	for i, e := range edges {
+		outgoing[e.from] = append(outgoing[e.from], i)
+	}
+
+	used := make([]bool, len(edges))
+	var loops [][]types.Point
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+
+		var loop []types.Point
+		cur := start
+		for {
+			used[cur] = true
+			e := edges[cur]
+			loop = append(loop, vertices[e.from])
+
+			v := vertices[e.to]
+			back := math.Atan2(vertices[e.from].Y-v.Y, vertices[e.from].X-v.X)
+			next, best := -1, math.Inf(1)
+			for _, k := range outgoing[e.to] {
+				w := vertices[edges[k].to]
+				turn := back - math.Atan2(w.Y-v.Y, w.X-v.X)
+				if turn <= 0 {
+					turn += 2 * math.Pi
+				}
+				if turn < best {
+					next, best = k, turn
+				}
+			}
+
+			if next < 0 || next == start || used[next] {
+				break
+			}
+			cur = next
+		}
+
+		if loop = removeCollinear(loop); len(loop) >= 3 {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// removeCollinear drops vertices that lie on the line through their
+// neighbours, including spikes that fold back on themselves.
+func removeCollinear(loop []types.Point) []types.Point {
+	for changed := true; changed && len(loop) >= 3; {
+		changed = false
+		for i := 0; i < len(loop) && len(loop) >= 3; i++ {
+			prev := loop[(i+len(loop)-1)%len(loop)]
+			next := loop[(i+1)%len(loop)]
+			if robust.Orient2D(prev, loop[i], next) == 0 {
+				loop = append(loop[:i], loop[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	return loop
+}
+
+// assemble groups stitched loops into polygons. Counter-clockwise loops are
+// outers; each clockwise loop becomes a hole of the smallest outer that
+// contains it.
+func assemble(loops [][]types.Point) []Polygon {
+	var polys []Polygon
+	var areas []float64
+	var holes [][]types.Point
+	for _, loop := range loops {
+		area := polygon.SignedArea(loop)
+		switch {
+		case area > 0:
+			polys = append(polys, Polygon{Outer: loop})
+			areas = append(areas, area)
+		case area < 0:
+			holes = append(holes, loop)
+		}
+	}
+
+	for _, h := range holes {
+		// The midpoint of a hole edge never lies on another result edge.
+		probe := types.Point{X: (h[0].X + h[1].X) / 2, Y: (h[0].Y + h[1].Y) / 2}
+		owner := -1
+		for i, p := range polys {
+			if polygon.PointInPolygon(probe, p.Outer) == polygon.Inside && (owner < 0 || areas[i] < areas[owner]) {
+				owner = i
+			}
+		}
+		if owner >= 0 {
+			polys[owner].Holes = append(polys[owner].Holes, h)
+		}
+	}
+
+	sort.SliceStable(polys, func(i, j int) bool {
+		return below(lowest(polys[i].Outer), lowest(polys[j].Outer))
+	})
+	return polys
+}
+
+// below orders points bottom to top, then left to right.
+func below(p, q types.Point) bool {
+	if p.Y != q.Y {
+		return p.Y < q.Y
+	}
+	return p.X < q.X
+}
+
+// lowest returns the bottom-most, then left-most, point of a loop.
+func lowest(loop []types.Point) types.Point {
+	best := loop[0]
+	for _, p := range loop[1:] {
+		if below(p, best) {
+			best = p
+		}
+	}
+	return best
+}
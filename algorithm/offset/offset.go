@@ -0,0 +1,247 @@
+// Package offset grows or shrinks polygons with holes by a fixed distance.
+//
+// Each loop is offset edge by edge into a raw outline that may overlap
+// itself, and the outlines are then resolved with algorithm/clip using the
+// positive fill rule. Parts of the raw outline that fold back on themselves
+// where a loop narrows to nothing are wound the other way and drop out, so
+// loops that vanish or split under a negative offset are handled without
+// special cases.
+package offset
+
+import (
+	"math"
+
+	"github.com/iceisfun/gomesh/algorithm/clip"
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// JoinType selects how offset edges are connected around convex corners.
+type JoinType int
+
+const (
+	// JoinMiter extends both edges until they meet, falling back to a
+	// square join when the miter would exceed the miter limit.
+	JoinMiter JoinType = iota
+
+	// JoinRound connects the edges with a circular arc.
+	JoinRound
+
+	// JoinSquare cuts the corner off at the offset distance.
+	JoinSquare
+)
+
+// Config holds offsetting options.
+type Config struct {
+	Join JoinType
+
+	// MiterLimit is the largest allowed ratio of miter length to offset
+	// distance before a miter is squared off.
+	MiterLimit float64
+
+	// ArcTolerance is the largest allowed distance between a round join's
+	// arc and the chords that approximate it. It is capped at a quarter of
+	// the offset distance.
+	ArcTolerance float64
+}
+
+// Option configures offsetting.
+type Option func(*Config)
+
+// DefaultConfig returns the default offsetting settings: miter joins with a
+// miter limit of 2 and an arc tolerance of 0.25.
+func DefaultConfig() Config {
+	return Config{
+		Join:         JoinMiter,
+		MiterLimit:   2,
+		ArcTolerance: 0.25,
+	}
+}
+
+// WithJoin sets the join type.
+func WithJoin(join JoinType) Option {
+	return func(c *Config) {
+		c.Join = join
+	}
+}
+
+// WithMiterLimit sets the miter limit. Values below 1 are ignored.
+func WithMiterLimit(limit float64) Option {
+	return func(c *Config) {
+		if limit >= 1 {
+			c.MiterLimit = limit
+		}
+	}
+}
+
+// WithArcTolerance sets the arc tolerance for round joins. Non-positive
+// values are ignored.
+func WithArcTolerance(tolerance float64) Option {
+	return func(c *Config) {
+		if tolerance > 0 {
+			c.ArcTolerance = tolerance
+		}
+	}
+}
+
+// Polygons offsets polygons by delta.
+//
+// A positive delta grows the polygons and shrinks their holes; a negative
+// delta shrinks the polygons and grows their holes. Overlapping results are
+// merged. Loop orientation in the input does not matter.
+func Polygons(polys []clip.Polygon, delta float64, opts ...Option) []clip.Polygon {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var raw [][]types.Point
+	for _, p := range polys {
+		raw = append(raw, cfg.offsetLoop(polygon.ReverseIfNeeded(p.Outer, true), delta))
+		for _, h := range p.Holes {
+			raw = append(raw, cfg.offsetLoop(polygon.ReverseIfNeeded(h, false), delta))
+		}
+	}
+	return clip.Simplify(raw, clip.Positive)
+}
+
+// Polygon offsets a single polygon by delta. See Polygons.
+func Polygon(p clip.Polygon, delta float64, opts ...Option) []clip.Polygon {
+	return Polygons([]clip.Polygon{p}, delta, opts...)
+}
+
+// Loop offsets a single loop, treated as an outer boundary, by delta.
+func Loop(loop []types.Point, delta float64, opts ...Option) []clip.Polygon {
+	return Polygons([]clip.Polygon{{Outer: loop}}, delta, opts...)
+}
+
+// offsetLoop builds the raw offset of an oriented loop. Moving along a
+// counter-clockwise outer or a clockwise hole, the material lies on the
+// left, so a positive delta moves each edge to its right.
+func (cfg Config) offsetLoop(loop []types.Point, delta float64) []types.Point {
+	loop = dedupe(loop)
+	n := len(loop)
+	if n < 3 || delta == 0 {
+		return loop
+	}
+
+	normals := make([]types.Point, n)
+	for i := range loop {
+		a, b := loop[i], loop[(i+1)%n]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		l := math.Hypot(dx, dy)
+		normals[i] = types.Point{X: dy / l, Y: -dx / l}
+	}
+
+	var out []types.Point
+	for i, p := range loop {
+		n1, n2 := normals[(i+n-1)%n], normals[i]
+		sin := n1.X*n2.Y - n1.Y*n2.X
+		cos := n1.X*n2.X + n1.Y*n2.Y
+		a := shift(p, n1, delta)
+		b := shift(p, n2, delta)
+
+		switch {
+		case robust.Orient2D(loop[(i+n-1)%n], p, loop[(i+1)%n]) == 0 && cos > 0:
+			// Straight through: both offset edges meet at a single point.
+			out = append(out, a)
+		case sin*delta > 0 || (sin == 0 && cos < 0):
+			// The corner opens up away from the material: fill the gap
+			// with a join.
+			out = cfg.join(out, p, n1, n2, sin, cos, delta)
+		default:
+			// The offset edges overlap at this corner. Routing the outline
+			// through the corner itself keeps the overlap consistently
+			// wound so the fill rule removes it.
+			out = append(out, a, p, b)
+		}
+	}
+	return out
+}
+
+// join appends the points connecting the offset edges at corner p.
+func (cfg Config) join(out []types.Point, p, n1, n2 types.Point, sin, cos, delta float64) []types.Point {
+	switch cfg.Join {
+	case JoinRound:
+		return cfg.round(out, p, n1, n2, sin, cos, delta)
+	case JoinMiter:
+		// The miter length relative to delta is sqrt(2/(1+cos)).
+		if 1+cos >= 2/(cfg.MiterLimit*cfg.MiterLimit) {
+			k := delta / (1 + cos)
+			return append(out, types.Point{X: p.X + (n1.X+n2.X)*k, Y: p.Y + (n1.Y+n2.Y)*k})
+		}
+	}
+	return squareJoin(out, p, n1, n2, delta)
+}
+
+// squareJoin cuts the corner with a line perpendicular to the bisector of the
+// offset directions at distance |delta| from p. When the loop reverses
+// direction at p, the cut faces along the incoming edge.
+func squareJoin(out []types.Point, p, n1, n2 types.Point, delta float64) []types.Point {
+	r := math.Abs(delta)
+	sign := math.Copysign(1, delta)
+	d1 := types.Point{X: n1.X * sign, Y: n1.Y * sign}
+	d2 := types.Point{X: n2.X * sign, Y: n2.Y * sign}
+	t1 := types.Point{X: -n1.Y, Y: n1.X}
+	t2 := types.Point{X: -n2.Y, Y: n2.X}
+
+	b := types.Point{X: d1.X + d2.X, Y: d1.Y + d2.Y}
+	if l := math.Hypot(b.X, b.Y); l > 1e-12 {
+		b = types.Point{X: b.X / l, Y: b.Y / l}
+	} else {
+		b = t1
+	}
+
+	// Slide along each offset edge from its normal point to the cut line.
+	cut := func(d, t types.Point) types.Point {
+		s := r * (1 - dot(d, b)) / dot(t, b)
+		return types.Point{X: p.X + r*d.X + s*t.X, Y: p.Y + r*d.Y + s*t.Y}
+	}
+	return append(out, cut(d1, t1), cut(d2, t2))
+}
+
+// round approximates the arc from n1 to n2 around p.
+func (cfg Config) round(out []types.Point, p, n1, n2 types.Point, sin, cos, delta float64) []types.Point {
+	r := math.Abs(delta)
+	tol := math.Min(cfg.ArcTolerance, r/4)
+	step := 2 * math.Acos(1-tol/r)
+
+	angle := math.Atan2(sin, cos)
+	if sin == 0 && cos < 0 {
+		angle = math.Copysign(math.Pi, delta)
+	}
+	steps := int(math.Ceil(math.Abs(angle) / step))
+	if steps < 1 {
+		steps = 1
+	}
+
+	start := math.Atan2(n1.Y, n1.X)
+	for k := 0; k <= steps; k++ {
+		theta := start + angle*float64(k)/float64(steps)
+		out = append(out, types.Point{X: p.X + delta*math.Cos(theta), Y: p.Y + delta*math.Sin(theta)})
+	}
+	return out
+}
+
+func dot(a, b types.Point) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+func shift(p, n types.Point, delta float64) types.Point {
+	return types.Point{X: p.X + n.X*delta, Y: p.Y + n.Y*delta}
+}
+
+// dedupe removes consecutive repeated points, which have no edge normal.
+func dedupe(loop []types.Point) []types.Point {
+	out := make([]types.Point, 0, len(loop))
+	for _, p := range loop {
+		if len(out) == 0 || out[len(out)-1] != p {
+			out = append(out, p)
+		}
+	}
+	for len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package offset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/clip"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func square(x, y, size float64) []types.Point {
+	return []types.Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+}
+
+func totalArea(polys []clip.Polygon) float64 {
+	area := 0.0
+	for _, p := range polys {
+		area += p.Area()
+	}
+	return area
+}
+
+func assertArea(t *testing.T, polys []clip.Polygon, want, tol float64) {
+	t.Helper()
+	if got := totalArea(polys); math.Abs(got-want) > tol {
+		t.Fatalf("expected area %v, got %v (%+v)", want, got, polys)
+	}
+}
+
+func TestOffsetSquareJoins(t *testing.T) {
+	sq := square(0, 0, 10)
+
+	grown := Loop(sq, 1)
+	assertArea(t, grown, 144, 1e-9)
+	if len(grown) != 1 || len(grown[0].Outer) != 4 {
+		t.Fatalf("expected mitered square, got %+v", grown)
+	}
+
+	assertArea(t, Loop(sq, -1), 64, 1e-9)
+	assertArea(t, Loop(sq, 1, WithJoin(JoinSquare)), 140+4*(2*math.Sqrt2-2), 1e-9)
+	assertArea(t, Loop(sq, 1, WithJoin(JoinRound), WithArcTolerance(0.001)), 140+math.Pi, 0.01)
+
+	// A miter limit below sqrt(2) squares off right angles.
+	assertArea(t, Loop(sq, 1, WithMiterLimit(1.2)), 140+4*(2*math.Sqrt2-2), 1e-9)
+}
+
+func TestOffsetConcaveLoop(t *testing.T) {
+	l := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 10}, {X: 0, Y: 10}}
+
+	assertArea(t, Loop(l, -1), 28, 1e-9)
+	assertArea(t, Loop(l, 1), 12*12-6*6, 1e-9)
+
+	round := Loop(l, -1, WithJoin(JoinRound))
+	if len(round) != 1 || totalArea(round) <= 28 {
+		t.Fatalf("expected rounded inner corner to add area, got %+v", round)
+	}
+}
+
+func TestOffsetVanishesAndSplits(t *testing.T) {
+	if got := Loop([]types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 1}, {X: 0, Y: 1}}, -1); len(got) != 0 {
+		t.Fatalf("expected thin rectangle to vanish, got %+v", got)
+	}
+
+	dumbbell := []types.Point{
+		{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 1.5}, {X: 6, Y: 1.5}, {X: 6, Y: 0}, {X: 10, Y: 0},
+		{X: 10, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 2.5}, {X: 4, Y: 2.5}, {X: 4, Y: 4}, {X: 0, Y: 4},
+	}
+	got := Loop(dumbbell, -0.75)
+	if len(got) != 2 {
+		t.Fatalf("expected dumbbell to split in two, got %+v", got)
+	}
+	assertArea(t, got, 2*2.5*2.5, 1e-9)
+}
+
+func TestOffsetHoles(t *testing.T) {
+	ring := clip.Polygon{Outer: square(0, 0, 10), Holes: [][]types.Point{square(3, 3, 4)}}
+
+	grown := Polygon(ring, 1)
+	if len(grown) != 1 || len(grown[0].Holes) != 1 {
+		t.Fatalf("expected shrunken hole, got %+v", grown)
+	}
+	assertArea(t, grown, 144-4, 1e-9)
+
+	closed := Polygon(ring, 2.5)
+	if len(closed) != 1 || len(closed[0].Holes) != 0 {
+		t.Fatalf("expected hole to close, got %+v", closed)
+	}
+	assertArea(t, closed, 225, 1e-9)
+
+	assertArea(t, Polygon(ring, -1), 64-36, 1e-9)
+}
+
+func TestOffsetMergesNeighbours(t *testing.T) {
+	got := Polygons([]clip.Polygon{{Outer: square(0, 0, 4)}, {Outer: square(5, 0, 4)}}, 1)
+	if len(got) != 1 {
+		t.Fatalf("expected grown squares to merge, got %+v", got)
+	}
+	assertArea(t, got, 6*11, 1e-9)
+}
@@ -0,0 +1,240 @@
+// Package repair turns dirty, user-drawn loops into simple polygons with
+// holes that cdt.Build and mesh.AddPerimeter accept.
+//
+// Each loop is cleaned of invalid coordinates, duplicate points, spikes and
+// collinear vertices, then self-intersections and overlaps are resolved with
+// algorithm/clip using the configured fill rule. Every change is recorded in
+// the returned Report.
+package repair
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iceisfun/gomesh/algorithm/clip"
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/pslg"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Config holds repair settings.
+type Config struct {
+	// FillRule decides which parts of a self-intersecting loop are inside.
+	FillRule clip.FillRule
+
+	// Epsilon controls when consecutive points are merged.
+	Epsilon types.Epsilon
+
+	// MinArea drops output polygons and holes smaller than this.
+	MinArea float64
+}
+
+// Option configures repair.
+type Option func(*Config)
+
+// DefaultConfig returns the default repair settings: even-odd filling, the
+// default epsilon and no minimum area.
+func DefaultConfig() Config {
+	return Config{
+		FillRule: clip.EvenOdd,
+		Epsilon:  types.DefaultEpsilon(),
+	}
+}
+
+// WithFillRule sets the fill rule used to resolve self-intersections.
+func WithFillRule(rule clip.FillRule) Option {
+	return func(c *Config) {
+		c.FillRule = rule
+	}
+}
+
+// WithEpsilon sets the tolerance used to merge duplicate points.
+func WithEpsilon(eps types.Epsilon) Option {
+	return func(c *Config) {
+		c.Epsilon = eps
+	}
+}
+
+// WithMinArea drops output polygons and holes with area below minArea.
+func WithMinArea(minArea float64) Option {
+	return func(c *Config) {
+		if minArea >= 0 {
+			c.MinArea = minArea
+		}
+	}
+}
+
+// Polygon repairs an outer loop and its holes.
+//
+// Loops may use either orientation. Each loop is resolved on its own with
+// the fill rule, and the holes are then subtracted from the outer region, so
+// holes that cross the outer loop are clipped to it. A self-intersecting
+// outer loop may produce several polygons.
+func Polygon(outer []types.Point, holes [][]types.Point, opts ...Option) ([]clip.Polygon, Report) {
+	cfg := applyOptions(opts)
+	var report Report
+
+	region := cfg.resolve(outer, 0, true, &report)
+
+	var cut []clip.Polygon
+	for i, hole := range holes {
+		loop := i + 1
+		hp := cfg.resolve(hole, loop, false, &report)
+		if len(hp) == 0 {
+			continue
+		}
+		area := totalArea(hp)
+		if inside := totalArea(clip.Intersection(hp, region)); inside < area*(1-1e-9) {
+			report.add(ChangeHoleClipped, loop, types.Point{}, fmt.Sprintf("%.6g of %.6g area inside outer", inside, area))
+		}
+		cut = append(cut, hp...)
+	}
+
+	result := region
+	if len(cut) > 0 {
+		result = clip.Difference(region, cut)
+	}
+	return cfg.dropSmall(result, &report), report
+}
+
+// Loops repairs a set of loops that together describe a region.
+//
+// Unlike Polygon, loops are not split into outers and holes up front; all
+// loops are resolved together with the fill rule. With EvenOdd, nested loops
+// become holes regardless of orientation; with NonZero, Positive or
+// Negative, orientation decides.
+func Loops(loops [][]types.Point, opts ...Option) ([]clip.Polygon, Report) {
+	cfg := applyOptions(opts)
+	var report Report
+
+	var cleaned [][]types.Point
+	for i, loop := range loops {
+		if c := cfg.clean(loop, i, &report); c != nil {
+			if pslg.LoopSelfIntersections(c) != nil {
+				report.add(ChangeSelfIntersection, i, types.Point{}, "")
+			}
+			cleaned = append(cleaned, c)
+		}
+	}
+
+	return cfg.dropSmall(clip.Simplify(cleaned, cfg.FillRule), &report), report
+}
+
+func applyOptions(opts []Option) Config {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// resolve cleans a single loop, fixes its orientation and splits it into
+// simple polygons. Outers are expected counter-clockwise and holes
+// clockwise; the returned region is always counter-clockwise.
+func (cfg Config) resolve(loop []types.Point, idx int, outer bool, report *Report) []clip.Polygon {
+	cleaned := cfg.clean(loop, idx, report)
+	if cleaned == nil {
+		return nil
+	}
+
+	if area := polygon.SignedArea(cleaned); area != 0 && (area > 0) != outer {
+		report.add(ChangeWinding, idx, types.Point{}, "")
+	}
+	cleaned = polygon.ReverseIfNeeded(cleaned, true)
+
+	polys := clip.Simplify([][]types.Point{cleaned}, cfg.FillRule)
+	if len(polys) == 0 {
+		report.add(ChangeDegenerateLoop, idx, types.Point{}, "no area")
+		return nil
+	}
+	if pslg.LoopSelfIntersections(cleaned) != nil {
+		report.add(ChangeSelfIntersection, idx, types.Point{}, fmt.Sprintf("split into %d polygons", len(polys)))
+	}
+	return polys
+}
+
+// clean removes invalid coordinates, duplicate points, spikes and collinear
+// vertices. It returns nil if fewer than three points remain.
+func (cfg Config) clean(loop []types.Point, idx int, report *Report) []types.Point {
+	out := make([]types.Point, 0, len(loop))
+	for _, p := range loop {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) {
+			report.add(ChangeInvalidPoint, idx, p, "")
+			continue
+		}
+		out = append(out, p)
+	}
+
+	// Removing a spike can leave its base point duplicated, so keep going
+	// until a pass makes no changes.
+	for changed := true; changed && len(out) >= 3; {
+		changed = false
+		for i := 0; i < len(out) && len(out) >= 3; i++ {
+			prev := out[(i+len(out)-1)%len(out)]
+			cur := out[i]
+			next := out[(i+1)%len(out)]
+
+			switch {
+			case cfg.same(prev, cur) || cfg.same(cur, next):
+				report.add(ChangeDuplicatePoint, idx, cur, "")
+			case robust.Orient2D(prev, cur, next) == 0:
+				kind := ChangeCollinearPoint
+				if (prev.X-cur.X)*(next.X-cur.X)+(prev.Y-cur.Y)*(next.Y-cur.Y) > 0 {
+					kind = ChangeSpike
+				}
+				report.add(kind, idx, cur, "")
+			default:
+				continue
+			}
+
+			out = append(out[:i], out[i+1:]...)
+			changed = true
+			i--
+		}
+	}
+
+	if len(out) < 3 {
+		report.add(ChangeDegenerateLoop, idx, types.Point{}, fmt.Sprintf("%d points remain", len(out)))
+		return nil
+	}
+	return out
+}
+
+func (cfg Config) same(a, b types.Point) bool {
+	return math.Hypot(a.X-b.X, a.Y-b.Y) <= cfg.Epsilon.MergeDistance(a, b)
+}
+
+// dropSmall removes polygons and holes below the minimum area.
+func (cfg Config) dropSmall(polys []clip.Polygon, report *Report) []clip.Polygon {
+	if cfg.MinArea <= 0 {
+		return polys
+	}
+
+	var out []clip.Polygon
+	for _, p := range polys {
+		if math.Abs(polygon.SignedArea(p.Outer)) < cfg.MinArea {
+			report.add(ChangeSmallPolygon, -1, p.Outer[0], "polygon")
+			continue
+		}
+		holes := p.Holes[:0:0]
+		for _, h := range p.Holes {
+			if math.Abs(polygon.SignedArea(h)) < cfg.MinArea {
+				report.add(ChangeSmallPolygon, -1, h[0], "hole")
+				continue
+			}
+			holes = append(holes, h)
+		}
+		p.Holes = holes
+		out = append(out, p)
+	}
+	return out
+}
+
+func totalArea(polys []clip.Polygon) float64 {
+	area := 0.0
+	for _, p := range polys {
+		area += p.Area()
+	}
+	return area
+}
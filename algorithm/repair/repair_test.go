@@ -0,0 +1,126 @@
+package repair
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/clip"
+	"github.com/iceisfun/gomesh/algorithm/pslg"
+	"github.com/iceisfun/gomesh/cdt"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func square(x, y, size float64) []types.Point {
+	return []types.Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+}
+
+// assertValid checks that each polygon passes the PSLG checks cdt.Build
+// runs on its input.
+func assertValid(t *testing.T, polys []clip.Polygon) {
+	t.Helper()
+	eps := types.DefaultEpsilon()
+	for i, p := range polys {
+		if err := pslg.ValidateLoops(p.Outer, p.Holes, eps); err != nil {
+			t.Fatalf("polygon %d invalid: %v (%+v)", i, err, p)
+		}
+		normalized, err := cdt.NormalizePSLG(p.Outer, p.Holes, nil, eps)
+		if err == nil {
+			err = cdt.ValidatePSLG(normalized)
+		}
+		if err != nil {
+			t.Fatalf("polygon %d rejected by cdt: %v (%+v)", i, err, p)
+		}
+	}
+}
+
+func TestCleanPolygonUnchanged(t *testing.T) {
+	polys, report := Polygon(square(0, 0, 10), [][]types.Point{{{X: 2, Y: 2}, {X: 2, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 2}}})
+	if report.Changed() {
+		t.Fatalf("expected no changes, got %v", report)
+	}
+	if len(polys) != 1 || len(polys[0].Holes) != 1 {
+		t.Fatalf("expected polygon with one hole, got %+v", polys)
+	}
+	assertValid(t, polys)
+}
+
+func TestRepairBowtie(t *testing.T) {
+	bowtie := []types.Point{{X: 0, Y: 0}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 4}}
+	polys, report := Polygon(bowtie, nil)
+	if len(polys) != 2 {
+		t.Fatalf("expected bow-tie to split into two polygons, got %+v", polys)
+	}
+	if report.Count(ChangeSelfIntersection) != 1 || report.Count(ChangeWinding) != 0 {
+		t.Fatalf("expected self-intersection to be reported, got %v", report)
+	}
+	assertValid(t, polys)
+}
+
+func TestRepairDirtyLoop(t *testing.T) {
+	// Clockwise, with a repeated point, a spike and a collinear vertex.
+	dirty := []types.Point{
+		{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 0, Y: 10}, {X: 5, Y: 10}, {X: 10, Y: 10},
+		{X: 10, Y: 0}, {X: 12, Y: 0}, {X: 10, Y: 0}, {X: math.NaN(), Y: 1},
+	}
+	polys, report := Polygon(dirty, nil)
+	for kind, want := range map[ChangeKind]int{
+		ChangeInvalidPoint:   1,
+		ChangeDuplicatePoint: 2,
+		ChangeSpike:          1,
+		ChangeCollinearPoint: 1,
+		ChangeWinding:        1,
+	} {
+		if got := report.Count(kind); got != want {
+			t.Fatalf("expected %d %s changes, got %d\n%v", want, kind, got, report)
+		}
+	}
+	if len(polys) != 1 || len(polys[0].Outer) != 4 || polys[0].Area() != 100 {
+		t.Fatalf("expected a clean square, got %+v", polys)
+	}
+	assertValid(t, polys)
+}
+
+func TestRepairClipsHoles(t *testing.T) {
+	polys, report := Polygon(square(0, 0, 10), [][]types.Point{square(8, 4, 4), square(20, 20, 1)})
+	if report.Count(ChangeHoleClipped) != 2 {
+		t.Fatalf("expected both holes to be reported as clipped, got %v", report)
+	}
+	if len(polys) != 1 || len(polys[0].Holes) != 0 || math.Abs(polys[0].Area()-92) > 1e-9 {
+		t.Fatalf("expected notched square of area 92, got %+v", polys)
+	}
+	assertValid(t, polys)
+}
+
+func TestRepairDegenerate(t *testing.T) {
+	polys, report := Polygon([]types.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}, nil)
+	if len(polys) != 0 || report.Count(ChangeDegenerateLoop) != 1 {
+		t.Fatalf("expected degenerate loop to be dropped, got %+v %v", polys, report)
+	}
+}
+
+func TestLoopsFillRules(t *testing.T) {
+	// Both loops counter-clockwise: even-odd makes the inner one a hole,
+	// non-zero fills it.
+	loops := [][]types.Point{square(0, 0, 10), square(2, 2, 2)}
+
+	polys, _ := Loops(loops)
+	if len(polys) != 1 || len(polys[0].Holes) != 1 {
+		t.Fatalf("expected even-odd to produce a hole, got %+v", polys)
+	}
+	assertValid(t, polys)
+
+	polys, _ = Loops(loops, WithFillRule(clip.NonZero))
+	if len(polys) != 1 || len(polys[0].Holes) != 0 {
+		t.Fatalf("expected non-zero to fill the inner loop, got %+v", polys)
+	}
+}
+
+func TestMinArea(t *testing.T) {
+	polys, report := Loops([][]types.Point{square(0, 0, 10), square(20, 0, 0.5), square(2, 2, 0.1)}, WithMinArea(1))
+	if len(polys) != 1 || len(polys[0].Holes) != 0 {
+		t.Fatalf("expected only the large square to remain, got %+v", polys)
+	}
+	if report.Count(ChangeSmallPolygon) != 2 {
+		t.Fatalf("expected two small polygons to be reported, got %v", report)
+	}
+}
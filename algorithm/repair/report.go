@@ -0,0 +1,98 @@
+package repair
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+// ChangeKind classifies a repair.
+type ChangeKind string
+
+const (
+	// ChangeInvalidPoint records a NaN or infinite coordinate that was dropped.
+	ChangeInvalidPoint ChangeKind = "invalid_point"
+
+	// ChangeDuplicatePoint records a point merged into its predecessor.
+	ChangeDuplicatePoint ChangeKind = "duplicate_point"
+
+	// ChangeSpike records a vertex where the loop folded back on itself.
+	ChangeSpike ChangeKind = "spike"
+
+	// ChangeCollinearPoint records a vertex removed from a straight run.
+	ChangeCollinearPoint ChangeKind = "collinear_point"
+
+	// ChangeDegenerateLoop records a loop dropped for having no area.
+	ChangeDegenerateLoop ChangeKind = "degenerate_loop"
+
+	// ChangeWinding records a loop whose orientation was reversed.
+	ChangeWinding ChangeKind = "winding"
+
+	// ChangeSelfIntersection records a self-intersecting loop that was split
+	// into simple polygons.
+	ChangeSelfIntersection ChangeKind = "self_intersection"
+
+	// ChangeHoleClipped records a hole that extended outside its outer loop.
+	ChangeHoleClipped ChangeKind = "hole_clipped"
+
+	// ChangeSmallPolygon records an output polygon or hole dropped for
+	// being smaller than the minimum area.
+	ChangeSmallPolygon ChangeKind = "small_polygon"
+)
+
+// Change describes a single repair.
+type Change struct {
+	Kind ChangeKind
+
+	// Loop is the index of the affected input loop. For Polygon the outer
+	// loop is 0 and hole i is i+1; it is -1 for changes to the output.
+	Loop int
+
+	// Point is the affected input point, where there is one.
+	Point types.Point
+
+	Detail string
+}
+
+// Report lists the changes made by a repair.
+type Report struct {
+	Changes []Change
+}
+
+// Changed reports whether the input needed any repair.
+func (r Report) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Count returns the number of changes of the given kind.
+func (r Report) Count(kind ChangeKind) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// String returns a human-readable summary of the report.
+func (r Report) String() string {
+	if !r.Changed() {
+		return "no changes"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d changes:\n", len(r.Changes))
+	for _, c := range r.Changes {
+		fmt.Fprintf(&sb, "  loop %d: %s", c.Loop, c.Kind)
+		if c.Detail != "" {
+			fmt.Fprintf(&sb, " (%s)", c.Detail)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func (r *Report) add(kind ChangeKind, loop int, p types.Point, detail string) {
+	r.Changes = append(r.Changes, Change{Kind: kind, Loop: loop, Point: p, Detail: detail})
+}
@@ -0,0 +1,381 @@
+package robust
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+// orient2DBig and inCircleBig are the previous predicates: a coarse float64
+// filter with a math/big.Float fallback. They are kept as the baseline for
+// the benchmarks.
+
+const bigFilter = 1e-15
+
+func orient2DBig(a, b, c types.Point) int {
+	ax := b.X - a.X
+	ay := b.Y - a.Y
+	bx := c.X - a.X
+	by := c.Y - a.Y
+	det := ax*by - ay*bx
+
+	maxMag := maxAbs(a.X, a.Y, b.X, b.Y, c.X, c.Y)
+	eps := maxMag * maxMag * bigFilter
+	if eps < bigFilter {
+		eps = bigFilter
+	}
+
+	switch {
+	case det > eps:
+		return 1
+	case det < -eps:
+		return -1
+	}
+
+	dx1 := bigFloat(b.X)
+	dx1.Sub(dx1, bigFloat(a.X))
+	dy1 := bigFloat(b.Y)
+	dy1.Sub(dy1, bigFloat(a.Y))
+	dx2 := bigFloat(c.X)
+	dx2.Sub(dx2, bigFloat(a.X))
+	dy2 := bigFloat(c.Y)
+	dy2.Sub(dy2, bigFloat(a.Y))
+	return det2(dx1, dy1, dx2, dy2).Sign()
+}
+
+func inCircleBig(a, b, c, d types.Point) int {
+	adx := a.X - d.X
+	ady := a.Y - d.Y
+	bdx := b.X - d.X
+	bdy := b.Y - d.Y
+	cdx := c.X - d.X
+	cdy := c.Y - d.Y
+
+	ad2 := adx*adx + ady*ady
+	bd2 := bdx*bdx + bdy*bdy
+	cd2 := cdx*cdx + cdy*cdy
+
+	det := ad2*(bdx*cdy-bdy*cdx) -
+		bd2*(adx*cdy-ady*cdx) +
+		cd2*(adx*bdy-ady*bdx)
+
+	maxMag := maxAbs(adx, ady, bdx, bdy, cdx, cdy)
+	eps := math.Pow(maxMag, 3) * bigFilter
+	if eps < bigFilter {
+		eps = bigFilter
+	}
+
+	switch {
+	case det > eps:
+		return 1
+	case det < -eps:
+		return -1
+	}
+
+	ax, ay := bigFloat(adx), bigFloat(ady)
+	bx, by := bigFloat(bdx), bigFloat(bdy)
+	cx, cy := bigFloat(cdx), bigFloat(cdy)
+	lift := func(x, y *big.Float) *big.Float {
+		out := bigFloat(0).Mul(x, x)
+		return out.Add(out, bigFloat(0).Mul(y, y))
+	}
+	sum := bigFloat(0).Mul(lift(ax, ay), det2(bx, by, cx, cy))
+	sum.Sub(sum, bigFloat(0).Mul(lift(bx, by), det2(ax, ay, cx, cy)))
+	sum.Add(sum, bigFloat(0).Mul(lift(cx, cy), det2(ax, ay, bx, by)))
+	return sum.Sign()
+}
+
+// ratOrient2D and ratInCircle are slow exact references.
+
+func ratOrient2D(a, b, c types.Point) int {
+	r := func(v float64) *big.Rat { return new(big.Rat).SetFloat64(v) }
+	acx := new(big.Rat).Sub(r(a.X), r(c.X))
+	acy := new(big.Rat).Sub(r(a.Y), r(c.Y))
+	bcx := new(big.Rat).Sub(r(b.X), r(c.X))
+	bcy := new(big.Rat).Sub(r(b.Y), r(c.Y))
+	left := new(big.Rat).Mul(acx, bcy)
+	right := new(big.Rat).Mul(acy, bcx)
+	return left.Cmp(right)
+}
+
+func ratInCircle(a, b, c, d types.Point) int {
+	r := func(v float64) *big.Rat { return new(big.Rat).SetFloat64(v) }
+	diff := func(p types.Point) (*big.Rat, *big.Rat) {
+		return new(big.Rat).Sub(r(p.X), r(d.X)), new(big.Rat).Sub(r(p.Y), r(d.Y))
+	}
+	ax, ay := diff(a)
+	bx, by := diff(b)
+	cx, cy := diff(c)
+	cross := func(px, py, qx, qy *big.Rat) *big.Rat {
+		return new(big.Rat).Sub(new(big.Rat).Mul(px, qy), new(big.Rat).Mul(py, qx))
+	}
+	lift := func(x, y *big.Rat) *big.Rat {
+		return new(big.Rat).Add(new(big.Rat).Mul(x, x), new(big.Rat).Mul(y, y))
+	}
+	sum := new(big.Rat).Mul(lift(ax, ay), cross(bx, by, cx, cy))
+	sum.Sub(sum, new(big.Rat).Mul(lift(bx, by), cross(ax, ay, cx, cy)))
+	sum.Add(sum, new(big.Rat).Mul(lift(cx, cy), cross(ax, ay, bx, by)))
+	return sum.Sign()
+}
+
+// Degenerate datasets. Each is deterministic.
+
+// gridCollinear returns triples of integer points on common lines, the
+// typical CAD case: every determinant is exactly zero.
+func gridCollinear(n int) [][3]types.Point {
+	rng := rand.New(rand.NewSource(1))
+	out := make([][3]types.Point, n)
+	for i := range out {
+		ox, oy := float64(rng.Intn(2000)-1000), float64(rng.Intn(2000)-1000)
+		dx, dy := float64(rng.Intn(20)-10), float64(rng.Intn(20)-10)
+		s, t := float64(rng.Intn(50)), float64(rng.Intn(50))
+		out[i] = [3]types.Point{
+			{X: ox, Y: oy},
+			{X: ox + s*dx, Y: oy + s*dy},
+			{X: ox + t*dx, Y: oy + t*dy},
+		}
+	}
+	return out
+}
+
+// nearCollinear returns triples whose third point is a rounded
+// interpolation of the first two, far from the origin, so the differences
+// themselves are inexact.
+func nearCollinear(n int) [][3]types.Point {
+	rng := rand.New(rand.NewSource(2))
+	out := make([][3]types.Point, n)
+	for i := range out {
+		a := types.Point{X: 1e6 + rng.Float64(), Y: 4e6 + rng.Float64()}
+		b := types.Point{X: 1e6 + rng.Float64(), Y: 4e6 + rng.Float64()}
+		t := rng.Float64()
+		out[i] = [3]types.Point{a, b, {X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)}}
+	}
+	return out
+}
+
+// ulpCollinear perturbs a point of the line y = x by a few ulps against
+// two distant points of the same line, Shewchuk's classic failure case for
+// float64 orientation. The differences are inexact, which exercises every
+// adaptive stage.
+func ulpCollinear() [][3]types.Point {
+	var out [][3]types.Point
+	u := math.Ldexp(1, -53)
+	for i := -16; i < 16; i++ {
+		for j := -16; j < 16; j++ {
+			a := types.Point{X: 0.5 + float64(i)*u, Y: 0.5 + float64(j)*u}
+			out = append(out, [3]types.Point{a, {X: 12, Y: 12}, {X: 24, Y: 24}})
+		}
+	}
+	return out
+}
+
+// ulpCocircular perturbs one corner of a square by a few ulps against its
+// other three corners.
+func ulpCocircular() [][4]types.Point {
+	var out [][4]types.Point
+	u := math.Ldexp(1, -53)
+	for i := -16; i < 16; i++ {
+		for j := -16; j < 16; j++ {
+			a := types.Point{X: 0.5 + float64(i)*u, Y: 0.5 + float64(j)*u}
+			out = append(out, [4]types.Point{a, {X: 23.5, Y: 0.5}, {X: 23.5, Y: 23.5}, {X: 0.5, Y: 23.5}})
+		}
+	}
+	return out
+}
+
+// rectCocircular returns the corners of rectangles with random float64
+// sides. The corners are exactly cocircular, but their differences are
+// inexact, so InCircle can only settle them with the exact determinant of
+// its last stage.
+func rectCocircular(n int) [][4]types.Point {
+	rng := rand.New(rand.NewSource(5))
+	out := make([][4]types.Point, n)
+	for i := range out {
+		x0, x1 := rng.Float64(), 1e3*(1+rng.Float64())
+		y0, y1 := -rng.Float64(), 1e2*(1+rng.Float64())
+		out[i] = [4]types.Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+	}
+	return out
+}
+
+// wideRectCocircular is rectCocircular with coordinates spread over many
+// orders of magnitude, which lengthens the expansions of the last stage.
+func wideRectCocircular(n int) [][4]types.Point {
+	rng := rand.New(rand.NewSource(9))
+	out := make([][4]types.Point, n)
+	for i := range out {
+		small, large := 1e-5, 1e7
+		if i%2 == 1 {
+			small, large = 1e-200, 1e200
+		}
+		x0, x1 := small*rng.Float64(), large*(1+rng.Float64())
+		y0, y1 := -small*rng.Float64(), large*(1+rng.Float64())
+		out[i] = [4]types.Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+	}
+	return out
+}
+
+// gridCocircular returns quadruples of integer points on circles of radius
+// 5 or 25, where every incircle determinant is exactly zero.
+func gridCocircular(n int) [][4]types.Point {
+	ring := []types.Point{
+		{X: 5, Y: 0}, {X: 4, Y: 3}, {X: 3, Y: 4}, {X: 0, Y: 5},
+		{X: -3, Y: 4}, {X: -4, Y: 3}, {X: -5, Y: 0}, {X: -4, Y: -3},
+		{X: -3, Y: -4}, {X: 0, Y: -5}, {X: 3, Y: -4}, {X: 4, Y: -3},
+	}
+	rng := rand.New(rand.NewSource(3))
+	out := make([][4]types.Point, n)
+	for i := range out {
+		ox, oy := float64(rng.Intn(2000)-1000), float64(rng.Intn(2000)-1000)
+		scale := float64(1 + 4*rng.Intn(2))
+		perm := rng.Perm(len(ring))
+		for j := range out[i] {
+			p := ring[perm[j]]
+			out[i][j] = types.Point{X: ox + scale*p.X, Y: oy + scale*p.Y}
+		}
+	}
+	return out
+}
+
+// nearCocircular returns quadruples of rounded points on a unit circle far
+// from the origin.
+func nearCocircular(n int) [][4]types.Point {
+	rng := rand.New(rand.NewSource(4))
+	out := make([][4]types.Point, n)
+	for i := range out {
+		ox, oy := 1e5+rng.Float64(), -3e5+rng.Float64()
+		for j := range out[i] {
+			theta := rng.Float64() * 2 * math.Pi
+			out[i][j] = types.Point{X: ox + math.Cos(theta), Y: oy + math.Sin(theta)}
+		}
+	}
+	return out
+}
+
+func TestOrient2DMatchesExact(t *testing.T) {
+	sets := map[string][][3]types.Point{
+		"grid collinear": gridCollinear(2000),
+		"near collinear": nearCollinear(2000),
+		"ulp collinear":  ulpCollinear(),
+	}
+	for name, set := range sets {
+		for _, p := range set {
+			for _, perm := range [][3]int{{0, 1, 2}, {1, 2, 0}, {2, 0, 1}, {1, 0, 2}} {
+				a, b, c := p[perm[0]], p[perm[1]], p[perm[2]]
+				if got, want := Orient2D(a, b, c), ratOrient2D(a, b, c); got != want {
+					t.Fatalf("%s: Orient2D(%v, %v, %v) = %d, want %d", name, a, b, c, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestInCircleMatchesExact(t *testing.T) {
+	sets := map[string][][4]types.Point{
+		"grid cocircular": gridCocircular(1000),
+		"near cocircular": nearCocircular(1000),
+		"ulp cocircular":  ulpCocircular(),
+		"rect cocircular": rectCocircular(1000),
+		"wide rect":       wideRectCocircular(1000),
+	}
+	for name, set := range sets {
+		for _, p := range set {
+			for _, perm := range [][4]int{{0, 1, 2, 3}, {1, 2, 3, 0}, {3, 1, 0, 2}} {
+				a, b, c, d := p[perm[0]], p[perm[1]], p[perm[2]], p[perm[3]]
+				if got, want := InCircle(a, b, c, d), ratInCircle(a, b, c, d); got != want {
+					t.Fatalf("%s: InCircle(%v, %v, %v, %v) = %d, want %d", name, a, b, c, d, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestPredicatesDoNotAllocate(t *testing.T) {
+	tri := ulpCollinear()[0]
+	quad := ulpCocircular()[0]
+	rect := rectCocircular(1)[0]
+	allocs := testing.AllocsPerRun(100, func() {
+		Orient2D(tri[0], tri[1], tri[2])
+		InCircle(quad[0], quad[1], quad[2], quad[3])
+		InCircle(rect[0], rect[1], rect[2], rect[3])
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestExpansionSumExact(t *testing.T) {
+	// 1 + 2^-60 + 2^-120 cannot be held by a float64, but its expansion is
+	// exact and the tiny component survives cancellation.
+	e := []float64{math.Ldexp(1, -120), 1}
+	f := []float64{math.Ldexp(1, -60), -1}
+	var h [4]float64
+	n := expansionSum(e, f, h[:])
+	if got := estimate(h[:n]); got != math.Ldexp(1, -60)+math.Ldexp(1, -120) {
+		t.Fatalf("unexpected sum %v from %v", got, h[:n])
+	}
+}
+
+var sinkInt int
+
+func BenchmarkOrient2D(b *testing.B) {
+	sets := []struct {
+		name string
+		pts  [][3]types.Point
+	}{
+		{"GridCollinear", gridCollinear(1024)},
+		{"NearCollinear", nearCollinear(1024)},
+		{"UlpCollinear", ulpCollinear()},
+	}
+	impls := []struct {
+		name string
+		fn   func(a, b, c types.Point) int
+	}{
+		{"Adaptive", Orient2D},
+		{"BigFloat", orient2DBig},
+	}
+	for _, set := range sets {
+		for _, impl := range impls {
+			b.Run(set.name+"/"+impl.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					p := set.pts[i%len(set.pts)]
+					sinkInt += impl.fn(p[0], p[1], p[2])
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkInCircle(b *testing.B) {
+	sets := []struct {
+		name string
+		pts  [][4]types.Point
+	}{
+		{"GridCocircular", gridCocircular(1024)},
+		{"NearCocircular", nearCocircular(1024)},
+		{"UlpCocircular", ulpCocircular()},
+		{"RectCocircularStageD", rectCocircular(1024)},
+	}
+	impls := []struct {
+		name string
+		fn   func(a, b, c, d types.Point) int
+	}{
+		{"Adaptive", InCircle},
+		{"BigFloat", inCircleBig},
+	}
+	for _, set := range sets {
+		for _, impl := range impls {
+			b.Run(set.name+"/"+impl.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					p := set.pts[i%len(set.pts)]
+					sinkInt += impl.fn(p[0], p[1], p[2], p[3])
+				}
+			})
+		}
+	}
+}
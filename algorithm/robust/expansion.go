@@ -0,0 +1,256 @@
+package robust
+
+// Floating-point expansion arithmetic after Shewchuk, "Adaptive Precision
+// Floating-Point Arithmetic and Fast Robust Geometric Predicates" (1997).
+//
+// An expansion is a sum of float64 components, stored in increasing order of
+// magnitude, whose nonzero components do not overlap. The operations below
+// are exact and write into caller-provided slices, so predicates built on
+// them need no heap allocation.
+//
+// The explicit float64 conversions around products keep the compiler from
+// fusing them into FMA instructions, which would break the error-free
+// transformations.
+
+const (
+	machEpsilon = 1.0 / (1 << 53)    // Half an ulp of 1
+	splitter    = float64(1<<27) + 1 // Splits a float64 into two 26-bit halves
+
+	resultErrBound = (3 + 8*machEpsilon) * machEpsilon
+	ccwErrBoundA   = (3 + 16*machEpsilon) * machEpsilon
+	ccwErrBoundB   = (2 + 12*machEpsilon) * machEpsilon
+	ccwErrBoundC   = (9 + 64*machEpsilon) * machEpsilon * machEpsilon
+	iccErrBoundA   = (10 + 96*machEpsilon) * machEpsilon
+	iccErrBoundB   = (4 + 48*machEpsilon) * machEpsilon
+	iccErrBoundC   = (44 + 576*machEpsilon) * machEpsilon * machEpsilon
+)
+
+// fastTwoSum returns a+b as x+y exactly, given |a| >= |b|.
+func fastTwoSum(a, b float64) (x, y float64) {
+	x = a + b
+	y = b - (x - a)
+	return x, y
+}
+
+// twoSum returns a+b as x+y exactly.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	y = (a - av) + (b - bv)
+	return x, y
+}
+
+// twoDiff returns a-b as x+y exactly.
+func twoDiff(a, b float64) (x, y float64) {
+	x = a - b
+	return x, twoDiffTail(a, b, x)
+}
+
+// twoDiffTail returns the roundoff of x = a-b.
+func twoDiffTail(a, b, x float64) float64 {
+	bv := a - x
+	av := x + bv
+	return (a - av) + (bv - b)
+}
+
+// split returns hi+lo = a with both halves fitting in 26 bits.
+func split(a float64) (hi, lo float64) {
+	c := float64(splitter * a)
+	big := c - a
+	hi = c - big
+	return hi, a - hi
+}
+
+// twoProduct returns a*b as x+y exactly.
+func twoProduct(a, b float64) (x, y float64) {
+	x = float64(a * b)
+	ahi, alo := split(a)
+	bhi, blo := split(b)
+	err := x - float64(ahi*bhi)
+	err -= float64(alo * bhi)
+	err -= float64(ahi * blo)
+	return x, float64(alo*blo) - err
+}
+
+// twoTwoDiff returns (a1+a0) - (b1+b0) as a four-component expansion.
+func twoTwoDiff(a1, a0, b1, b0 float64) [4]float64 {
+	var h [4]float64
+	i, x0 := twoDiff(a0, b0)
+	j, r0 := twoSum(a1, i)
+	k, x1 := twoDiff(r0, b1)
+	h[3], h[2] = twoSum(j, k)
+	h[1], h[0] = x1, x0
+	return h
+}
+
+// twoTwoSum returns (a1+a0) + (b1+b0) as a four-component expansion.
+func twoTwoSum(a1, a0, b1, b0 float64) [4]float64 {
+	var h [4]float64
+	i, x0 := twoSum(a0, b0)
+	j, r0 := twoSum(a1, i)
+	k, x1 := twoSum(r0, b1)
+	h[3], h[2] = twoSum(j, k)
+	h[1], h[0] = x1, x0
+	return h
+}
+
+// estimate approximates the value of an expansion.
+func estimate(e []float64) float64 {
+	sum := 0.0
+	for _, v := range e {
+		sum += v
+	}
+	return sum
+}
+
+// expansionSum writes e+f into h, dropping zero components, and returns the
+// length of the result. h needs room for len(e)+len(f) components.
+func expansionSum(e, f, h []float64) int {
+	ei, fi := 0, 0
+	enow, fnow := e[0], f[0]
+
+	// Components are merged in increasing order of magnitude.
+	var q float64
+	if (fnow > enow) == (fnow > -enow) {
+		q = enow
+		ei++
+	} else {
+		q = fnow
+		fi++
+	}
+
+	n := 0
+	var hh float64
+	if ei < len(e) && fi < len(f) {
+		enow, fnow = e[ei], f[fi]
+		if (fnow > enow) == (fnow > -enow) {
+			q, hh = fastTwoSum(enow, q)
+			ei++
+		} else {
+			q, hh = fastTwoSum(fnow, q)
+			fi++
+		}
+		if hh != 0 {
+			h[n] = hh
+			n++
+		}
+		for ei < len(e) && fi < len(f) {
+			enow, fnow = e[ei], f[fi]
+			if (fnow > enow) == (fnow > -enow) {
+				q, hh = twoSum(q, enow)
+				ei++
+			} else {
+				q, hh = twoSum(q, fnow)
+				fi++
+			}
+			if hh != 0 {
+				h[n] = hh
+				n++
+			}
+		}
+	}
+	for ; ei < len(e); ei++ {
+		q, hh = twoSum(q, e[ei])
+		if hh != 0 {
+			h[n] = hh
+			n++
+		}
+	}
+	for ; fi < len(f); fi++ {
+		q, hh = twoSum(q, f[fi])
+		if hh != 0 {
+			h[n] = hh
+			n++
+		}
+	}
+	if q != 0 || n == 0 {
+		h[n] = q
+		n++
+	}
+	return n
+}
+
+// scaleExpansion writes e*b into h, dropping zero components, and returns
+// the length of the result. h needs room for 2*len(e) components.
+func scaleExpansion(e []float64, b float64, h []float64) int {
+	bhi, blo := split(b)
+	n := 0
+
+	q, hh := twoProductPresplit(e[0], b, bhi, blo)
+	if hh != 0 {
+		h[n] = hh
+		n++
+	}
+	for _, v := range e[1:] {
+		p1, p0 := twoProductPresplit(v, b, bhi, blo)
+		sum, hh := twoSum(q, p0)
+		if hh != 0 {
+			h[n] = hh
+			n++
+		}
+		q, hh = fastTwoSum(p1, sum)
+		if hh != 0 {
+			h[n] = hh
+			n++
+		}
+	}
+	if q != 0 || n == 0 {
+		h[n] = q
+		n++
+	}
+	return n
+}
+
+// compress rewrites e in place as an expansion with the same value and
+// no more components, typically far fewer, and returns its length. Scaling
+// a compressed expansion is correspondingly cheaper.
+func compress(e []float64) int {
+	bottom := len(e) - 1
+	q := e[bottom]
+	for i := len(e) - 2; i >= 0; i-- {
+		sum, err := fastTwoSum(q, e[i])
+		if err != 0 {
+			e[bottom] = sum
+			bottom--
+			q = err
+		} else {
+			q = sum
+		}
+	}
+	top := 0
+	for i := bottom + 1; i < len(e); i++ {
+		sum, err := fastTwoSum(e[i], q)
+		if err != 0 {
+			e[top] = err
+			top++
+		}
+		q = sum
+	}
+	e[top] = q
+	return top + 1
+}
+
+// twoProductPresplit is twoProduct with b already split.
+func twoProductPresplit(a, b, bhi, blo float64) (x, y float64) {
+	x = float64(a * b)
+	ahi, alo := split(a)
+	err := x - float64(ahi*bhi)
+	err -= float64(alo * bhi)
+	err -= float64(ahi * blo)
+	return x, float64(alo*blo) - err
+}
+
+// expansionProduct writes e*f into h and returns the length of the result.
+// h needs room for 2*len(e)*len(f) components and tmp for as many again.
+// f should be the shorter of the two.
+func expansionProduct(e, f, h, tmp []float64) int {
+	n := scaleExpansion(e, f[0], h)
+	var scaled [64]float64
+	for _, v := range f[1:] {
+		m := scaleExpansion(e, v, scaled[:])
+		n = expansionSum(h[:n], scaled[:m], tmp)
+		copy(h, tmp[:n])
+	}
+	return n
+}
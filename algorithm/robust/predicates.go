@@ -7,140 +7,390 @@ import (
 	"github.com/iceisfun/gomesh/types"
 )
 
-const (
-	orientFilter = 1e-15
-	crossFilter  = 1e-15
-)
+const crossFilter = 1e-15
 
 // Orient2D returns the orientation of triangle (a,b,c).
 //
 // The return value is:
 //   - +1 if the points make a counter-clockwise turn
 //   - -1 if the points make a clockwise turn
-//   - 0 if the points are (near) collinear
+//   - 0 if the points are exactly collinear
 //
-// The implementation first evaluates the determinant in float64 with a
-// small adaptive filter and falls back to arbitrary precision arithmetic
-// when necessary.
+// The determinant is first evaluated in float64 and accepted when it clears
+// a forward error bound. Otherwise it is refined in stages with expansion
+// arithmetic until the sign is certain, so the result is exact without
+// allocating.
 func Orient2D(a, b, c types.Point) int {
-	ax := b.X - a.X
-	ay := b.Y - a.Y
-	bx := c.X - a.X
-	by := c.Y - a.Y
-	det := ax*by - ay*bx
+	return sign(orient2D(a, b, c))
+}
 
-	maxMag := maxAbs(a.X, a.Y, b.X, b.Y, c.X, c.Y)
-	eps := maxMag * maxMag * orientFilter
-	if eps < orientFilter {
-		eps = orientFilter
-	}
+func orient2D(a, b, c types.Point) float64 {
+	detLeft := float64((a.X - c.X) * (b.Y - c.Y))
+	detRight := float64((a.Y - c.Y) * (b.X - c.X))
+	det := detLeft - detRight
 
+	var detSum float64
 	switch {
-	case det > eps:
-		return 1
-	case det < -eps:
-		return -1
+	case detLeft > 0:
+		if detRight <= 0 {
+			return det
+		}
+		detSum = detLeft + detRight
+	case detLeft < 0:
+		if detRight >= 0 {
+			return det
+		}
+		detSum = -detLeft - detRight
 	default:
-		return orient2DExact(a, b, c)
+		return det
 	}
+
+	errBound := ccwErrBoundA * detSum
+	if det >= errBound || -det >= errBound {
+		return det
+	}
+	return orient2DAdapt(a, b, c, detSum)
 }
 
-func orient2DExact(a, b, c types.Point) int {
-	ax := bigFloat(b.X)
-	ax.Sub(ax, bigFloat(a.X))
-	ay := bigFloat(b.Y)
-	ay.Sub(ay, bigFloat(a.Y))
+func orient2DAdapt(a, b, c types.Point, detSum float64) float64 {
+	acx, acy := a.X-c.X, a.Y-c.Y
+	bcx, bcy := b.X-c.X, b.Y-c.Y
+
+	// Stage B: exact products of the rounded differences.
+	l1, l0 := twoProduct(acx, bcy)
+	r1, r0 := twoProduct(acy, bcx)
+	bExp := twoTwoDiff(l1, l0, r1, r0)
+	det := estimate(bExp[:])
+	errBound := ccwErrBoundB * detSum
+	if det >= errBound || -det >= errBound {
+		return det
+	}
 
-	bx := bigFloat(c.X)
-	bx.Sub(bx, bigFloat(a.X))
-	by := bigFloat(c.Y)
-	by.Sub(by, bigFloat(a.Y))
+	acxTail := twoDiffTail(a.X, c.X, acx)
+	bcxTail := twoDiffTail(b.X, c.X, bcx)
+	acyTail := twoDiffTail(a.Y, c.Y, acy)
+	bcyTail := twoDiffTail(b.Y, c.Y, bcy)
+	if acxTail == 0 && acyTail == 0 && bcxTail == 0 && bcyTail == 0 {
+		return det
+	}
+
+	// Stage C: first-order correction for the roundoff of the differences.
+	errBound = ccwErrBoundC*detSum + resultErrBound*abs(det)
+	det += (acx*bcyTail + bcy*acxTail) - (acy*bcxTail + bcx*acyTail)
+	if det >= errBound || -det >= errBound {
+		return det
+	}
+
+	// Stage D: the exact determinant.
+	var c1 [8]float64
+	var c2 [12]float64
+	var d [16]float64
+
+	s1, s0 := twoProduct(acxTail, bcy)
+	t1, t0 := twoProduct(acyTail, bcx)
+	u := twoTwoDiff(s1, s0, t1, t0)
+	n1 := expansionSum(bExp[:], u[:], c1[:])
 
-	term1 := bigFloat(0)
-	term1.Mul(ax, by)
+	s1, s0 = twoProduct(acx, bcyTail)
+	t1, t0 = twoProduct(acy, bcxTail)
+	u = twoTwoDiff(s1, s0, t1, t0)
+	n2 := expansionSum(c1[:n1], u[:], c2[:])
 
-	term2 := bigFloat(0)
-	term2.Mul(ay, bx)
+	s1, s0 = twoProduct(acxTail, bcyTail)
+	t1, t0 = twoProduct(acyTail, bcxTail)
+	u = twoTwoDiff(s1, s0, t1, t0)
+	n := expansionSum(c2[:n2], u[:], d[:])
 
-	det := bigFloat(0)
-	det.Sub(term1, term2)
-	return det.Sign()
+	return d[n-1]
 }
 
 // InCircle tests whether point d lies inside, on, or outside the circumcircle
 // of triangle (a,b,c). The sign of the return value matches the standard
 // predicates convention: positive when inside (assuming a,b,c are CCW),
 // negative when outside, and zero when cocircular.
+//
+// Like Orient2D it filters with a float64 error bound, refines adaptively
+// and is exact.
 func InCircle(a, b, c, d types.Point) int {
-	// Fast eval in float64
-	adx := a.X - d.X
-	ady := a.Y - d.Y
-	bdx := b.X - d.X
-	bdy := b.Y - d.Y
-	cdx := c.X - d.X
-	cdy := c.Y - d.Y
+	return sign(inCircle(a, b, c, d))
+}
+
+func inCircle(a, b, c, d types.Point) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	aLift := float64(adx*adx) + float64(ady*ady)
 
-	ad2 := adx*adx + ady*ady
-	bd2 := bdx*bdx + bdy*bdy
-	cd2 := cdx*cdx + cdy*cdy
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	bLift := float64(bdx*bdx) + float64(bdy*bdy)
 
-	det := ad2*(bdx*cdy-bdy*cdx) -
-		bd2*(adx*cdy-ady*cdx) +
-		cd2*(adx*bdy-ady*bdx)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	cLift := float64(cdx*cdx) + float64(cdy*cdy)
 
-	maxMag := maxAbs(adx, ady, bdx, bdy, cdx, cdy)
-	eps := math.Pow(maxMag, 3) * orientFilter
-	if eps < orientFilter {
-		eps = orientFilter
+	det := float64(aLift*(bdxcdy-cdxbdy)) +
+		float64(bLift*(cdxady-adxcdy)) +
+		float64(cLift*(adxbdy-bdxady))
+
+	permanent := (abs(bdxcdy)+abs(cdxbdy))*aLift +
+		(abs(cdxady)+abs(adxcdy))*bLift +
+		(abs(adxbdy)+abs(bdxady))*cLift
+	errBound := iccErrBoundA * permanent
+	if det > errBound || -det > errBound {
+		return det
 	}
+	return inCircleAdapt(a, b, c, d, permanent)
+}
 
-	switch {
-	case det > eps:
-		return 1
-	case det < -eps:
-		return -1
-	default:
-		return inCircleExact(a, b, c, d)
+func inCircleAdapt(a, b, c, d types.Point, permanent float64) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	// Stage B: exact arithmetic on the rounded differences.
+	// The cross products are compressed, since each is scaled four times.
+	bc4 := crossExpansion(bdx, bdy, cdx, cdy)
+	ca4 := crossExpansion(cdx, cdy, adx, ady)
+	ab4 := crossExpansion(adx, ady, bdx, bdy)
+	bc, ca, ab := bc4[:compress(bc4[:])], ca4[:compress(ca4[:])], ab4[:compress(ab4[:])]
+	var adet, bdet, cdet [32]float64
+	na := liftedTermB(adx, ady, bc, adet[:])
+	nb := liftedTermB(bdx, bdy, ca, bdet[:])
+	nc := liftedTermB(cdx, cdy, ab, cdet[:])
+	var ab2 [64]float64
+	var fin [96]float64
+	nab := expansionSum(adet[:na], bdet[:nb], ab2[:])
+	nfin := expansionSum(ab2[:nab], cdet[:nc], fin[:])
+	det := estimate(fin[:nfin])
+	errBound := iccErrBoundB * permanent
+	if det >= errBound || -det >= errBound {
+		return det
+	}
+
+	pa := tailed{x: adx, y: ady, xTail: twoDiffTail(a.X, d.X, adx), yTail: twoDiffTail(a.Y, d.Y, ady)}
+	pb := tailed{x: bdx, y: bdy, xTail: twoDiffTail(b.X, d.X, bdx), yTail: twoDiffTail(b.Y, d.Y, bdy)}
+	pc := tailed{x: cdx, y: cdy, xTail: twoDiffTail(c.X, d.X, cdx), yTail: twoDiffTail(c.Y, d.Y, cdy)}
+	if !pa.hasTail() && !pb.hasTail() && !pc.hasTail() {
+		return det
+	}
+
+	// Stage C: first-order correction for the roundoff of the differences.
+	adxTail, adyTail := pa.xTail, pa.yTail
+	bdxTail, bdyTail := pb.xTail, pb.yTail
+	cdxTail, cdyTail := pc.xTail, pc.yTail
+	errBound = iccErrBoundC*permanent + resultErrBound*abs(det)
+	det += ((adx*adx+ady*ady)*((bdx*cdyTail+cdy*bdxTail)-(bdy*cdxTail+cdx*bdyTail)) +
+		2*(adx*adxTail+ady*adyTail)*(bdx*cdy-bdy*cdx)) +
+		((bdx*bdx+bdy*bdy)*((cdx*adyTail+ady*cdxTail)-(cdy*adxTail+adx*cdyTail)) +
+			2*(bdx*bdxTail+bdy*bdyTail)*(cdx*ady-cdy*adx)) +
+		((cdx*cdx+cdy*cdy)*((adx*bdyTail+bdy*adxTail)-(ady*bdxTail+bdx*adyTail)) +
+			2*(cdx*cdxTail+cdy*cdyTail)*(adx*bdy-ady*bdx))
+	if det >= errBound || -det >= errBound {
+		return det
 	}
+
+	// Stage D: add the terms involving the tails to the stage B
+	// determinant. Writing each point's exact lift as pp+A and the exact
+	// cross product of the other two as qr+T, stage B covered pp*qr and the
+	// tails contribute A*(qr+T) + pp*T. The factors are compressed first and
+	// the scratch space is sized from their lengths, so the work follows the
+	// tails actually present.
+	var terms [3]tailTerm
+	terms[0].init(pa, pb, pc, bc)
+	terms[1].init(pb, pc, pa, ca)
+	terms[2].init(pc, pa, pb, ab)
+	maxProduct, total := tailSizes(&terms, nfin)
+	if 2*(maxProduct+total) <= 512 {
+		var scratch [512]float64
+		return addTailTerms(&terms, fin[:nfin], scratch[:], maxProduct, total)
+	}
+	// At most 2*(256 + 96 + 3*(256+96)) components.
+	var scratch [2816]float64
+	return addTailTerms(&terms, fin[:nfin], scratch[:], maxProduct, total)
 }
 
-func inCircleExact(a, b, c, d types.Point) int {
-	ax := bigFloat(a.X - d.X)
-	ay := bigFloat(a.Y - d.Y)
-	bx := bigFloat(b.X - d.X)
-	by := bigFloat(b.Y - d.Y)
-	cx := bigFloat(c.X - d.X)
-	cy := bigFloat(c.Y - d.Y)
+// crossExpansion returns qx*ry - qy*rx as a four-component expansion.
+func crossExpansion(qx, qy, rx, ry float64) [4]float64 {
+	l1, l0 := twoProduct(qx, ry)
+	r1, r0 := twoProduct(qy, rx)
+	return twoTwoDiff(l1, l0, r1, r0)
+}
 
-	ad2 := bigFloat(0)
-	ad2.Mul(ax, ax)
-	tmp := bigFloat(0)
-	tmp.Mul(ay, ay)
-	ad2.Add(ad2, tmp)
+// liftedTermB writes (px²+py²)*cross into h, which needs room for 32
+// components, and returns its length.
+func liftedTermB(px, py float64, cross []float64, h []float64) int {
+	var xs, ys [8]float64
+	var xxs, yys [16]float64
+	n := scaleExpansion(cross, px, xs[:])
+	nx := scaleExpansion(xs[:n], px, xxs[:])
+	n = scaleExpansion(cross, py, ys[:])
+	ny := scaleExpansion(ys[:n], py, yys[:])
+	return expansionSum(xxs[:nx], yys[:ny], h)
+}
 
-	bd2 := bigFloat(0)
-	bd2.Mul(bx, bx)
-	tmp.Mul(by, by)
-	bd2.Add(bd2, tmp)
+// tailed is a difference of input coordinates held as a rounded value and
+// its roundoff.
+type tailed struct {
+	x, y         float64
+	xTail, yTail float64
+}
 
-	cd2 := bigFloat(0)
-	cd2.Mul(cx, cx)
-	tmp.Mul(cy, cy)
-	cd2.Add(cd2, tmp)
+func (p tailed) hasTail() bool {
+	return p.xTail != 0 || p.yTail != 0
+}
 
-	term1 := bigFloat(0)
-	term1.Mul(ad2, det2(bx, by, cx, cy))
+// lift returns x²+y² of the rounded values as a four-component expansion.
+func (p tailed) lift() [4]float64 {
+	x1, x0 := twoProduct(p.x, p.x)
+	y1, y0 := twoProduct(p.y, p.y)
+	return twoTwoSum(x1, x0, y1, y0)
+}
 
-	term2 := bigFloat(0)
-	term2.Mul(bd2, det2(ax, ay, cx, cy))
+// tailTerm holds the compressed factors of the stage D terms of one point
+// p, given the two points q and r following it in the cyclic order a, b, c:
+// A, the tail part of the exact lift of p; qr+T, the exact cross product of
+// q and r; T alone; and pp, the lift of the rounded differences of p.
+type tailTerm struct {
+	lift  [8]float64
+	cross [16]float64
+	tail  [12]float64
+	pp    [4]float64
+
+	nLift, nCross, nTail, nPP int
+}
 
-	term3 := bigFloat(0)
-	term3.Mul(cd2, det2(ax, ay, bx, by))
+func (t *tailTerm) init(p, q, r tailed, qr []float64) {
+	var tail, lift productSum
+	crossTail(q, r, &tail)
+	if e := tail.compressed(); e != nil {
+		t.nTail = copy(t.tail[:], e)
+		t.pp = p.lift()
+		t.nPP = compress(t.pp[:])
+	}
+	p.liftTail(&lift)
+	if e := lift.compressed(); e != nil {
+		t.nLift = copy(t.lift[:], e)
+		n := copy(t.cross[:], qr)
+		if t.nTail > 0 {
+			n = expansionSum(qr, t.tail[:t.nTail], t.cross[:])
+		}
+		t.nCross = compress(t.cross[:n])
+	}
+}
+
+// products returns the number of components of A*(qr+T) and of pp*T.
+func (t *tailTerm) products() (int, int) {
+	return 2 * t.nCross * t.nLift, 2 * t.nTail * t.nPP
+}
 
-	det := bigFloat(0)
-	det.Add(term1, term3)
-	det.Sub(det, term2)
-	return det.Sign()
+// tailSizes returns the length bound of the largest stage D product and
+// that of the sum of n stage B components and every product.
+func tailSizes(terms *[3]tailTerm, n int) (maxProduct, total int) {
+	total = n
+	for i := range terms {
+		l, r := terms[i].products()
+		maxProduct = max(maxProduct, l, r)
+		total += l + r
+	}
+	return maxProduct, total
+}
+
+// addTailTerms returns the most significant component of det plus the
+// stage D terms. scratch needs room for 2*(maxProduct+total) components,
+// as returned by tailSizes.
+func addTailTerms(terms *[3]tailTerm, det, scratch []float64, maxProduct, total int) float64 {
+	prod, tmp := scratch[:maxProduct], scratch[maxProduct:2*maxProduct]
+	sum, next := scratch[2*maxProduct:2*maxProduct+total], scratch[2*maxProduct+total:]
+
+	n := copy(sum, det)
+	for i := range terms {
+		t := &terms[i]
+		if t.nLift > 0 {
+			m := expansionProduct(t.cross[:t.nCross], t.lift[:t.nLift], prod, tmp)
+			n = expansionSum(sum[:n], prod[:m], next)
+			sum, next = next, sum
+		}
+		if t.nTail > 0 {
+			m := expansionProduct(t.tail[:t.nTail], t.pp[:t.nPP], prod, tmp)
+			n = expansionSum(sum[:n], prod[:m], next)
+			sum, next = next, sum
+		}
+	}
+	return sum[n-1]
+}
+
+// productSum sums products of float64s exactly, skipping those with a zero
+// factor. It holds up to eight products.
+type productSum struct {
+	buf [2][16]float64
+	cur int
+	n   int
+}
+
+func (s *productSum) add(a, b float64) {
+	if a == 0 || b == 0 {
+		return
+	}
+	x, y := twoProduct(a, b)
+	p := [2]float64{y, x}
+	e := p[:]
+	if y == 0 {
+		e = p[1:]
+	}
+	if s.n == 0 {
+		s.n = copy(s.buf[s.cur][:], e)
+		return
+	}
+	s.n = expansionSum(s.buf[s.cur][:s.n], e, s.buf[1-s.cur][:])
+	s.cur = 1 - s.cur
+}
+
+// compressed compresses the sum and returns it, or nil if it is empty.
+func (s *productSum) compressed() []float64 {
+	if s.n == 0 {
+		return nil
+	}
+	return s.buf[s.cur][:compress(s.buf[s.cur][:s.n])]
+}
+
+// liftTail sets s to the tail part of the exact lift of p,
+// xTail*(2x+xTail) + yTail*(2y+yTail).
+func (p tailed) liftTail(s *productSum) {
+	s.add(p.xTail, 2*p.x)
+	s.add(p.xTail, p.xTail)
+	s.add(p.yTail, 2*p.y)
+	s.add(p.yTail, p.yTail)
+}
+
+// crossTail sets s to the tail part of the exact cross product of q and r,
+// the terms of (qx+qxTail)(ry+ryTail) - (qy+qyTail)(rx+rxTail) involving a
+// tail.
+func crossTail(q, r tailed, s *productSum) {
+	s.add(q.xTail, r.y)
+	s.add(q.x, r.yTail)
+	s.add(q.xTail, r.yTail)
+	s.add(-r.xTail, q.y)
+	s.add(-r.x, q.yTail)
+	s.add(-r.xTail, q.yTail)
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
 }
 
 // SegmentIntersect computes whether two closed segments [p,q] and [r,s] intersect.
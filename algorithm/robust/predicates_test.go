@@ -107,3 +107,45 @@ func TestSegmentIntersect(t *testing.T) {
 		t.Fatalf("expected overlap to return true with NaN params, got ok=%v t=%f u=%f", okOverlap, tOverlap, uOverlap)
 	}
 }
+
+func TestPointOnSegment(t *testing.T) {
+	a := types.Point{X: 0, Y: 0}
+	b := types.Point{X: 3, Y: 3}
+	if !PointOnSegment(types.Point{X: 1, Y: 1}, a, b) {
+		t.Fatalf("expected interior point to lie on the segment")
+	}
+	if !PointOnSegment(b, a, b) {
+		t.Fatalf("expected endpoint to lie on the segment")
+	}
+	if PointOnSegment(types.Point{X: 4, Y: 4}, a, b) {
+		t.Fatalf("expected point beyond the endpoint to be off the segment")
+	}
+	// One ulp off the diagonal is not on it.
+	if PointOnSegment(types.Point{X: 1, Y: math.Nextafter(1, 2)}, a, b) {
+		t.Fatalf("expected point one ulp off the line to be off the segment")
+	}
+}
+
+func TestSegmentIntersectionKind(t *testing.T) {
+	p := func(x, y float64) types.Point { return types.Point{X: x, Y: y} }
+	cases := []struct {
+		name           string
+		a1, a2, b1, b2 types.Point
+		want           types.IntersectionType
+	}{
+		{"proper", p(0, 0), p(2, 2), p(0, 2), p(2, 0), types.IntersectProper},
+		{"disjoint", p(0, 0), p(1, 0), p(0, 1), p(1, 1), types.IntersectNone},
+		{"shared endpoint", p(0, 0), p(1, 0), p(1, 0), p(1, 1), types.IntersectTouching},
+		{"t junction", p(0, 0), p(2, 0), p(1, 0), p(1, 1), types.IntersectTouching},
+		{"overlap", p(0, 0), p(2, 0), p(1, 0), p(3, 0), types.IntersectCollinearOverlap},
+		{"vertical overlap", p(0, 0), p(0, 2), p(0, 3), p(0, 1), types.IntersectCollinearOverlap},
+		{"collinear end to end", p(0, 0), p(1, 1), p(1, 1), p(2, 2), types.IntersectTouching},
+		{"collinear apart", p(0, 0), p(1, 1), p(2, 2), p(3, 3), types.IntersectNone},
+		{"near miss", p(0, 0), p(1, 0), p(0.5, math.SmallestNonzeroFloat64), p(0.5, 1), types.IntersectNone},
+	}
+	for _, tc := range cases {
+		if got := SegmentIntersectionKind(tc.a1, tc.a2, tc.b1, tc.b2); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package robust
+
+import (
+	"math"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+// PointOnSegment reports whether p lies on the closed segment [a,b].
+//
+// Collinearity is decided by Orient2D and containment by exact coordinate
+// comparisons, so no tolerance is involved. A degenerate segment contains
+// only its own point.
+func PointOnSegment(p, a, b types.Point) bool {
+	if Orient2D(a, b, p) != 0 {
+		return false
+	}
+	return between(p.X, a.X, b.X) && between(p.Y, a.Y, b.Y)
+}
+
+// SegmentIntersectionKind classifies how the closed segments [a1,a2] and
+// [b1,b2] intersect, using only exact decisions:
+//   - IntersectProper when they cross at a single interior point
+//   - IntersectCollinearOverlap when they share a piece of positive length
+//   - IntersectTouching when they meet at a single point that is an endpoint
+//     of at least one of them
+//   - IntersectNone otherwise
+func SegmentIntersectionKind(a1, a2, b1, b2 types.Point) types.IntersectionType {
+	o1 := Orient2D(a1, a2, b1)
+	o2 := Orient2D(a1, a2, b2)
+	o3 := Orient2D(b1, b2, a1)
+	o4 := Orient2D(b1, b2, a2)
+
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return types.IntersectProper
+	}
+
+	if o1 == 0 && o2 == 0 && o3 == 0 && o4 == 0 {
+		return collinearKind(a1, a2, b1, b2)
+	}
+
+	if PointOnSegment(b1, a1, a2) || PointOnSegment(b2, a1, a2) ||
+		PointOnSegment(a1, b1, b2) || PointOnSegment(a2, b1, b2) {
+		return types.IntersectTouching
+	}
+	return types.IntersectNone
+}
+
+// collinearKind compares the extents of two collinear segments along the
+// axis where the four points spread the most, which preserves their order
+// on the common line.
+func collinearKind(a1, a2, b1, b2 types.Point) types.IntersectionType {
+	xs := [4]float64{a1.X, a2.X, b1.X, b2.X}
+	ys := [4]float64{a1.Y, a2.Y, b1.Y, b2.Y}
+	coords := xs
+	if spread(ys) > spread(xs) {
+		coords = ys
+	}
+
+	lo := math.Max(math.Min(coords[0], coords[1]), math.Min(coords[2], coords[3]))
+	hi := math.Min(math.Max(coords[0], coords[1]), math.Max(coords[2], coords[3]))
+	switch {
+	case lo < hi:
+		return types.IntersectCollinearOverlap
+	case lo == hi:
+		return types.IntersectTouching
+	default:
+		return types.IntersectNone
+	}
+}
+
+func spread(v [4]float64) float64 {
+	return math.Max(math.Max(v[0], v[1]), math.Max(v[2], v[3])) -
+		math.Min(math.Min(v[0], v[1]), math.Min(v[2], v[3]))
+}
+
+func between(v, a, b float64) bool {
+	return v >= math.Min(a, b) && v <= math.Max(a, b)
+}
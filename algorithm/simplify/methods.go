@@ -0,0 +1,161 @@
+package simplify
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/iceisfun/gomesh/algorithm/geometry"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// douglasPeucker marks the points of a closed loop kept by Douglas–Peucker.
+//
+// The loop is anchored at three points spanning a triangle of maximal
+// extent: the first point, the point furthest from it, and the point
+// furthest from the line through those two. Each span between anchors is
+// then split recursively at its furthest point while that point lies more
+// than tol from the span's chord.
+func douglasPeucker(loop []types.Point, tol float64) []bool {
+	n := len(loop)
+	keep := make([]bool, n)
+
+	a, b := 0, 0
+	for i, p := range loop {
+		if dist(p, loop[a]) > dist(loop[b], loop[a]) {
+			b = i
+		}
+	}
+	c, best := -1, 0.0
+	for i, p := range loop {
+		if d := math.Abs(geometry.Area2(loop[a], loop[b], p)); d > best {
+			c, best = i, d
+		}
+	}
+	if c < 0 {
+		// Every point lies on one line; there is nothing to preserve.
+		return allTrue(n)
+	}
+
+	anchors := []int{a, b, c}
+	if b > c {
+		anchors[1], anchors[2] = c, b
+	}
+	for _, i := range anchors {
+		keep[i] = true
+	}
+
+	type span struct{ from, to int }
+	var stack []span
+	for k := range anchors {
+		stack = append(stack, span{anchors[k], anchors[(k+1)%3]})
+	}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		far, d := furthest(loop, s.from, s.to)
+		if far >= 0 && d > tol {
+			keep[far] = true
+			stack = append(stack, span{s.from, far}, span{far, s.to})
+		}
+	}
+	return keep
+}
+
+// furthest returns the point strictly between from and to (walking forward
+// around the loop) furthest from the chord between them, or -1 if the span
+// has no interior points.
+func furthest(loop []types.Point, from, to int) (int, float64) {
+	n := len(loop)
+	best, far := -1.0, -1
+	for i := (from + 1) % n; i != to; i = (i + 1) % n {
+		if d := geometry.DistancePointSegment(loop[i], loop[from], loop[to]); d > best {
+			far, best = i, d
+		}
+	}
+	return far, best
+}
+
+// visvalingam marks the points of a closed loop kept by Visvalingam–Whyatt.
+// Collinear points are always removed.
+//
+// A removed vertex's area is carried over to its neighbours when their new
+// area would be smaller, so points are removed in a consistent order.
+func visvalingam(loop []types.Point, minArea float64) []bool {
+	n := len(loop)
+	keep := allTrue(n)
+	prev := make([]int, n)
+	next := make([]int, n)
+	for i := range loop {
+		prev[i] = (i + n - 1) % n
+		next[i] = (i + 1) % n
+	}
+
+	h := &areaHeap{index: make([]int, n)}
+	for i := range loop {
+		heap.Push(h, areaItem{vertex: i, area: triangleArea(loop, prev[i], i, next[i])})
+	}
+
+	remaining := n
+	for remaining > 3 && h.Len() > 0 {
+		top := h.items[0]
+		if top.area >= minArea && top.area > 0 {
+			break
+		}
+		heap.Pop(h)
+
+		v := top.vertex
+		keep[v] = false
+		remaining--
+		p, q := prev[v], next[v]
+		next[p], prev[q] = q, p
+
+		for _, u := range []int{p, q} {
+			area := math.Max(triangleArea(loop, prev[u], u, next[u]), top.area)
+			h.items[h.index[u]].area = area
+			heap.Fix(h, h.index[u])
+		}
+	}
+	return keep
+}
+
+func triangleArea(loop []types.Point, a, b, c int) float64 {
+	return math.Abs(geometry.Area2(loop[a], loop[b], loop[c])) / 2
+}
+
+func dist(a, b types.Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+type areaItem struct {
+	vertex int
+	area   float64
+}
+
+// areaHeap is a min-heap of vertex areas that tracks each vertex's position
+// so its area can be updated in place.
+type areaHeap struct {
+	items []areaItem
+	index []int
+}
+
+func (h areaHeap) Len() int           { return len(h.items) }
+func (h areaHeap) Less(i, j int) bool { return h.items[i].area < h.items[j].area }
+
+func (h areaHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].vertex] = i
+	h.index[h.items[j].vertex] = j
+}
+
+func (h *areaHeap) Push(x any) {
+	item := x.(areaItem)
+	h.index[item.vertex] = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *areaHeap) Pop() any {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
@@ -0,0 +1,112 @@
+// Package simplify reduces the number of points in polygon loops while
+// preserving their topology.
+//
+// Each loop is first simplified on its own with Douglas–Peucker or
+// Visvalingam–Whyatt. Wherever the result would cross itself or another
+// loop, or would sweep over another loop's vertices, the original point
+// furthest from the offending shortcut is restored, and this repeats until
+// the loops are valid again. Given simple, non-touching input loops, the
+// output loops are simple, do not touch, and keep their nesting, so holes
+// stay inside their perimeters.
+package simplify
+
+import "github.com/iceisfun/gomesh/types"
+
+// Method selects the simplification algorithm.
+type Method int
+
+const (
+	// DouglasPeucker keeps every original point within Tolerance of the
+	// simplified loop.
+	DouglasPeucker Method = iota
+
+	// Visvalingam repeatedly removes the vertex forming the smallest
+	// triangle with its neighbours while that area is below
+	// Tolerance²/2, the area of a triangle of base and height Tolerance.
+	Visvalingam
+)
+
+// Config holds simplification settings.
+type Config struct {
+	Method    Method
+	Tolerance float64
+}
+
+// Option configures simplification.
+type Option func(*Config)
+
+// DefaultConfig returns the default settings: Douglas–Peucker with zero
+// tolerance, which only removes exactly collinear points.
+func DefaultConfig() Config {
+	return Config{Method: DouglasPeucker}
+}
+
+// WithMethod selects the simplification algorithm.
+func WithMethod(method Method) Option {
+	return func(c *Config) {
+		c.Method = method
+	}
+}
+
+// WithTolerance sets the simplification tolerance, a distance in input units.
+func WithTolerance(tolerance float64) Option {
+	return func(c *Config) {
+		if tolerance >= 0 {
+			c.Tolerance = tolerance
+		}
+	}
+}
+
+// Loop simplifies a single loop, keeping it simple.
+func Loop(loop []types.Point, opts ...Option) []types.Point {
+	return Loops([][]types.Point{loop}, opts...)[0]
+}
+
+// Polygon simplifies a perimeter and its holes together. The results can be
+// passed straight to cdt.Build.
+func Polygon(outer []types.Point, holes [][]types.Point, opts ...Option) ([]types.Point, [][]types.Point) {
+	loops := Loops(append([][]types.Point{outer}, holes...), opts...)
+	return loops[0], loops[1:]
+}
+
+// Loops simplifies a set of loops together so that none of them crosses,
+// touches or swallows another. Loops with fewer than four points are
+// returned unchanged; every other loop keeps at least three points.
+func Loops(loops [][]types.Point, opts ...Option) [][]types.Point {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	keep := make([][]bool, len(loops))
+	for i, loop := range loops {
+		switch {
+		case len(loop) < 4:
+			keep[i] = allTrue(len(loop))
+		case cfg.Method == Visvalingam:
+			keep[i] = visvalingam(loop, cfg.Tolerance*cfg.Tolerance/2)
+		default:
+			keep[i] = douglasPeucker(loop, cfg.Tolerance)
+		}
+	}
+
+	restoreTopology(loops, keep)
+
+	out := make([][]types.Point, len(loops))
+	for i, loop := range loops {
+		for j, p := range loop {
+			if keep[i][j] {
+				out[i] = append(out[i], p)
+			}
+		}
+	}
+	return out
+}
+
+func allTrue(n int) []bool {
+	out := make([]bool, n)
+	for i := range out {
+		out[i] = true
+	}
+	return out
+}
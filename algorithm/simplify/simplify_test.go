@@ -0,0 +1,118 @@
+package simplify
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/geometry"
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/pslg"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func noisyCircle(cx, cy, r float64, n int, noise float64, seed int64) []types.Point {
+	rng := rand.New(rand.NewSource(seed))
+	loop := make([]types.Point, n)
+	for i := range loop {
+		theta := 2 * math.Pi * float64(i) / float64(n)
+		rr := r + (rng.Float64()-0.5)*noise
+		loop[i] = types.Point{X: cx + rr*math.Cos(theta), Y: cy + rr*math.Sin(theta)}
+	}
+	return loop
+}
+
+// maxDeviation returns the largest distance from an original point to the
+// simplified loop.
+func maxDeviation(original, simplified []types.Point) float64 {
+	worst := 0.0
+	for _, p := range original {
+		best := math.Inf(1)
+		for i := range simplified {
+			best = math.Min(best, geometry.DistancePointSegment(p, simplified[i], simplified[(i+1)%len(simplified)]))
+		}
+		worst = math.Max(worst, best)
+	}
+	return worst
+}
+
+func TestRemovesCollinearPoints(t *testing.T) {
+	loop := []types.Point{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	for _, method := range []Method{DouglasPeucker, Visvalingam} {
+		if got := Loop(loop, WithMethod(method)); len(got) != 4 {
+			t.Fatalf("method %d: expected 4 corners, got %v", method, got)
+		}
+	}
+}
+
+func TestDouglasPeuckerTolerance(t *testing.T) {
+	circle := noisyCircle(0, 0, 10, 2000, 0.02, 1)
+	got := Loop(circle, WithTolerance(0.05))
+	if len(got) >= 200 {
+		t.Fatalf("expected substantial reduction, got %d points", len(got))
+	}
+	if d := maxDeviation(circle, got); d > 0.05 {
+		t.Fatalf("expected deviation within tolerance, got %v", d)
+	}
+	if err := pslg.LoopSelfIntersections(got); err != nil {
+		t.Fatalf("simplified loop is not simple: %v", err)
+	}
+}
+
+func TestVisvalingam(t *testing.T) {
+	circle := noisyCircle(0, 0, 10, 2000, 0.02, 2)
+	got := Loop(circle, WithMethod(Visvalingam), WithTolerance(0.2))
+	if len(got) >= 400 || len(got) < 3 {
+		t.Fatalf("expected substantial reduction, got %d points", len(got))
+	}
+	if math.Abs(polygon.SignedArea(got)-polygon.SignedArea(circle)) > 0.01*polygon.SignedArea(circle) {
+		t.Fatalf("expected area to be preserved within 1%%")
+	}
+}
+
+func TestKeepsHoleInsideBump(t *testing.T) {
+	// Simplifying the perimeter alone would cut off the bump that contains
+	// the hole.
+	outer := []types.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 5, Y: -3}, {X: 6, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	hole := []types.Point{{X: 4.8, Y: -1}, {X: 5.2, Y: -1}, {X: 5, Y: -2}}
+
+	for _, method := range []Method{DouglasPeucker, Visvalingam} {
+		if alone := Loop(outer, WithMethod(method), WithTolerance(5)); len(alone) != 4 {
+			t.Fatalf("method %d: expected the bump to be removed without holes, got %v", method, alone)
+		}
+
+		gotOuter, gotHoles := Polygon(outer, [][]types.Point{hole}, WithMethod(method), WithTolerance(5))
+		if err := pslg.ValidateLoops(gotOuter, gotHoles, types.DefaultEpsilon()); err != nil {
+			t.Fatalf("method %d: simplified polygon invalid: %v (%v %v)", method, err, gotOuter, gotHoles)
+		}
+	}
+}
+
+func TestHolesStayApart(t *testing.T) {
+	outer := noisyCircle(0, 0, 20, 800, 0.05, 3)
+	holes := [][]types.Point{
+		polygon.ReverseIfNeeded(noisyCircle(-2.5, 0, 2, 400, 0.2, 4), false),
+		polygon.ReverseIfNeeded(noisyCircle(2.5, 0, 2, 400, 0.2, 5), false),
+		{{X: -6, Y: 18.2}, {X: -6, Y: 18.8}, {X: -4, Y: 18.8}, {X: -4, Y: 18.2}},
+	}
+	if err := pslg.ValidateLoops(outer, holes, types.DefaultEpsilon()); err != nil {
+		t.Fatalf("test input invalid: %v", err)
+	}
+	if err := pslg.ValidateLoops(Loop(outer, WithTolerance(1.5)), holes[2:], types.DefaultEpsilon()); err == nil {
+		t.Fatalf("expected the perimeter simplified alone to cut into the last hole")
+	}
+
+	for _, method := range []Method{DouglasPeucker, Visvalingam} {
+		gotOuter, gotHoles := Polygon(outer, holes, WithMethod(method), WithTolerance(1.5))
+		if err := pslg.ValidateLoops(gotOuter, gotHoles, types.DefaultEpsilon()); err != nil {
+			t.Fatalf("method %d: simplified polygon invalid: %v", method, err)
+		}
+		total := len(gotOuter)
+		for _, h := range gotHoles {
+			total += len(h)
+		}
+		if total > 200 {
+			t.Fatalf("method %d: expected substantial reduction, got %d points", method, total)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package simplify
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/geometry"
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// span is a simplified edge of a loop, replacing the original points
+// strictly between from and to.
+type span struct {
+	loop     int
+	from, to int
+	minX     float64
+	maxX     float64
+}
+
+// restoreTopology re-inserts original points until no simplified edge
+// crosses or touches another, and no simplified edge sweeps over a kept
+// vertex on its way from the original chain to the shortcut.
+//
+// Conflicts that cannot be fixed because the offending edges are already
+// original edges are left alone, so the loop always terminates.
+func restoreTopology(loops [][]types.Point, keep [][]bool) {
+	for {
+		spans := currentSpans(loops, keep)
+		fixed := false
+		for _, s := range conflicts(loops, keep, spans) {
+			if far, _ := furthest(loops[s.loop], s.from, s.to); far >= 0 {
+				keep[s.loop][far] = true
+				fixed = true
+			}
+		}
+		if !fixed {
+			return
+		}
+	}
+}
+
+func currentSpans(loops [][]types.Point, keep [][]bool) []span {
+	var spans []span
+	for l, loop := range loops {
+		var kept []int
+		for i := range loop {
+			if keep[l][i] {
+				kept = append(kept, i)
+			}
+		}
+		for k, from := range kept {
+			to := kept[(k+1)%len(kept)]
+			a, b := loop[from], loop[to]
+			spans = append(spans, span{loop: l, from: from, to: to, minX: math.Min(a.X, b.X), maxX: math.Max(a.X, b.X)})
+		}
+	}
+	return spans
+}
+
+// conflicts returns the spans involved in a topology violation.
+func conflicts(loops [][]types.Point, keep [][]bool, spans []span) []span {
+	bad := make([]bool, len(spans))
+
+	order := make([]int, len(spans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return spans[order[i]].minX < spans[order[j]].minX })
+
+	for oi, i := range order {
+		si := spans[i]
+		for _, j := range order[oi+1:] {
+			sj := spans[j]
+			if sj.minX > si.maxX {
+				break
+			}
+			if cross(loops, si, sj) {
+				bad[i], bad[j] = true, true
+			}
+		}
+	}
+
+	// Every kept vertex, sorted by X, for the swept-region test.
+	type vertex struct {
+		loop, index int
+		p           types.Point
+	}
+	var vertices []vertex
+	for l, loop := range loops {
+		for i, p := range loop {
+			if keep[l][i] {
+				vertices = append(vertices, vertex{l, i, p})
+			}
+		}
+	}
+	sort.Slice(vertices, func(i, j int) bool { return vertices[i].p.X < vertices[j].p.X })
+
+	for i, s := range spans {
+		if bad[i] {
+			continue
+		}
+		chain := chainOf(loops[s.loop], s.from, s.to)
+		if len(chain) < 3 {
+			continue
+		}
+		box := geometry.BBox(chain)
+
+		start := sort.Search(len(vertices), func(k int) bool { return vertices[k].p.X >= box.Min.X })
+		for _, v := range vertices[start:] {
+			if v.p.X > box.Max.X {
+				break
+			}
+			if v.p.Y < box.Min.Y || v.p.Y > box.Max.Y {
+				continue
+			}
+			if v.loop == s.loop && (v.index == s.from || v.index == s.to) {
+				continue
+			}
+			if polygon.PointInPolygon(v.p, chain) != polygon.Outside {
+				bad[i] = true
+				break
+			}
+		}
+	}
+
+	var out []span
+	for i, s := range spans {
+		if bad[i] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// cross reports whether two simplified edges intersect anywhere other than
+// at an endpoint they share as neighbours in the same loop.
+func cross(loops [][]types.Point, a, b span) bool {
+	pa, qa := loops[a.loop][a.from], loops[a.loop][a.to]
+	pb, qb := loops[b.loop][b.from], loops[b.loop][b.to]
+	if math.Min(pa.Y, qa.Y) > math.Max(pb.Y, qb.Y) || math.Min(pb.Y, qb.Y) > math.Max(pa.Y, qa.Y) {
+		return false
+	}
+
+	ok, t, _ := robust.SegmentIntersect(pa, qa, pb, qb)
+	if !ok {
+		return false
+	}
+	adjacent := a.loop == b.loop && (a.to == b.from || b.to == a.from)
+	if !adjacent {
+		return true
+	}
+	// Neighbours always meet at their shared endpoint; they only conflict
+	// when they fold back over each other.
+	return math.IsNaN(t)
+}
+
+// chainOf returns the original points from index from to index to,
+// walking forward around the loop.
+func chainOf(loop []types.Point, from, to int) []types.Point {
+	chain := []types.Point{loop[from]}
+	for i := (from + 1) % len(loop); i != to; i = (i + 1) % len(loop) {
+		chain = append(chain, loop[i])
+	}
+	return append(chain, loop[to])
+}
@@ -0,0 +1,247 @@
+// Package snap rounds geometry to a fixed-precision grid.
+//
+// Grid snaps points to the nearest grid point and converts them to and from
+// int64 fixed-point coordinates. Round performs snap rounding of a set of
+// segments: every endpoint and every intersection is rounded to its grid
+// point, the hot pixel, and each segment is rerouted through the centres of
+// all hot pixels it passes through. The output segments therefore meet only
+// at shared grid vertices, and two inputs that cross are both split at the
+// same grid point, so stages that consume the result see a consistent
+// topology regardless of floating-point drift upstream.
+//
+// Intersections are found with the algorithm/robust predicates. Snap
+// rounding moves every point by at most half a cell along each axis.
+package snap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Grid is a square grid with spacing Cell and a grid point at the origin.
+//
+// A grid with Cell <= 0 is disabled and leaves points unchanged.
+type Grid struct {
+	Cell float64
+}
+
+// Fixed is a grid point in integer cell units.
+type Fixed struct {
+	X, Y int64
+}
+
+// Enabled reports whether the grid snaps points.
+func (g Grid) Enabled() bool {
+	return g.Cell > 0
+}
+
+// Snap returns the grid point nearest to p.
+func (g Grid) Snap(p types.Point) types.Point {
+	if !g.Enabled() {
+		return p
+	}
+	return g.Point(g.Fixed(p))
+}
+
+// Fixed returns the fixed-point coordinates of the grid point nearest to p.
+//
+// The grid must be enabled.
+func (g Grid) Fixed(p types.Point) Fixed {
+	return Fixed{
+		X: int64(math.Round(p.X / g.Cell)),
+		Y: int64(math.Round(p.Y / g.Cell)),
+	}
+}
+
+// Point returns the coordinates of a fixed-point grid point.
+func (g Grid) Point(f Fixed) types.Point {
+	return types.Point{X: float64(f.X) * g.Cell, Y: float64(f.Y) * g.Cell}
+}
+
+// Result is the output of Round.
+type Result struct {
+	Vertices []types.Point // Distinct grid points, in order of first use
+	Segments [][2]int      // Distinct output segments (indices into Vertices)
+	Chains   [][]int       // Per input segment, the vertices it runs through from start to end
+}
+
+// Round snap-rounds segs to the grid.
+//
+// Each chain starts at the snapped start point and ends at the snapped end
+// point of its input segment; a segment shorter than a cell may collapse to
+// a single vertex. Pieces shared by several inputs, such as collinear
+// overlaps, appear once in Segments. The grid must be enabled.
+func Round(segs [][2]types.Point, g Grid) *Result {
+	r := &rounder{grid: g, hot: make(map[types.Point]bool)}
+	for _, s := range segs {
+		r.addHot(s[0])
+		r.addHot(s[1])
+	}
+	r.addIntersections(segs)
+	sort.Slice(r.pixels, func(i, j int) bool {
+		if r.pixels[i].X != r.pixels[j].X {
+			return r.pixels[i].X < r.pixels[j].X
+		}
+		return r.pixels[i].Y < r.pixels[j].Y
+	})
+
+	res := &Result{Chains: make([][]int, len(segs))}
+	ids := make(map[types.Point]int)
+	vertex := func(p types.Point) int {
+		id, ok := ids[p]
+		if !ok {
+			id = len(res.Vertices)
+			ids[p] = id
+			res.Vertices = append(res.Vertices, p)
+		}
+		return id
+	}
+
+	seen := make(map[[2]int]bool)
+	for i, s := range segs {
+		var chain []int
+		for _, p := range r.route(s[0], s[1]) {
+			if id := vertex(p); len(chain) == 0 || chain[len(chain)-1] != id {
+				chain = append(chain, id)
+			}
+		}
+		res.Chains[i] = chain
+
+		for k := 1; k < len(chain); k++ {
+			key := [2]int{chain[k-1], chain[k]}
+			if key[0] > key[1] {
+				key[0], key[1] = key[1], key[0]
+			}
+			if !seen[key] {
+				seen[key] = true
+				res.Segments = append(res.Segments, [2]int{chain[k-1], chain[k]})
+			}
+		}
+	}
+	return res
+}
+
+// rounder holds the hot pixels of a Round call, keyed by their centres.
+type rounder struct {
+	grid   Grid
+	hot    map[types.Point]bool
+	pixels []types.Point
+}
+
+func (r *rounder) addHot(p types.Point) {
+	c := r.grid.Snap(p)
+	if !r.hot[c] {
+		r.hot[c] = true
+		r.pixels = append(r.pixels, c)
+	}
+}
+
+// addIntersections marks the pixels containing the proper and touching
+// intersections of every pair of segments. Collinear overlaps need no extra
+// pixels: their extent is bounded by endpoints, which are already hot.
+func (r *rounder) addIntersections(segs [][2]types.Point) {
+	order := make([]int, len(segs))
+	for i := range order {
+		order[i] = i
+	}
+	minX := func(i int) float64 { return math.Min(segs[i][0].X, segs[i][1].X) }
+	sort.Slice(order, func(i, j int) bool { return minX(order[i]) < minX(order[j]) })
+
+	for oi, i := range order {
+		a, b := segs[i][0], segs[i][1]
+		maxX := math.Max(a.X, b.X)
+		for _, j := range order[oi+1:] {
+			if minX(j) > maxX {
+				break
+			}
+			c, d := segs[j][0], segs[j][1]
+			if math.Min(a.Y, b.Y) > math.Max(c.Y, d.Y) || math.Min(c.Y, d.Y) > math.Max(a.Y, b.Y) {
+				continue
+			}
+			ok, t, _ := robust.SegmentIntersect(a, b, c, d)
+			if ok && !math.IsNaN(t) {
+				r.addHot(types.Point{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)})
+			}
+		}
+	}
+}
+
+// route returns the centres of the hot pixels that segment [a,b] passes
+// through, in the order it enters them, starting and ending with the pixels
+// of its own endpoints.
+func (r *rounder) route(a, b types.Point) []types.Point {
+	start, end := r.grid.Snap(a), r.grid.Snap(b)
+	out := []types.Point{start}
+	if start == end {
+		return out
+	}
+
+	half := r.grid.Cell / 2
+	lo := sort.Search(len(r.pixels), func(i int) bool { return r.pixels[i].X >= math.Min(a.X, b.X)-half })
+	maxX := math.Max(a.X, b.X) + half
+	minY, maxY := math.Min(a.Y, b.Y)-half, math.Max(a.Y, b.Y)+half
+
+	type hit struct {
+		t float64
+		p types.Point
+	}
+	var hits []hit
+	for _, c := range r.pixels[lo:] {
+		if c.X > maxX {
+			break
+		}
+		if c.Y < minY || c.Y > maxY || c == start || c == end {
+			continue
+		}
+		pixel := types.AABB{
+			Min: types.Point{X: c.X - half, Y: c.Y - half},
+			Max: types.Point{X: c.X + half, Y: c.Y + half},
+		}
+		if t, ok := enter(a, b, pixel); ok {
+			hits = append(hits, hit{t, c})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].t < hits[j].t })
+
+	for _, h := range hits {
+		out = append(out, h.p)
+	}
+	return append(out, end)
+}
+
+// enter clips [a,b] against box (Liang–Barsky) and returns the parameter at
+// which the segment enters it.
+func enter(a, b types.Point, box types.AABB) (float64, bool) {
+	t0, t1 := 0.0, 1.0
+	dx, dy := b.X-a.X, b.Y-a.Y
+	for _, c := range [4][2]float64{
+		{-dx, a.X - box.Min.X},
+		{dx, box.Max.X - a.X},
+		{-dy, a.Y - box.Min.Y},
+		{dy, box.Max.Y - a.Y},
+	} {
+		p, q := c[0], c[1]
+		if p == 0 {
+			if q < 0 {
+				return 0, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return 0, false
+			}
+			t0 = math.Max(t0, t)
+		} else {
+			if t < t0 {
+				return 0, false
+			}
+			t1 = math.Min(t1, t)
+		}
+	}
+	return t0, true
+}
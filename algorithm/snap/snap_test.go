@@ -0,0 +1,117 @@
+package snap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestGridSnap(t *testing.T) {
+	g := Grid{Cell: 0.25}
+	p := types.Point{X: 1.13, Y: -0.37}
+	got := g.Snap(p)
+	if want := (types.Point{X: 1.25, Y: -0.25}); got != want {
+		t.Fatalf("Snap(%v) = %v, want %v", p, got, want)
+	}
+	if f := g.Fixed(p); f != (Fixed{X: 5, Y: -1}) {
+		t.Fatalf("Fixed(%v) = %v", p, f)
+	}
+	if g.Snap(got) != got {
+		t.Fatalf("snapping is not idempotent")
+	}
+
+	// Points that drifted apart in floating point land on the same vertex.
+	if g.Snap(types.Point{X: 0.1 + 0.2, Y: 0}) != g.Snap(types.Point{X: 0.3, Y: 0}) {
+		t.Fatalf("expected drifted points to snap together")
+	}
+
+	if (Grid{}).Snap(p) != p {
+		t.Fatalf("a disabled grid must not move points")
+	}
+}
+
+func TestRoundSplitsCrossingSegments(t *testing.T) {
+	segs := [][2]types.Point{
+		{{X: 0, Y: 0}, {X: 4, Y: 4.1}},
+		{{X: 0, Y: 4}, {X: 4, Y: 0.1}},
+	}
+	res := Round(segs, Grid{Cell: 1})
+
+	mid := types.Point{X: 2, Y: 2}
+	for i, chain := range res.Chains {
+		if len(chain) != 3 || res.Vertices[chain[1]] != mid {
+			t.Fatalf("chain %d = %v, want to pass through %v", i, chain, mid)
+		}
+	}
+	if res.Chains[0][1] != res.Chains[1][1] {
+		t.Fatalf("crossing segments must share the intersection vertex")
+	}
+	if len(res.Segments) != 4 {
+		t.Fatalf("expected 4 output segments, got %d", len(res.Segments))
+	}
+}
+
+func TestRoundReroutesThroughHotPixels(t *testing.T) {
+	// The second segment ends within a cell of the first, so the first is
+	// bent through that endpoint's grid point.
+	segs := [][2]types.Point{
+		{{X: 0, Y: 0}, {X: 10, Y: 0}},
+		{{X: 5.2, Y: 0.4}, {X: 5, Y: 5}},
+	}
+	res := Round(segs, Grid{Cell: 1})
+
+	if got := len(res.Chains[0]); got != 3 {
+		t.Fatalf("expected the first segment to be split, chain %v", res.Chains[0])
+	}
+	if res.Chains[0][1] != res.Chains[1][0] {
+		t.Fatalf("expected the split vertex to be the second segment's start")
+	}
+}
+
+func TestRoundMergesOverlapsAndCollapses(t *testing.T) {
+	segs := [][2]types.Point{
+		{{X: 0, Y: 0}, {X: 4, Y: 0}},
+		{{X: 2, Y: 0}, {X: 6, Y: 0}},
+		{{X: 8, Y: 8}, {X: 8.2, Y: 8.1}},
+	}
+	res := Round(segs, Grid{Cell: 1})
+
+	if len(res.Segments) != 3 {
+		t.Fatalf("expected the overlap to be shared, got segments %v", res.Segments)
+	}
+	if len(res.Chains[2]) != 1 {
+		t.Fatalf("expected a sub-cell segment to collapse, got %v", res.Chains[2])
+	}
+}
+
+func TestRoundOutputDoesNotCross(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	segs := make([][2]types.Point, 60)
+	for i := range segs {
+		for j := range segs[i] {
+			segs[i][j] = types.Point{X: rng.Float64() * 20, Y: rng.Float64() * 20}
+		}
+	}
+	g := Grid{Cell: 0.5}
+	res := Round(segs, g)
+
+	for _, p := range res.Vertices {
+		if g.Snap(p) != p {
+			t.Fatalf("vertex %v is not on the grid", p)
+		}
+	}
+	for i, s := range res.Segments {
+		a1, a2 := res.Vertices[s[0]], res.Vertices[s[1]]
+		for _, o := range res.Segments[i+1:] {
+			b1, b2 := res.Vertices[o[0]], res.Vertices[o[1]]
+			switch robust.SegmentIntersectionKind(a1, a2, b1, b2) {
+			case types.IntersectProper:
+				t.Fatalf("segments %v-%v and %v-%v cross", a1, a2, b1, b2)
+			case types.IntersectCollinearOverlap:
+				t.Fatalf("segments %v-%v and %v-%v overlap", a1, a2, b1, b2)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package cdt
 import (
 	"fmt"
 
+	"github.com/iceisfun/gomesh/algorithm/snap"
 	"github.com/iceisfun/gomesh/mesh"
 	"github.com/iceisfun/gomesh/types"
 )
@@ -22,6 +23,22 @@ type BuildOptions struct {
 	// UseFloodFill enables flood-fill based classification instead of centroid-based
 	UseFloodFill bool
 
+	// Conforming splits constraint segments with Steiner points until every
+	// piece is a Delaunay edge, producing a true Delaunay triangulation
+	// instead of a constrained one
+	Conforming bool
+
+	// MaxSteinerPoints caps the vertices Conforming may add (0 = 100000)
+	MaxSteinerPoints int
+
+	// SnapGrid, when positive, snap-rounds the perimeter, holes and extra
+	// constraints to a grid of this spacing before triangulating (see
+	// algorithm/snap). Every input vertex and every crossing then lands on a
+	// grid point, and crossing constraints are split there. Steiner points
+	// added by Conforming are not snapped. Pass mesh.WithSnapGrid in
+	// MeshOptions to keep later edits on the same grid.
+	SnapGrid float64
+
 	// MeshOptions are passed to the final mesh constructor
 	MeshOptions []mesh.Option
 }
@@ -45,23 +62,40 @@ func DefaultBuildOptions() BuildOptions {
 //  3. Insert all vertices using incremental Delaunay insertion
 //  4. Insert all constrained edges (perimeter, holes, extra constraints)
 //  5. Legalize non-constrained edges to conform to Delaunay property
+//     (and, in conforming mode, split constraints that are not Delaunay)
 //  6. Classify and remove triangles outside the valid region
 //  7. Remove cover vertices and export to mesh.Mesh
 func Build(outer []types.Point, holes [][]types.Point, extras [][2]types.Point, opts BuildOptions) (*mesh.Mesh, error) {
-	// Step 1: Normalize PSLG
+	m, _, err := BuildWithReport(outer, holes, extras, opts)
+	return m, err
+}
+
+// BuildWithReport is Build that also reports the Steiner points added in
+// conforming mode and which input segments they subdivide.
+func BuildWithReport(outer []types.Point, holes [][]types.Point, extras [][2]types.Point, opts BuildOptions) (*mesh.Mesh, *BuildReport, error) {
+	// Step 1: Snap to the grid if requested, then normalize the PSLG
+	var extraIndex []int
+	if opts.SnapGrid > 0 {
+		var err error
+		outer, holes, extras, extraIndex, err = snapInput(outer, holes, extras, snap.Grid{Cell: opts.SnapGrid})
+		if err != nil {
+			return nil, nil, fmt.Errorf("snap rounding failed: %w", err)
+		}
+	}
+
 	pslg, err := NormalizePSLG(outer, holes, extras, opts.Epsilon)
 	if err != nil {
-		return nil, fmt.Errorf("PSLG normalization failed: %w", err)
+		return nil, nil, fmt.Errorf("PSLG normalization failed: %w", err)
 	}
 
 	if err := ValidatePSLG(pslg); err != nil {
-		return nil, fmt.Errorf("PSLG validation failed: %w", err)
+		return nil, nil, fmt.Errorf("PSLG validation failed: %w", err)
 	}
 
 	// Step 2: Create bounding cover
 	ts, coverVerts, err := SeedTriangulation(pslg.Vertices, opts.CoverMargin)
 	if err != nil {
-		return nil, fmt.Errorf("seed triangulation failed: %w", err)
+		return nil, nil, fmt.Errorf("seed triangulation failed: %w", err)
 	}
 
 	// Step 3: Insert all PSLG vertices
@@ -111,13 +145,13 @@ func Build(outer []types.Point, holes [][]types.Point, extras [][2]types.Point,
 		loc, err := locator.LocatePoint(p)
 		if err != nil {
 			fmt.Printf("[Builder] LocatePoint failed for vertex %d at (%.12f, %.12f)\n", vidx, p.X, p.Y)
-			return nil, fmt.Errorf("failed to locate vertex %d: %w", vidx, err)
+			return nil, nil, fmt.Errorf("failed to locate vertex %d: %w", vidx, err)
 		}
 
 		// Insert the point
 		_, edgesToLegalize, err := InsertPoint(ts, loc, vidx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert vertex %d: %w", vidx, err)
+			return nil, nil, fmt.Errorf("failed to insert vertex %d: %w", vidx, err)
 		}
 
 		// Legalize edges (Delaunay conformance)
@@ -127,13 +161,13 @@ func Build(outer []types.Point, holes [][]types.Point, extras [][2]types.Point,
 	// Step 4: Insert constrained edges
 	// Insert outer perimeter
 	if err := InsertConstraintLoop(ts, pslg.Outer, constrained); err != nil {
-		return nil, fmt.Errorf("failed to insert outer perimeter: %w", err)
+		return nil, nil, fmt.Errorf("failed to insert outer perimeter: %w", err)
 	}
 
 	// Insert holes
 	for i, hole := range pslg.Holes {
 		if err := InsertConstraintLoop(ts, hole, constrained); err != nil {
-			return nil, fmt.Errorf("failed to insert hole %d: %w", i, err)
+			return nil, nil, fmt.Errorf("failed to insert hole %d: %w", i, err)
 		}
 	}
 
@@ -146,7 +180,7 @@ func Build(outer []types.Point, holes [][]types.Point, extras [][2]types.Point,
 		}
 
 		if err := InsertConstraintEdge(ts, seg[0], seg[1], constrained); err != nil {
-			return nil, fmt.Errorf("failed to insert constraint segment %d: %w", i, err)
+			return nil, nil, fmt.Errorf("failed to insert constraint segment %d: %w", i, err)
 		}
 	}
 
@@ -163,6 +197,15 @@ func Build(outer []types.Point, holes [][]types.Point, extras [][2]types.Point,
 	}
 	LegalizeAround(ts, allEdges, constrained)
 
+	report := &BuildReport{}
+	var chains map[EdgeKey][]int
+	if opts.Conforming {
+		chains, report.SteinerPoints, err = conform(ts, constrained, numOriginalVerts, opts.MaxSteinerPoints)
+		if err != nil {
+			return nil, nil, fmt.Errorf("conforming refinement failed: %w", err)
+		}
+	}
+
 	// Step 6: Classify and prune triangles
 	if opts.UseFloodFill {
 		PruneByFloodFill(ts, pslg, constrained)
@@ -175,16 +218,35 @@ func Build(outer []types.Point, holes [][]types.Point, extras [][2]types.Point,
 
 	// Validate topology before export
 	if err := ValidateTopology(ts); err != nil {
-		return nil, fmt.Errorf("topology validation failed: %w", err)
+		return nil, nil, fmt.Errorf("topology validation failed: %w", err)
 	}
 
 	// Step 8: Export to mesh.Mesh
-	m, err := ExportToMesh(ts, opts.MeshOptions...)
+	m, ids, err := exportToMesh(ts, opts.MeshOptions...)
 	if err != nil {
-		return nil, fmt.Errorf("mesh export failed: %w", err)
+		return nil, nil, fmt.Errorf("mesh export failed: %w", err)
+	}
+
+	for _, v := range pslg.Crossings {
+		id, ok := ids[v]
+		if !ok {
+			id = types.NilVertex
+		}
+		report.Crossings = append(report.Crossings, id)
+	}
+	if chains != nil {
+		report.Subdivided = subdivisionReport(pslg, ts, chains, ids)
+		if extraIndex != nil {
+			// Report snapped pieces against the extra they came from.
+			for i := range report.Subdivided {
+				if s := &report.Subdivided[i]; s.Kind == SegmentExtra {
+					s.Index = extraIndex[s.Index]
+				}
+			}
+		}
 	}
 
-	return m, nil
+	return m, report, nil
 }
 
 // BuildSimple is a convenience wrapper that uses default options.
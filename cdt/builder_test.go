@@ -123,6 +123,46 @@ func TestBuildWithConstraints(t *testing.T) {
 	t.Logf("Square with constraint mesh: %d vertices, %d triangles", mesh.NumVertices(), mesh.NumTriangles())
 }
 
+func TestBuildWithSnapGrid(t *testing.T) {
+	// Perimeter vertices drifted by float error, and two crossing
+	// constraints whose intersection is not representable on its own.
+	outer := []types.Point{
+		{X: 0.1 + 0.2 - 0.3, Y: 0},
+		{X: 10, Y: 1e-12},
+		{X: 10 - 1e-13, Y: 10},
+		{X: 0, Y: 10},
+	}
+	constraints := [][2]types.Point{
+		{{X: 1, Y: 1}, {X: 9, Y: 8.3}},
+		{{X: 1, Y: 8}, {X: 9, Y: 1.3}},
+	}
+
+	opts := DefaultBuildOptions()
+	opts.SnapGrid = 0.5
+	mesh, err := BuildWithOptions(outer, nil, constraints, opts)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	onGrid := func(v float64) bool { return v/0.5 == float64(int(v/0.5)) }
+	var crossing types.VertexID = types.NilVertex
+	for i := 0; i < mesh.NumVertices(); i++ {
+		p := mesh.GetVertex(types.VertexID(i))
+		if !onGrid(p.X) || !onGrid(p.Y) {
+			t.Fatalf("vertex %v is not on the grid", p)
+		}
+		if p == (types.Point{X: 5, Y: 4.5}) {
+			crossing = types.VertexID(i)
+		}
+	}
+	if crossing == types.NilVertex {
+		t.Fatal("expected the constraints to be split at their snapped crossing")
+	}
+	if n := len(mesh.VertexTriangles(crossing)); n < 4 {
+		t.Fatalf("expected the crossing vertex to join both constraints, got %d triangles", n)
+	}
+}
+
 func TestBuildLShape(t *testing.T) {
 	// L-shaped polygon
 	outer := []types.Point{
@@ -219,25 +219,28 @@ func FindSeedTriangle(ts *TriSoup, pslg *PSLG) (TriID, bool) {
 
 // PruneByFloodFill removes triangles using flood fill classification.
 // This is more accurate than centroid-based pruning for complex geometries.
+//
+// Constrained edges split the triangulation into regions. Each region is
+// classified by one of its triangles and kept or removed as a whole, so
+// cells enclosed by extra constraints survive along with the rest.
 func PruneByFloodFill(ts *TriSoup, pslg *PSLG, constrained map[EdgeKey]bool) int {
-	// Find a seed triangle
-	seed, ok := FindSeedTriangle(ts, pslg)
-	if !ok {
-		// No valid triangles found - remove all
-		removed := 0
-		for i := range ts.Tri {
-			if !ts.IsDeleted(TriID(i)) {
-				ts.RemoveTri(TriID(i))
-				removed++
+	inside := make(map[TriID]bool)
+	visited := make(map[TriID]bool)
+	for i := range ts.Tri {
+		seed := TriID(i)
+		if ts.IsDeleted(seed) || visited[seed] {
+			continue
+		}
+
+		keep := ClassifyTriangle(ts, seed, pslg) == Inside
+		for t := range FloodFillClassify(ts, seed, pslg, constrained) {
+			visited[t] = true
+			if keep {
+				inside[t] = true
 			}
 		}
-		CleanStaleNeighborsAfterPrune(ts)
-		return removed
 	}
 
-	// Flood fill to find all inside triangles
-	inside := FloodFillClassify(ts, seed, pslg, constrained)
-
 	// Remove triangles not in the inside set
 	removed := 0
 	for i := range ts.Tri {
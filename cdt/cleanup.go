@@ -63,6 +63,13 @@ func CleanStaleNeighbors(ts *TriSoup) {
 // Only non-deleted triangles are exported.
 // Vertices are remapped to exclude unused vertices (like cover vertices).
 func ExportToMesh(ts *TriSoup, opts ...mesh.Option) (*mesh.Mesh, error) {
+	m, _, err := exportToMesh(ts, opts...)
+	return m, err
+}
+
+// exportToMesh is ExportToMesh that also returns the mesh vertex ID of
+// every exported TriSoup vertex.
+func exportToMesh(ts *TriSoup, opts ...mesh.Option) (*mesh.Mesh, map[int]types.VertexID, error) {
 	// Find all vertices actually used by non-deleted triangles
 	usedVerts := make(map[int]bool)
 	for i := range ts.Tri {
@@ -94,7 +101,7 @@ func ExportToMesh(ts *TriSoup, opts ...mesh.Option) (*mesh.Mesh, error) {
 	for oldIdx, newIdx := range vertexRemap {
 		vid, err := m.AddVertex(newVertices[newIdx])
 		if err != nil {
-			return nil, fmt.Errorf("failed to add vertex %d: %w", newIdx, err)
+			return nil, nil, fmt.Errorf("failed to add vertex %d: %w", newIdx, err)
 		}
 		actualVertexIDs[oldIdx] = vid
 	}
@@ -111,11 +118,11 @@ func ExportToMesh(ts *TriSoup, opts ...mesh.Option) (*mesh.Mesh, error) {
 		v3 := actualVertexIDs[tri.V[2]]
 
 		if err := m.AddTriangle(v1, v2, v3); err != nil {
-			return nil, fmt.Errorf("failed to add triangle %d: %w", i, err)
+			return nil, nil, fmt.Errorf("failed to add triangle %d: %w", i, err)
 		}
 	}
 
-	return m, nil
+	return m, actualVertexIDs, nil
 }
 
 // CompactTriSoup removes deleted triangles and unused vertices from the TriSoup.
@@ -0,0 +1,240 @@
+package cdt
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+// ErrSteinerLimit is returned when conforming mode needs more Steiner points
+// than BuildOptions.MaxSteinerPoints allows.
+var ErrSteinerLimit = errors.New("steiner point limit reached")
+
+// defaultMaxSteinerPoints is used when BuildOptions.MaxSteinerPoints is not positive.
+const defaultMaxSteinerPoints = 100000
+
+// SegmentKind identifies which part of the input a constraint segment came from.
+type SegmentKind int
+
+const (
+	// SegmentOuter is an edge of the outer perimeter.
+	SegmentOuter SegmentKind = iota
+	// SegmentHole is an edge of a hole.
+	SegmentHole
+	// SegmentExtra is an extra constraint segment.
+	SegmentExtra
+)
+
+// String returns a human-readable name for the segment kind.
+func (k SegmentKind) String() string {
+	switch k {
+	case SegmentOuter:
+		return "outer"
+	case SegmentHole:
+		return "hole"
+	case SegmentExtra:
+		return "extra"
+	default:
+		return "unknown"
+	}
+}
+
+// SubdividedSegment describes an input constraint segment that conforming
+// mode split with Steiner points.
+type SubdividedSegment struct {
+	Kind SegmentKind
+
+	// Index is the hole index for SegmentHole, the index into the extras
+	// argument for SegmentExtra, and zero for SegmentOuter.
+	Index int
+
+	// Start and End are the segment endpoints after vertex merging and
+	// splitting at crossings, so a constraint crossed by another is reported
+	// piece by piece. Loop edges run in the normalized winding (outer CCW,
+	// holes CW), which may be the reverse of the input.
+	Start, End types.Point
+
+	// Points lists Start, every Steiner point in order along the segment,
+	// then End.
+	Points []types.Point
+
+	// Vertices holds the mesh vertex ID of each entry in Points, or
+	// types.NilVertex for vertices that were pruned with the triangles
+	// outside the domain.
+	Vertices []types.VertexID
+}
+
+// BuildReport describes the changes Build made to the input.
+type BuildReport struct {
+	// SteinerPoints is the number of vertices added by conforming mode.
+	SteinerPoints int
+
+	// Subdivided lists the input segments that were split, outer edges
+	// first, then hole edges, then extras.
+	Subdivided []SubdividedSegment
+
+	// Crossings holds the mesh vertex ID of every vertex added where input
+	// segments cross (see NormalizePSLG), or types.NilVertex for crossings
+	// that were pruned with the triangles outside the domain.
+	Crossings []types.VertexID
+}
+
+// conform splits constrained edges with Steiner points until every one of
+// them is locally Delaunay, which makes the whole triangulation Delaunay.
+// Vertices below numInput are input vertices. The returned map gives, for
+// each original constrained edge, the chain of vertices from key.A to key.B.
+func conform(ts *TriSoup, constrained map[EdgeKey]bool, numInput, limit int) (map[EdgeKey][]int, int, error) {
+	if limit <= 0 {
+		limit = defaultMaxSteinerPoints
+	}
+
+	owner := make(map[EdgeKey]EdgeKey, len(constrained))
+	chains := make(map[EdgeKey][]int, len(constrained))
+	for key := range constrained {
+		owner[key] = key
+		chains[key] = []int{key.A, key.B}
+	}
+
+	encroached := func(key EdgeKey) (edgeUse, bool) {
+		uses := ts.FindEdgeTriangles(key.A, key.B)
+		for _, u := range uses {
+			if IsIllegal(ts, u.T, u.LocalEdge, nil) {
+				return uses[0], true
+			}
+		}
+		return edgeUse{}, false
+	}
+
+	added := 0
+	for {
+		// A split can encroach on edges that were already checked, so repeat
+		// full passes until one makes no change.
+		queue := make([]EdgeKey, 0, len(constrained))
+		for key := range constrained {
+			queue = append(queue, key)
+		}
+		sort.Slice(queue, func(i, j int) bool {
+			if queue[i].A != queue[j].A {
+				return queue[i].A < queue[j].A
+			}
+			return queue[i].B < queue[j].B
+		})
+
+		split := false
+		for len(queue) > 0 {
+			key := queue[0]
+			queue = queue[1:]
+
+			use, ok := encroached(key)
+			if !ok {
+				continue
+			}
+			if added >= limit {
+				return nil, added, ErrSteinerLimit
+			}
+
+			vidx := len(ts.V)
+			ts.V = append(ts.V, splitPoint(ts.V[key.A], ts.V[key.B], key.A < numInput, key.B < numInput))
+			_, edges, err := InsertPoint(ts, Location{T: use.T, OnEdge: true, Edge: use.LocalEdge}, vidx)
+			if err != nil {
+				ts.V = ts.V[:vidx]
+				return nil, added, err
+			}
+			added++
+			split = true
+
+			a, b := NewEdgeKey(key.A, vidx), NewEdgeKey(vidx, key.B)
+			delete(constrained, key)
+			constrained[a], constrained[b] = true, true
+
+			root := owner[key]
+			delete(owner, key)
+			owner[a], owner[b] = root, root
+			chain := chains[root]
+			for i := 1; i < len(chain); i++ {
+				if NewEdgeKey(chain[i-1], chain[i]) == key {
+					chain = append(chain[:i], append([]int{vidx}, chain[i:]...)...)
+					break
+				}
+			}
+			chains[root] = chain
+
+			LegalizeAround(ts, edges, constrained)
+			queue = append(queue, a, b)
+		}
+
+		if !split {
+			return chains, added, nil
+		}
+	}
+}
+
+// splitPoint chooses where to split segment ab. When exactly one endpoint is
+// an input vertex the split lands at a power-of-two distance from it, so
+// segments meeting at a small angle are split on shared concentric circles
+// instead of encroaching on each other forever.
+func splitPoint(a, b types.Point, aInput, bInput bool) types.Point {
+	if aInput == bInput {
+		return types.Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+	}
+	if bInput {
+		a, b = b, a
+	}
+
+	length := math.Hypot(b.X-a.X, b.Y-a.Y)
+	t := math.Exp2(math.Round(math.Log2(length/2))) / length
+	t = math.Max(0.25, math.Min(0.75, t))
+	return types.Point{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)}
+}
+
+// subdivisionReport lists the input segments of pslg whose chains gained
+// Steiner points. ids maps exported TriSoup vertices to mesh vertex IDs.
+func subdivisionReport(pslg *PSLG, ts *TriSoup, chains map[EdgeKey][]int, ids map[int]types.VertexID) []SubdividedSegment {
+	var out []SubdividedSegment
+	add := func(kind SegmentKind, index, u, v int) {
+		chain := chains[NewEdgeKey(u, v)]
+		if len(chain) <= 2 {
+			return
+		}
+
+		s := SubdividedSegment{
+			Kind:     kind,
+			Index:    index,
+			Start:    ts.V[u],
+			End:      ts.V[v],
+			Points:   make([]types.Point, len(chain)),
+			Vertices: make([]types.VertexID, len(chain)),
+		}
+		for i := range chain {
+			vidx := chain[i]
+			if chain[0] != u {
+				vidx = chain[len(chain)-1-i]
+			}
+			s.Points[i] = ts.V[vidx]
+			s.Vertices[i] = types.NilVertex
+			if id, ok := ids[vidx]; ok {
+				s.Vertices[i] = id
+			}
+		}
+		out = append(out, s)
+	}
+
+	loop := func(kind SegmentKind, index int, indices []int) {
+		for i := range indices {
+			add(kind, index, indices[i], indices[(i+1)%len(indices)])
+		}
+	}
+
+	loop(SegmentOuter, 0, pslg.Outer)
+	for i, hole := range pslg.Holes {
+		loop(SegmentHole, i, hole)
+	}
+	for i, path := range pslg.ExtraPaths {
+		for k := 1; k < len(path); k++ {
+			add(SegmentExtra, i, path[k-1], path[k])
+		}
+	}
+	return out
+}
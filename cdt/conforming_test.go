@@ -0,0 +1,123 @@
+package cdt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// emptyCircumcircles reports whether no mesh vertex lies strictly inside the
+// circumcircle of any triangle.
+func emptyCircumcircles(m *mesh.Mesh) bool {
+	vertices := m.GetVertices()
+	for i := 0; i < m.NumTriangles(); i++ {
+		a, b, c := m.GetTriangleCoords(i)
+		for _, p := range vertices {
+			if robust.InCircle(a, b, c, p) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestConformingBuild(t *testing.T) {
+	// The long constraint passes between two close vertices, so the
+	// triangles on either side have huge circumcircles.
+	outer := []types.Point{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 1}, {X: 5, Y: 1}, {X: 0, Y: 1}}
+	extras := [][2]types.Point{{{X: 1, Y: 0.5}, {X: 9, Y: 0.5}}}
+
+	m, err := Build(outer, nil, extras, DefaultBuildOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if emptyCircumcircles(m) {
+		t.Fatal("expected the constrained triangulation not to be Delaunay")
+	}
+
+	opts := DefaultBuildOptions()
+	opts.Conforming = true
+	m, report, err := BuildWithReport(outer, nil, extras, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !emptyCircumcircles(m) {
+		t.Fatal("conforming triangulation is not Delaunay")
+	}
+	if report.SteinerPoints == 0 || len(report.Subdivided) == 0 {
+		t.Fatalf("expected Steiner points to be reported, got %+v", report)
+	}
+	if got, want := m.NumVertices(), len(outer)+2+report.SteinerPoints; got != want {
+		t.Fatalf("expected %d vertices, got %d", want, got)
+	}
+
+	steiner := 0
+	for _, s := range report.Subdivided {
+		n := len(s.Points)
+		if n < 3 || s.Points[0] != s.Start || s.Points[n-1] != s.End || len(s.Vertices) != n {
+			t.Fatalf("malformed subdivision %+v", s)
+		}
+		for i, p := range s.Points {
+			if robust.Orient2D(s.Start, s.End, p) != 0 {
+				t.Fatalf("%s segment point %v is off the segment", s.Kind, p)
+			}
+			if s.Vertices[i] == types.NilVertex {
+				t.Fatalf("%s segment vertex %d missing from mesh", s.Kind, i)
+			}
+			if got := m.GetVertex(s.Vertices[i]); got != p {
+				t.Fatalf("vertex %d is %v, expected %v", s.Vertices[i], got, p)
+			}
+		}
+		if s.Kind == SegmentOuter && s.Start == outer[0] && s.End != outer[1] {
+			t.Fatalf("unexpected outer segment %v-%v", s.Start, s.End)
+		}
+		steiner += n - 2
+	}
+	if steiner != report.SteinerPoints {
+		t.Fatalf("report lists %d Steiner points, expected %d", steiner, report.SteinerPoints)
+	}
+}
+
+func TestConformingBuildWithHole(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 3}, {X: 10, Y: 3}, {X: 0, Y: 3}}
+	hole := []types.Point{{X: 2, Y: 1}, {X: 18, Y: 1}, {X: 18, Y: 2}, {X: 2, Y: 2}}
+
+	m, err := Build(outer, [][]types.Point{hole}, nil, DefaultBuildOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if emptyCircumcircles(m) {
+		t.Fatal("expected the constrained triangulation not to be Delaunay")
+	}
+
+	opts := DefaultBuildOptions()
+	opts.Conforming = true
+	m, report, err := BuildWithReport(outer, [][]types.Point{hole}, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !emptyCircumcircles(m) {
+		t.Fatal("conforming triangulation is not Delaunay")
+	}
+
+	holeSplits := 0
+	for _, s := range report.Subdivided {
+		if s.Kind == SegmentHole {
+			holeSplits++
+			if s.Index != 0 {
+				t.Fatalf("expected hole index 0, got %d", s.Index)
+			}
+		}
+	}
+	if holeSplits == 0 {
+		t.Fatal("expected the long hole edges to be subdivided")
+	}
+
+	opts.MaxSteinerPoints = report.SteinerPoints - 1
+	if _, _, err := BuildWithReport(outer, [][]types.Point{hole}, nil, opts); !errors.Is(err, ErrSteinerLimit) {
+		t.Fatalf("expected ErrSteinerLimit, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package cdt
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// splitCrossings splits the segments of p wherever they cross, overlap, or
+// end on another segment's interior, so that constraint insertion never has
+// to cut through an existing constraint.
+//
+// Crossing points become new vertices, listed in p.Crossings; a crossing
+// within tolerance of an existing vertex reuses it. Loops are rebuilt through
+// the vertices inserted on their edges, and p.ExtraPaths records the vertices
+// each extra constraint now runs through.
+//
+// A piece ending at a rounded or merged crossing can cross another piece, or
+// pass over a vertex, that its segment did not, so the pieces are split again
+// until none changes. Later passes only find crossings within tolerance of an
+// earlier split, and a path never revisits a vertex, so in practice this
+// settles after a few passes.
+func splitCrossings(p *PSLG, eps types.Epsilon) {
+	index := newVertexIndex(p, eps)
+	paths := make([][]int, len(p.Segments))
+	for i, seg := range p.Segments {
+		paths[i] = []int{seg[0], seg[1]}
+	}
+
+	for changed := true; changed; {
+		splits := findSplits(p, paths, index, eps)
+		changed = false
+		n := 0
+		for i, path := range paths {
+			next := []int{path[0]}
+			for k := 1; k < len(path); k++ {
+				next = append(append(next, splits[n]...), path[k])
+				n++
+			}
+			if !slices.Equal(next, path) {
+				paths[i] = next
+				changed = true
+			}
+		}
+	}
+
+	// Replace every segment by its pieces, ordered along it.
+	byEnds := make(map[[2]int][]int, 2*len(paths))
+	var pieces [][2]int
+	for i, seg := range p.Segments {
+		path := paths[i]
+		for k := 1; k < len(path); k++ {
+			pieces = append(pieces, [2]int{path[k-1], path[k]})
+		}
+		byEnds[seg] = path
+		byEnds[[2]int{seg[1], seg[0]}] = reverseIndices(path)
+	}
+	p.Segments = DedupSegments(pieces)
+
+	loop := func(indices []int) []int {
+		var out []int
+		for k := range indices {
+			path := byEnds[[2]int{indices[k], indices[(k+1)%len(indices)]}]
+			if path == nil {
+				// A loop collapsed to one vertex has no segments.
+				continue
+			}
+			out = append(out, path[:len(path)-1]...)
+		}
+		return out
+	}
+	p.Outer = loop(p.Outer)
+	for i, hole := range p.Holes {
+		p.Holes[i] = loop(hole)
+	}
+
+	p.ExtraPaths = make([][]int, len(p.Extras))
+	for i, seg := range p.Extras {
+		if path, ok := byEnds[seg]; ok {
+			p.ExtraPaths[i] = path
+		} else {
+			// Collapsed by vertex merging.
+			p.ExtraPaths[i] = []int{seg[0]}
+		}
+	}
+}
+
+// findSplits returns, for every piece of paths in order, the vertices inside
+// it where another piece crosses, overlaps, or ends, ordered along the
+// piece. It reports whether any piece is split.
+//
+// A piece is never split at a vertex its path already runs through, so each
+// path visits a vertex at most once. Pieces are swept in order of their
+// leftmost x coordinate, so only pieces whose x ranges overlap are tested
+// against each other.
+func findSplits(p *PSLG, paths [][]int, index *vertexIndex, eps types.Epsilon) [][]int {
+	var pieces [][2]int
+	var owner []int
+	for i, path := range paths {
+		for k := 1; k < len(path); k++ {
+			pieces = append(pieces, [2]int{path[k-1], path[k]})
+			owner = append(owner, i)
+		}
+	}
+
+	mode := predicates.Mode{Epsilon: eps}
+	splits := make([][]int, len(pieces))
+	added := make([][]int, len(paths))
+	addSplit := func(i, v int) {
+		o := owner[i]
+		if slices.Contains(paths[o], v) || slices.Contains(added[o], v) {
+			return
+		}
+		splits[i] = append(splits[i], v)
+		added[o] = append(added[o], v)
+	}
+	// endsOn splits piece i at the endpoints of piece j lying on it.
+	endsOn := func(i, j int) {
+		a, b := p.Vertices[pieces[i][0]], p.Vertices[pieces[i][1]]
+		for _, v := range pieces[j] {
+			if mode.PointOnSegment(p.Vertices[v], a, b) {
+				addSplit(i, v)
+			}
+		}
+	}
+
+	minX := func(i int) float64 {
+		return math.Min(p.Vertices[pieces[i][0]].X, p.Vertices[pieces[i][1]].X)
+	}
+	order := make([]int, len(pieces))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(x, y int) bool { return minX(order[x]) < minX(order[y]) })
+	// reach bounds the tolerance of every pair of pieces.
+	reach := eps.TolForPoints(p.Vertices...)
+
+	for oi, i := range order {
+		a1, a2 := p.Vertices[pieces[i][0]], p.Vertices[pieces[i][1]]
+		maxX := math.Max(a1.X, a2.X)
+		for _, j := range order[oi+1:] {
+			if minX(j) > maxX+reach {
+				break
+			}
+			b1, b2 := p.Vertices[pieces[j][0]], p.Vertices[pieces[j][1]]
+			tol := eps.TolForPoints(a1, a2, b1, b2)
+			if math.Min(b1.X, b2.X) > maxX+tol ||
+				math.Min(a1.Y, a2.Y) > math.Max(b1.Y, b2.Y)+tol || math.Min(b1.Y, b2.Y) > math.Max(a1.Y, a2.Y)+tol {
+				continue
+			}
+
+			pt, kind := mode.SegmentIntersectionPoint(a1, a2, b1, b2)
+			switch kind {
+			case types.IntersectProper:
+				v := index.crossing(pt)
+				addSplit(i, v)
+				addSplit(j, v)
+			case types.IntersectTouching, types.IntersectCollinearOverlap:
+				endsOn(i, j)
+				endsOn(j, i)
+			}
+		}
+	}
+
+	for i, mid := range splits {
+		if len(mid) < 2 {
+			continue
+		}
+		a, b := p.Vertices[pieces[i][0]], p.Vertices[pieces[i][1]]
+		along := func(v int) float64 {
+			q := p.Vertices[v]
+			return (q.X-a.X)*(b.X-a.X) + (q.Y-a.Y)*(b.Y-a.Y)
+		}
+		sort.Slice(mid, func(x, y int) bool { return along(mid[x]) < along(mid[y]) })
+	}
+	return splits
+}
+
+// vertexIndex finds the PSLG vertex a crossing point merges with.
+type vertexIndex struct {
+	p   *PSLG
+	eps types.Epsilon
+	byX []int // Vertex indices sorted by x coordinate
+}
+
+func newVertexIndex(p *PSLG, eps types.Epsilon) *vertexIndex {
+	byX := make([]int, len(p.Vertices))
+	for i := range byX {
+		byX[i] = i
+	}
+	sort.Slice(byX, func(i, j int) bool { return p.Vertices[byX[i]].X < p.Vertices[byX[j]].X })
+	return &vertexIndex{p: p, eps: eps, byX: byX}
+}
+
+// crossing returns the vertex within merge distance of pt, or adds pt as a
+// new crossing vertex if there is none.
+func (vi *vertexIndex) crossing(pt types.Point) int {
+	// A vertex q merges with pt when their distance is within Abs plus Rel
+	// times the larger magnitude; for Rel below 1/2 that distance is at most
+	// twice the tolerance at pt.
+	reach := 2 * vi.eps.TolForPoints(pt)
+	vs := vi.p.Vertices
+	start := sort.Search(len(vi.byX), func(i int) bool { return vs[vi.byX[i]].X >= pt.X-reach })
+	for _, v := range vi.byX[start:] {
+		q := vs[v]
+		if q.X > pt.X+reach {
+			break
+		}
+		if math.Sqrt(predicates.Dist2(pt, q)) <= vi.eps.MergeDistance(pt, q) {
+			return v
+		}
+	}
+
+	v := len(vs)
+	vi.p.Vertices = append(vs, pt)
+	vi.p.Crossings = append(vi.p.Crossings, v)
+	at := sort.Search(len(vi.byX), func(i int) bool { return vi.p.Vertices[vi.byX[i]].X >= pt.X })
+	vi.byX = slices.Insert(vi.byX, at, v)
+	return v
+}
+
+// reverseIndices returns a reversed copy of indices.
+func reverseIndices(indices []int) []int {
+	out := make([]int, len(indices))
+	for i, v := range indices {
+		out[len(indices)-1-i] = v
+	}
+	return out
+}
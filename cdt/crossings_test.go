@@ -0,0 +1,182 @@
+package cdt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestNormalizePSLGSplitsCrossingExtras(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	extras := [][2]types.Point{
+		{{X: 1, Y: 1}, {X: 9, Y: 9}},
+		{{X: 1, Y: 9}, {X: 9, Y: 1}},
+	}
+
+	p, err := NormalizePSLG(outer, nil, extras, types.DefaultEpsilon())
+	if err != nil {
+		t.Fatalf("NormalizePSLG failed: %v", err)
+	}
+	if len(p.Crossings) != 1 {
+		t.Fatalf("expected one crossing, got %v", p.Crossings)
+	}
+	v := p.Crossings[0]
+	if p.Vertices[v] != (types.Point{X: 5, Y: 5}) {
+		t.Fatalf("crossing at %v, want (5, 5)", p.Vertices[v])
+	}
+	for i, path := range p.ExtraPaths {
+		if len(path) != 3 || path[1] != v {
+			t.Fatalf("extra %d path %v does not pass through the crossing", i, path)
+		}
+	}
+	if len(p.Segments) != 8 {
+		t.Fatalf("expected 4 perimeter and 4 extra segments, got %v", p.Segments)
+	}
+	if err := ValidatePSLG(p); err != nil {
+		t.Fatalf("ValidatePSLG failed: %v", err)
+	}
+}
+
+func TestNormalizePSLGSplitsLoopEdges(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	hole := []types.Point{{X: 4, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 6}, {X: 4, Y: 6}}
+	extras := [][2]types.Point{
+		// Ends on the bottom edge and crosses the hole.
+		{{X: 5, Y: 0}, {X: 5, Y: 8}},
+		// Runs along part of the right edge.
+		{{X: 10, Y: 2}, {X: 10, Y: 4}},
+	}
+
+	p, err := NormalizePSLG(outer, [][]types.Point{hole}, extras, types.DefaultEpsilon())
+	if err != nil {
+		t.Fatalf("NormalizePSLG failed: %v", err)
+	}
+	if len(p.Crossings) != 2 {
+		t.Fatalf("expected the hole edges to be crossed twice, got %v", p.Crossings)
+	}
+	if len(p.Outer) != 7 {
+		t.Fatalf("expected the perimeter to gain 3 vertices, got %v", p.Outer)
+	}
+	if len(p.Holes[0]) != 6 {
+		t.Fatalf("expected the hole to gain 2 vertices, got %v", p.Holes[0])
+	}
+	if got := len(p.ExtraPaths[0]); got != 4 {
+		t.Fatalf("expected the first extra to be split twice, got path %v", p.ExtraPaths[0])
+	}
+
+	seen := make(map[EdgeKey]bool)
+	for _, seg := range p.Segments {
+		key := NewEdgeKey(seg[0], seg[1])
+		if seen[key] {
+			t.Fatalf("segment %v appears twice", seg)
+		}
+		seen[key] = true
+	}
+}
+
+func TestBuildLineNetwork(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	hole := []types.Point{{X: 6, Y: 6}, {X: 8, Y: 6}, {X: 8, Y: 8}, {X: 6, Y: 8}}
+	var extras [][2]types.Point
+	for _, c := range []float64{2, 4, 7} {
+		extras = append(extras,
+			[2]types.Point{{X: 1, Y: c}, {X: 9, Y: c}},
+			[2]types.Point{{X: c, Y: 1}, {X: c, Y: 9}},
+		)
+	}
+
+	m, report, err := BuildWithReport(outer, [][]types.Point{hole}, extras, DefaultBuildOptions())
+	if err != nil {
+		t.Fatalf("BuildWithReport failed: %v", err)
+	}
+
+	// 9 crossings between the lines, one of them inside the hole, and 4
+	// where the lines at 7 cross the hole edges.
+	if len(report.Crossings) != 13 {
+		t.Fatalf("expected 13 crossings, got %d", len(report.Crossings))
+	}
+	kept := 0
+	for _, id := range report.Crossings {
+		if id == types.NilVertex {
+			continue
+		}
+		kept++
+		if p := m.GetVertex(id); p.X != 2 && p.X != 4 && p.X != 7 && p.Y != 2 && p.Y != 4 && p.Y != 7 {
+			t.Fatalf("crossing vertex %v is not on a line", p)
+		}
+	}
+	if kept != 12 {
+		t.Fatalf("expected the crossing inside the hole to be pruned, kept %d", kept)
+	}
+}
+
+func TestNormalizePSLGMergesCrossingWithVertex(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	extras := [][2]types.Point{
+		{{X: 1, Y: 1}, {X: 9, Y: 9}},
+		{{X: 1, Y: 9}, {X: 9, Y: 1}},
+		// Ends within tolerance of the crossing of the other two.
+		{{X: 5 + 1e-12, Y: 5}, {X: 5, Y: 1}},
+	}
+
+	p, err := NormalizePSLG(outer, nil, extras, types.DefaultEpsilon())
+	if err != nil {
+		t.Fatalf("NormalizePSLG failed: %v", err)
+	}
+	if len(p.Crossings) != 0 {
+		t.Fatalf("expected the crossing to reuse the existing vertex, got %v", p.Crossings)
+	}
+	end := p.ExtraPaths[2][0]
+	for i, path := range p.ExtraPaths[:2] {
+		if len(path) != 3 || path[1] != end {
+			t.Fatalf("extra %d path %v does not pass through vertex %d", i, path, end)
+		}
+	}
+	if err := ValidatePSLG(p); err != nil {
+		t.Fatalf("ValidatePSLG failed: %v", err)
+	}
+}
+
+func TestNormalizePSLGNearDegenerateCrossings(t *testing.T) {
+	// Constraints fanning out from nearly the same point cross each other
+	// many times close to it, where crossing points are rounded and merged.
+	// The fan is spread over about a thousand tolerances; closer than that,
+	// crossings are within merge distance of each other and the result is
+	// only planar up to tolerance.
+	outer := []types.Point{{X: -20, Y: -20}, {X: 20, Y: -20}, {X: 20, Y: 20}, {X: -20, Y: 20}}
+	eps := types.DefaultEpsilon()
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		var extras [][2]types.Point
+		for i := 0; i < 16; i++ {
+			theta := rng.Float64() * math.Pi
+			dx, dy := math.Cos(theta), math.Sin(theta)
+			ox, oy := 1e-6*rng.NormFloat64(), 1e-6*rng.NormFloat64()
+			extras = append(extras, [2]types.Point{{X: ox - 10*dx, Y: oy - 10*dy}, {X: ox + 10*dx, Y: oy + 10*dy}})
+		}
+
+		p, err := NormalizePSLG(outer, nil, extras, eps)
+		if err != nil {
+			t.Fatalf("NormalizePSLG failed: %v", err)
+		}
+		for i, s := range p.Segments {
+			a, b := p.Vertices[s[0]], p.Vertices[s[1]]
+			for v, q := range p.Vertices {
+				if v != s[0] && v != s[1] && robust.PointOnSegment(q, a, b) {
+					t.Fatalf("seed %d: vertex %v lies on segment %v-%v", seed, q, a, b)
+				}
+			}
+			for _, r := range p.Segments[i+1:] {
+				if s[0] == r[0] || s[0] == r[1] || s[1] == r[0] || s[1] == r[1] {
+					continue
+				}
+				if ok, _, _ := robust.SegmentIntersect(a, b, p.Vertices[r[0]], p.Vertices[r[1]]); ok {
+					t.Fatalf("seed %d: segments %v and %v cross", seed, s, r)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,633 @@
+package cdt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+var (
+	// ErrOutsideCover is returned when a point lies outside the bounds a
+	// Triangulation was created with.
+	ErrOutsideCover = errors.New("point outside triangulation cover")
+
+	// ErrUnknownVertex is returned for vertex indices that were never
+	// inserted, have been removed, or belong to the bounding cover.
+	ErrUnknownVertex = errors.New("unknown vertex")
+
+	// ErrUnknownConstraint is returned when removing a constraint that was
+	// never inserted.
+	ErrUnknownConstraint = errors.New("unknown constraint")
+
+	// ErrConstraintCrossing is returned when a new constraint would cross an
+	// existing one.
+	ErrConstraintCrossing = errors.New("constraint crosses existing constraint")
+
+	// ErrVertexConstrained is returned when removing a vertex that is used
+	// by a constraint.
+	ErrVertexConstrained = errors.New("vertex is used by a constraint")
+)
+
+// coverVertices is the number of bounding cover vertices. They occupy
+// indices 0-3 of a Triangulation's vertex list.
+const coverVertices = 4
+
+// Triangulation is a constrained Delaunay triangulation that can be edited
+// in place. Points and constraint segments are inserted and removed one at a
+// time, and only the triangles around each edit are touched, so the cost of
+// an edit does not grow with the size of the triangulation.
+//
+// All points must lie inside the bounds given to NewTriangulation. Vertex
+// indices returned by InsertPoint stay valid until the vertex is removed.
+type Triangulation struct {
+	ts      *TriSoup
+	locator *Locator
+	opts    BuildOptions
+	removed map[int]bool
+
+	// constrained holds every triangulation edge that is part of a
+	// constraint, in the form expected by LegalizeAround.
+	constrained map[EdgeKey]bool
+
+	// constraints maps each inserted constraint to the triangulation edges
+	// it currently consists of. A constraint is split wherever a vertex
+	// lies on it.
+	constraints map[EdgeKey][]EdgeKey
+
+	// owners maps each constrained edge to the constraints that use it.
+	owners map[EdgeKey][]EdgeKey
+}
+
+// NewTriangulation creates an empty triangulation whose bounding cover
+// contains bounds, enlarged by opts.CoverMargin.
+func NewTriangulation(bounds types.AABB, opts BuildOptions) *Triangulation {
+	if opts.CoverMargin <= 0 {
+		opts.CoverMargin = DefaultBuildOptions().CoverMargin
+	}
+
+	p0, p1, p2, p3 := BoundingCover([]types.Point{bounds.Min, bounds.Max}, opts.CoverMargin)
+	ts := NewTriSoup([]types.Point{p0, p1, p2, p3}, 2)
+	t1 := ts.AddTri(0, 1, 2)
+	t2 := ts.AddTri(0, 2, 3)
+	ts.Tri[t1].N[1] = t2
+	ts.Tri[t2].N[2] = t1
+
+	return &Triangulation{
+		ts:          ts,
+		locator:     NewLocator(ts),
+		opts:        opts,
+		removed:     make(map[int]bool),
+		constrained: make(map[EdgeKey]bool),
+		constraints: make(map[EdgeKey][]EdgeKey),
+		owners:      make(map[EdgeKey][]EdgeKey),
+	}
+}
+
+// Vertex returns the position of vertex v.
+func (t *Triangulation) Vertex(v int) types.Point {
+	return t.ts.V[v]
+}
+
+// NumTriangles returns the number of triangles, excluding those that touch
+// the bounding cover.
+func (t *Triangulation) NumTriangles() int {
+	count := 0
+	for i := range t.ts.Tri {
+		if t.isExported(TriID(i)) {
+			count++
+		}
+	}
+	return count
+}
+
+// Constraints returns the inserted constraints as vertex index pairs.
+func (t *Triangulation) Constraints() [][2]int {
+	out := make([][2]int, 0, len(t.constraints))
+	for key := range t.constraints {
+		out = append(out, [2]int{key.A, key.B})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i][0] != out[j][0] {
+			return out[i][0] < out[j][0]
+		}
+		return out[i][1] < out[j][1]
+	})
+	return out
+}
+
+// InsertPoint adds p and returns its vertex index. A point within the merge
+// distance of an existing vertex returns that vertex instead. A point that
+// lands on a constraint splits it.
+func (t *Triangulation) InsertPoint(p types.Point) (int, error) {
+	cover := t.ts.V[:coverVertices]
+	if p.X <= cover[0].X || p.X >= cover[2].X || p.Y <= cover[0].Y || p.Y >= cover[2].Y {
+		return 0, ErrOutsideCover
+	}
+
+	loc, err := t.locator.LocatePoint(p)
+	if err != nil {
+		return 0, fmt.Errorf("failed to locate point: %w", err)
+	}
+	for _, v := range t.ts.Tri[loc.T].V {
+		q := t.ts.V[v]
+		if math.Hypot(p.X-q.X, p.Y-q.Y) <= t.opts.Epsilon.MergeDistance(p, q) {
+			return v, nil
+		}
+	}
+
+	var split EdgeKey
+	if loc.OnEdge {
+		split = NewEdgeKey(t.ts.Tri[loc.T].Edge(loc.Edge))
+	}
+
+	vidx := len(t.ts.V)
+	t.ts.V = append(t.ts.V, p)
+	created, edges, err := InsertPoint(t.ts, loc, vidx)
+	if err != nil {
+		t.ts.V = t.ts.V[:vidx]
+		return 0, fmt.Errorf("failed to insert vertex: %w", err)
+	}
+
+	if loc.OnEdge && t.constrained[split] {
+		t.splitConstrainedEdge(split, vidx)
+	}
+
+	LegalizeAround(t.ts, edges, t.constrained)
+	t.locator.last = created[0]
+	return vidx, nil
+}
+
+// splitConstrainedEdge replaces constrained edge e by its two halves at v.
+func (t *Triangulation) splitConstrainedEdge(e EdgeKey, v int) {
+	a, b := NewEdgeKey(e.A, v), NewEdgeKey(v, e.B)
+	owners := t.owners[e]
+	for _, owner := range owners {
+		subs := t.constraints[owner]
+		out := make([]EdgeKey, 0, len(subs)+1)
+		for _, sub := range subs {
+			if sub == e {
+				out = append(out, a, b)
+			} else {
+				out = append(out, sub)
+			}
+		}
+		t.constraints[owner] = out
+	}
+
+	delete(t.constrained, e)
+	delete(t.owners, e)
+	t.constrained[a], t.constrained[b] = true, true
+	t.owners[a] = append([]EdgeKey(nil), owners...)
+	t.owners[b] = append([]EdgeKey(nil), owners...)
+}
+
+// InsertConstraint forces the segment between vertices u and v into the
+// triangulation. Vertices lying on the segment split it into several
+// triangulation edges. The segment may not cross an existing constraint.
+func (t *Triangulation) InsertConstraint(u, v int) error {
+	if err := t.checkVertex(u); err != nil {
+		return err
+	}
+	if err := t.checkVertex(v); err != nil {
+		return err
+	}
+	if u == v {
+		return fmt.Errorf("cannot insert zero-length constraint edge")
+	}
+
+	key := NewEdgeKey(u, v)
+	if _, ok := t.constraints[key]; ok {
+		return nil
+	}
+
+	// Check every piece before changing anything, so a crossing leaves the
+	// triangulation untouched.
+	for a := u; a != v; {
+		w, err := t.walkSegment(a, v)
+		if err != nil {
+			return err
+		}
+		a = w.end
+	}
+
+	var subs []EdgeKey
+	for a := u; a != v; {
+		w, err := t.walkSegment(a, v)
+		if err != nil {
+			return err
+		}
+		if len(w.cavity) > 0 {
+			t.retriangulateCavity(a, w)
+		}
+		subs = append(subs, NewEdgeKey(a, w.end))
+		a = w.end
+	}
+
+	t.constraints[key] = subs
+	for _, sub := range subs {
+		t.constrained[sub] = true
+		t.owners[sub] = append(t.owners[sub], key)
+	}
+	return nil
+}
+
+// RemoveConstraint removes the constraint between u and v and restores the
+// Delaunay property around the edges it no longer holds in place. Vertices
+// are kept.
+func (t *Triangulation) RemoveConstraint(u, v int) error {
+	key := NewEdgeKey(u, v)
+	subs, ok := t.constraints[key]
+	if !ok {
+		return ErrUnknownConstraint
+	}
+	delete(t.constraints, key)
+
+	var seeds []EdgeToLegalize
+	for _, sub := range subs {
+		owners := t.owners[sub][:0]
+		for _, owner := range t.owners[sub] {
+			if owner != key {
+				owners = append(owners, owner)
+			}
+		}
+		if len(owners) > 0 {
+			t.owners[sub] = owners
+			continue
+		}
+
+		delete(t.owners, sub)
+		delete(t.constrained, sub)
+		for _, use := range t.ts.FindEdgeTriangles(sub.A, sub.B) {
+			seeds = append(seeds, EdgeToLegalize{T: use.T, E: use.LocalEdge})
+		}
+	}
+	LegalizeAround(t.ts, seeds, t.constrained)
+	return nil
+}
+
+// RemovePoint deletes vertex v and retriangulates the hole it leaves. A
+// vertex that is an endpoint of a constraint, or splits one, cannot be
+// removed until the constraint is.
+func (t *Triangulation) RemovePoint(v int) error {
+	if err := t.checkVertex(v); err != nil {
+		return err
+	}
+
+	star, err := t.star(v)
+	if err != nil {
+		return err
+	}
+
+	// The link of v, counter-clockwise: star triangle k is (v, ring[k], ring[k+1]).
+	ring := make([]int, len(star))
+	for k, tri := range star {
+		i := localIndex(&t.ts.Tri[tri], v)
+		ring[k] = t.ts.Tri[tri].V[(i+1)%3]
+		if t.constrained[NewEdgeKey(v, ring[k])] {
+			return ErrVertexConstrained
+		}
+	}
+
+	fill, err := t.clipRing(ring)
+	if err != nil {
+		return fmt.Errorf("failed to fill hole of vertex %d: %w", v, err)
+	}
+
+	for _, tri := range star {
+		t.ts.RemoveTri(tri)
+	}
+	created := t.addTriangles(fill)
+	t.removed[v] = true
+
+	var seeds []EdgeToLegalize
+	for _, tri := range created {
+		for e := 0; e < 3; e++ {
+			seeds = append(seeds, EdgeToLegalize{T: tri, E: e})
+		}
+	}
+	LegalizeAround(t.ts, seeds, t.constrained)
+	t.locator.last = created[0]
+	return nil
+}
+
+// Mesh exports every triangle that does not touch the bounding cover.
+func (t *Triangulation) Mesh() (*mesh.Mesh, error) {
+	return t.export(func(TriID) bool { return true })
+}
+
+// MeshWithin exports the triangles whose centroid lies inside outer and
+// outside every hole. The loops are normally also inserted as constraints,
+// so that triangle edges follow them.
+func (t *Triangulation) MeshWithin(outer []types.Point, holes [][]types.Point) (*mesh.Mesh, error) {
+	return t.export(func(id TriID) bool {
+		tri := &t.ts.Tri[id]
+		a, b, c := t.ts.V[tri.V[0]], t.ts.V[tri.V[1]], t.ts.V[tri.V[2]]
+		centroid := types.Point{X: (a.X + b.X + c.X) / 3, Y: (a.Y + b.Y + c.Y) / 3}
+		return ClassifyPoint(centroid, outer, holes) == Inside
+	})
+}
+
+// Validate checks the triangulation topology and that every non-constrained
+// edge is locally Delaunay.
+func (t *Triangulation) Validate() error {
+	if err := ValidateTopology(t.ts); err != nil {
+		return err
+	}
+	if !IsDelaunay(t.ts, t.constrained) {
+		return fmt.Errorf("triangulation is not constrained Delaunay")
+	}
+	for key := range t.constrained {
+		if len(t.ts.FindEdgeTriangles(key.A, key.B)) == 0 {
+			return fmt.Errorf("constrained edge (%d, %d) is missing", key.A, key.B)
+		}
+	}
+	return nil
+}
+
+func (t *Triangulation) checkVertex(v int) error {
+	if v < coverVertices || v >= len(t.ts.V) || t.removed[v] {
+		return fmt.Errorf("%w %d", ErrUnknownVertex, v)
+	}
+	return nil
+}
+
+func (t *Triangulation) isExported(id TriID) bool {
+	if t.ts.IsDeleted(id) {
+		return false
+	}
+	for _, v := range t.ts.Tri[id].V {
+		if v < coverVertices {
+			return false
+		}
+	}
+	return true
+}
+
+// export builds a mesh from the non-cover triangles accepted by keep.
+// Vertices are added in index order so the output is deterministic.
+func (t *Triangulation) export(keep func(TriID) bool) (*mesh.Mesh, error) {
+	var tris []TriID
+	used := make(map[int]bool)
+	for i := range t.ts.Tri {
+		id := TriID(i)
+		if !t.isExported(id) || !keep(id) {
+			continue
+		}
+		tris = append(tris, id)
+		for _, v := range t.ts.Tri[id].V {
+			used[v] = true
+		}
+	}
+
+	verts := make([]int, 0, len(used))
+	for v := range used {
+		verts = append(verts, v)
+	}
+	sort.Ints(verts)
+
+	m := mesh.NewMesh(t.opts.MeshOptions...)
+	ids := make(map[int]types.VertexID, len(verts))
+	for _, v := range verts {
+		vid, err := m.AddVertex(t.ts.V[v])
+		if err != nil {
+			return nil, fmt.Errorf("failed to add vertex %d: %w", v, err)
+		}
+		ids[v] = vid
+	}
+	for _, id := range tris {
+		tri := &t.ts.Tri[id]
+		if err := m.AddTriangle(ids[tri.V[0]], ids[tri.V[1]], ids[tri.V[2]]); err != nil {
+			return nil, fmt.Errorf("failed to add triangle %d: %w", id, err)
+		}
+	}
+	return m, nil
+}
+
+// star returns the triangles around interior vertex v in counter-clockwise
+// order.
+func (t *Triangulation) star(v int) ([]TriID, error) {
+	start := NilTri
+	if loc, err := t.locator.LocatePoint(t.ts.V[v]); err == nil && localIndex(&t.ts.Tri[loc.T], v) >= 0 {
+		start = loc.T
+	} else if tris := findTrianglesContainingVertex(t.ts, v); len(tris) > 0 {
+		start = tris[0]
+	}
+	if start == NilTri {
+		return nil, fmt.Errorf("vertex %d is not in the triangulation", v)
+	}
+
+	var star []TriID
+	for cur := start; ; {
+		star = append(star, cur)
+		i := localIndex(&t.ts.Tri[cur], v)
+		cur = t.ts.Tri[cur].N[(i+1)%3]
+		if cur == NilTri {
+			return nil, fmt.Errorf("vertex %d is on the triangulation boundary", v)
+		}
+		if cur == start {
+			return star, nil
+		}
+	}
+}
+
+func localIndex(tri *Tri, v int) int {
+	for i, w := range tri.V {
+		if w == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// segmentWalk describes the triangles crossed by a constraint from a vertex
+// up to end, the first vertex on the segment.
+type segmentWalk struct {
+	end    int
+	cavity []TriID
+
+	// left and right are the cavity boundary vertices on each side of the
+	// segment, ordered from the start vertex towards end.
+	left, right []int
+}
+
+// walkSegment walks from u towards v and collects the triangles crossed
+// before reaching v or a vertex lying on the segment. It does not modify
+// the triangulation.
+func (t *Triangulation) walkSegment(u, v int) (segmentWalk, error) {
+	pu, pv := t.ts.V[u], t.ts.V[v]
+	ahead := func(w int) bool {
+		pw := t.ts.V[w]
+		return (pw.X-pu.X)*(pv.X-pu.X)+(pw.Y-pu.Y)*(pv.Y-pu.Y) > 0
+	}
+
+	star, err := t.star(u)
+	if err != nil {
+		return segmentWalk{}, err
+	}
+
+	var walk segmentWalk
+	cur := NilTri
+	for _, tri := range star {
+		i := localIndex(&t.ts.Tri[tri], u)
+		a, b := t.ts.Tri[tri].V[(i+1)%3], t.ts.Tri[tri].V[(i+2)%3]
+		oa := robust.Orient2D(pu, t.ts.V[a], pv)
+		ob := robust.Orient2D(pu, t.ts.V[b], pv)
+		if a == v || (oa == 0 && ahead(a)) {
+			return segmentWalk{end: a}, nil
+		}
+		if oa > 0 && ob < 0 {
+			cur = tri
+			walk.right, walk.left = []int{a}, []int{b}
+		}
+	}
+	if cur == NilTri {
+		return segmentWalk{}, fmt.Errorf("no triangle at vertex %d towards vertex %d", u, v)
+	}
+
+	for {
+		walk.cavity = append(walk.cavity, cur)
+		l, r := walk.left[len(walk.left)-1], walk.right[len(walk.right)-1]
+		if t.constrained[NewEdgeKey(l, r)] {
+			return segmentWalk{}, fmt.Errorf("%w: segment (%d, %d) crosses edge (%d, %d)", ErrConstraintCrossing, u, v, l, r)
+		}
+
+		next := NilTri
+		for _, use := range t.ts.FindEdgeTriangles(l, r) {
+			if use.T != cur {
+				next = use.T
+			}
+		}
+		if next == NilTri {
+			return segmentWalk{}, fmt.Errorf("segment (%d, %d) leaves the triangulation", u, v)
+		}
+
+		cur = next
+		e, _ := t.ts.FindTriEdge(cur, l, r)
+		w := t.ts.Tri[cur].V[e]
+		if w == v {
+			walk.cavity = append(walk.cavity, cur)
+			walk.end = v
+			return walk, nil
+		}
+
+		switch robust.Orient2D(pu, pv, t.ts.V[w]) {
+		case 0:
+			walk.cavity = append(walk.cavity, cur)
+			walk.end = w
+			return walk, nil
+		case 1:
+			walk.left = append(walk.left, w)
+		default:
+			walk.right = append(walk.right, w)
+		}
+	}
+}
+
+// retriangulateCavity replaces the triangles crossed by segment (u,
+// w.end) with constrained Delaunay triangulations of the two pseudo-polygons
+// on either side of it.
+func (t *Triangulation) retriangulateCavity(u int, w segmentWalk) {
+	for _, tri := range w.cavity {
+		t.ts.RemoveTri(tri)
+	}
+
+	fill := t.triangulatePseudoPolygon(w.left, u, w.end)
+	fill = append(fill, t.triangulatePseudoPolygon(w.right, u, w.end)...)
+	created := t.addTriangles(fill)
+	t.locator.last = created[0]
+}
+
+// triangulatePseudoPolygon triangulates the polygon formed by base edge
+// (a, b) and the chain of vertices between them, choosing at each step the
+// chain vertex whose circumcircle with the base edge is empty.
+func (t *Triangulation) triangulatePseudoPolygon(chain []int, a, b int) [][3]int {
+	if len(chain) == 0 {
+		return nil
+	}
+
+	pa, pb := t.ts.V[a], t.ts.V[b]
+	c := 0
+	for i := 1; i < len(chain); i++ {
+		if inCircumcircle(pa, pb, t.ts.V[chain[c]], t.ts.V[chain[i]]) {
+			c = i
+		}
+	}
+
+	tris := t.triangulatePseudoPolygon(chain[:c], a, chain[c])
+	tris = append(tris, t.triangulatePseudoPolygon(chain[c+1:], chain[c], b)...)
+	return append(tris, [3]int{a, b, chain[c]})
+}
+
+// inCircumcircle reports whether d lies strictly inside the circumcircle of
+// triangle abc, whatever its orientation.
+func inCircumcircle(a, b, c, d types.Point) bool {
+	if robust.Orient2D(a, b, c) < 0 {
+		b, c = c, b
+	}
+	return robust.InCircle(a, b, c, d) > 0
+}
+
+// clipRing triangulates the simple counter-clockwise polygon ring by ear
+// clipping without modifying the triangulation.
+func (t *Triangulation) clipRing(ring []int) ([][3]int, error) {
+	ring = append([]int(nil), ring...)
+	var tris [][3]int
+	for len(ring) > 3 {
+		clipped := false
+		for i := range ring {
+			a, b, c := ring[(i+len(ring)-1)%len(ring)], ring[i], ring[(i+1)%len(ring)]
+			pa, pb, pc := t.ts.V[a], t.ts.V[b], t.ts.V[c]
+			if robust.Orient2D(pa, pb, pc) <= 0 {
+				continue
+			}
+
+			ear := true
+			for _, w := range ring {
+				if w == a || w == b || w == c {
+					continue
+				}
+				pw := t.ts.V[w]
+				if robust.Orient2D(pa, pb, pw) >= 0 && robust.Orient2D(pb, pc, pw) >= 0 && robust.Orient2D(pc, pa, pw) >= 0 {
+					ear = false
+					break
+				}
+			}
+			if ear {
+				tris = append(tris, [3]int{a, b, c})
+				ring = append(ring[:i], ring[i+1:]...)
+				clipped = true
+				break
+			}
+		}
+		if !clipped {
+			return nil, fmt.Errorf("no ear found in %d-vertex ring", len(ring))
+		}
+	}
+	return append(tris, [3]int{ring[0], ring[1], ring[2]}), nil
+}
+
+// addTriangles adds triangles counter-clockwise and links them to each
+// other and to the existing triangles they share edges with.
+func (t *Triangulation) addTriangles(tris [][3]int) []TriID {
+	created := make([]TriID, len(tris))
+	for i, tri := range tris {
+		created[i] = addTriCCW(t.ts, tri[0], tri[1], tri[2])
+	}
+	for _, id := range created {
+		for e := 0; e < 3; e++ {
+			a, b := t.ts.Tri[id].Edge(e)
+			for _, use := range t.ts.FindEdgeTriangles(a, b) {
+				if use.T != id {
+					t.ts.Tri[id].N[e] = use.T
+					t.ts.Tri[use.T].N[use.LocalEdge] = id
+				}
+			}
+		}
+	}
+	return created
+}
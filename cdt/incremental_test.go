@@ -0,0 +1,244 @@
+package cdt
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+func newTestTriangulation() *Triangulation {
+	return NewTriangulation(types.AABB{Min: types.Point{X: 0, Y: 0}, Max: types.Point{X: 10, Y: 10}}, DefaultBuildOptions())
+}
+
+func insertPoints(t *testing.T, tr *Triangulation, pts ...types.Point) []int {
+	t.Helper()
+	ids := make([]int, len(pts))
+	for i, p := range pts {
+		id, err := tr.InsertPoint(p)
+		if err != nil {
+			t.Fatalf("InsertPoint(%v): %v", p, err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func hasEdge(tr *Triangulation, u, v int) bool {
+	return len(tr.ts.FindEdgeTriangles(u, v)) > 0
+}
+
+func TestTriangulationInsertPoints(t *testing.T) {
+	tr := newTestTriangulation()
+	ids := insertPoints(t, tr,
+		types.Point{X: 0, Y: 0}, types.Point{X: 10, Y: 0}, types.Point{X: 10, Y: 10}, types.Point{X: 0, Y: 10}, types.Point{X: 5, Y: 5})
+
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tr.NumTriangles(); got != 4 {
+		t.Fatalf("expected 4 triangles, got %d", got)
+	}
+
+	dup, err := tr.InsertPoint(types.Point{X: 5, Y: 5})
+	if err != nil || dup != ids[4] {
+		t.Fatalf("expected duplicate to return vertex %d, got %d (%v)", ids[4], dup, err)
+	}
+
+	if _, err := tr.InsertPoint(types.Point{X: 100, Y: 0}); !errors.Is(err, ErrOutsideCover) {
+		t.Fatalf("expected ErrOutsideCover, got %v", err)
+	}
+
+	m, err := tr.Mesh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.NumVertices() != 5 || m.NumTriangles() != 4 {
+		t.Fatalf("expected 5 vertices and 4 triangles, got %d and %d", m.NumVertices(), m.NumTriangles())
+	}
+}
+
+func TestTriangulationConstraints(t *testing.T) {
+	tr := newTestTriangulation()
+	// A thin diamond whose long diagonal is not Delaunay.
+	ids := insertPoints(t, tr,
+		types.Point{X: 1, Y: 5}, types.Point{X: 5, Y: 4.5}, types.Point{X: 9, Y: 5}, types.Point{X: 5, Y: 5.5})
+	if hasEdge(tr, ids[0], ids[2]) {
+		t.Fatal("long diagonal should not be Delaunay")
+	}
+
+	if err := tr.InsertConstraint(ids[0], ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	if !hasEdge(tr, ids[0], ids[2]) {
+		t.Fatal("constraint edge missing")
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tr.InsertConstraint(ids[1], ids[3]); !errors.Is(err, ErrConstraintCrossing) {
+		t.Fatalf("expected ErrConstraintCrossing, got %v", err)
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatalf("failed insertion changed the triangulation: %v", err)
+	}
+
+	if err := tr.RemovePoint(ids[0]); !errors.Is(err, ErrVertexConstrained) {
+		t.Fatalf("expected ErrVertexConstrained, got %v", err)
+	}
+
+	if err := tr.RemoveConstraint(ids[0], ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	if hasEdge(tr, ids[0], ids[2]) {
+		t.Fatal("expected the Delaunay diagonal to return after removing the constraint")
+	}
+	if err := tr.RemoveConstraint(ids[0], ids[2]); !errors.Is(err, ErrUnknownConstraint) {
+		t.Fatalf("expected ErrUnknownConstraint, got %v", err)
+	}
+}
+
+func TestTriangulationConstraintSplitting(t *testing.T) {
+	tr := newTestTriangulation()
+	ids := insertPoints(t, tr, types.Point{X: 1, Y: 1}, types.Point{X: 5, Y: 5}, types.Point{X: 9, Y: 9}, types.Point{X: 1, Y: 9})
+
+	// The constraint passes through the middle vertex.
+	if err := tr.InsertConstraint(ids[0], ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	if !hasEdge(tr, ids[0], ids[1]) || !hasEdge(tr, ids[1], ids[2]) {
+		t.Fatal("expected constraint to be split at the collinear vertex")
+	}
+
+	// A new point on the constraint splits it again.
+	mid := insertPoints(t, tr, types.Point{X: 3, Y: 3})[0]
+	if !tr.constrained[NewEdgeKey(ids[0], mid)] || !tr.constrained[NewEdgeKey(mid, ids[1])] {
+		t.Fatal("expected inserted point to split the constrained edge")
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tr.RemoveConstraint(ids[0], ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.constrained) != 0 {
+		t.Fatalf("expected no constrained edges, got %v", tr.constrained)
+	}
+	if err := tr.RemovePoint(mid); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTriangulationMeshWithin(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	hole := []types.Point{{X: 4, Y: 4}, {X: 4, Y: 6}, {X: 6, Y: 6}, {X: 6, Y: 4}}
+
+	tr := newTestTriangulation()
+	for _, loop := range [][]types.Point{outer, hole} {
+		ids := insertPoints(t, tr, loop...)
+		for i := range ids {
+			if err := tr.InsertConstraint(ids[i], ids[(i+1)%len(ids)]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	m, err := tr.MeshWithin(outer, [][]types.Point{hole})
+	if err != nil {
+		t.Fatal(err)
+	}
+	area := 0.0
+	for i := 0; i < m.NumTriangles(); i++ {
+		a, b, c := m.GetTriangleCoords(i)
+		area += math.Abs((b.X-a.X)*(c.Y-a.Y)-(b.Y-a.Y)*(c.X-a.X)) / 2
+	}
+	if math.Abs(area-96) > 1e-9 {
+		t.Fatalf("expected area 96, got %v", area)
+	}
+}
+
+func TestTriangulationRandomEdits(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	tr := newTestTriangulation()
+	random := func() types.Point {
+		return types.Point{X: rng.Float64() * 10, Y: rng.Float64() * 10}
+	}
+
+	var ids []int
+	for i := 0; i < 300; i++ {
+		id, err := tr.InsertPoint(random())
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	inserted := 0
+	for i := 0; i < 60; i++ {
+		u, v := ids[rng.Intn(len(ids))], ids[rng.Intn(len(ids))]
+		if u == v {
+			continue
+		}
+		err := tr.InsertConstraint(u, v)
+		if errors.Is(err, ErrConstraintCrossing) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		inserted++
+	}
+	if inserted == 0 {
+		t.Fatal("expected some constraints to be inserted")
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Points landing on constraints split them.
+	for i := 0; i < 100; i++ {
+		if _, err := tr.InsertPoint(random()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	removed := 0
+	for _, id := range ids {
+		err := tr.RemovePoint(id)
+		if errors.Is(err, ErrVertexConstrained) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		removed++
+	}
+	if removed == 0 {
+		t.Fatal("expected some points to be removed")
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range tr.Constraints() {
+		if err := tr.RemoveConstraint(c[0], c[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.constrained) != 0 {
+		t.Fatal("expected every constrained edge to be released")
+	}
+}
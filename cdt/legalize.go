@@ -90,7 +90,9 @@ func LegalizeAround(ts *TriSoup, seeds []EdgeToLegalize, constrained map[EdgeKey
 	queue := make([]EdgeToLegalize, len(seeds))
 	copy(queue, seeds)
 
-	// Track edges we've already processed to avoid infinite loops
+	// Track edges we've already processed to avoid infinite loops. Triangle
+	// slots are reused after flips, so an edge is identified by the
+	// triangle's vertices rather than its ID.
 	processed := make(map[edgeRef]bool)
 
 	for len(queue) > 0 {
@@ -98,17 +100,17 @@ func LegalizeAround(ts *TriSoup, seeds []EdgeToLegalize, constrained map[EdgeKey
 		edge := queue[0]
 		queue = queue[1:]
 
-		// Skip if already processed
-		ref := edgeRef{T: edge.T, E: edge.E}
-		if processed[ref] {
+		// Skip if triangle was deleted
+		if ts.IsDeleted(edge.T) {
 			continue
 		}
-		processed[ref] = true
 
-		// Skip if triangle was deleted
-		if ts.IsDeleted(edge.T) {
+		// Skip if already processed
+		ref := edgeRef{V: ts.Tri[edge.T].V, E: edge.E}
+		if processed[ref] {
 			continue
 		}
+		processed[ref] = true
 
 		// Check if the edge is illegal
 		if !IsIllegal(ts, edge.T, edge.E, constrained) {
@@ -124,19 +126,19 @@ func LegalizeAround(ts *TriSoup, seeds []EdgeToLegalize, constrained map[EdgeKey
 		// Add the four edges around the new diamond to the queue
 		// These are the edges that might have become illegal due to the flip
 
-		// For newLeft triangle, check edges 0 and 2 (not the shared edge 1)
+		// For newLeft triangle, check edges 0 and 1 (not the shared edge 2)
 		queue = append(queue, EdgeToLegalize{T: newLeft, E: 0})
-		queue = append(queue, EdgeToLegalize{T: newLeft, E: 2})
+		queue = append(queue, EdgeToLegalize{T: newLeft, E: 1})
 
-		// For newRight triangle, check edges 0 and 2 (not the shared edge 1)
+		// For newRight triangle, check edges 0 and 1 (not the shared edge 2)
 		queue = append(queue, EdgeToLegalize{T: newRight, E: 0})
-		queue = append(queue, EdgeToLegalize{T: newRight, E: 2})
+		queue = append(queue, EdgeToLegalize{T: newRight, E: 1})
 	}
 }
 
 // edgeRef uniquely identifies an edge within the triangulation.
 type edgeRef struct {
-	T TriID
+	V [3]int
 	E int
 }
 
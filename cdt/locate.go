@@ -103,8 +103,9 @@ func (l *Locator) LocatePoint(p types.Point) (Location, error) {
 			outside = append(outside, 2)
 		}
 
-		// If p is on an edge, return that location
-		if len(onEdge) > 0 {
+		// If p is on an edge, return that location. Being collinear with an
+		// edge only counts when p is not outside another edge.
+		if len(onEdge) > 0 && len(outside) == 0 {
 			l.last = current
 			return Location{
 				T:      current,
@@ -194,7 +195,7 @@ func (l *Locator) LocatePoint(p types.Point) (Location, error) {
 					lastEdge = 2
 				}
 
-				if onEdgeCount > 0 {
+				if onEdgeCount > 0 && o0 >= 0 && o1 >= 0 && o2 >= 0 {
 					l.last = TriID(i)
 					fmt.Printf("[Locator] Linear search found point on edge %d of triangle %d\n", lastEdge, i)
 					return Location{
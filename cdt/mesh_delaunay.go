@@ -0,0 +1,164 @@
+package cdt
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// NonDelaunayEdge is an unconstrained mesh edge whose two triangles fail the
+// empty-circumcircle test.
+type NonDelaunayEdge struct {
+	Edge types.Edge
+
+	// Triangles are the indices of the two triangles sharing Edge.
+	Triangles [2]int
+
+	// Opposite holds the vertex of each triangle that is not on Edge.
+	// Opposite[1] lies strictly inside the circumcircle of Triangles[0],
+	// and vice versa.
+	Opposite [2]types.VertexID
+}
+
+// CheckMeshDelaunay reports every edge of m that is not locally Delaunay,
+// using the exact robust.InCircle predicate.
+//
+// Edges on the perimeters and holes of m and the extra constraint edges are
+// exempt, as are edges with fewer or more than two triangles, so an empty
+// result means m is a constrained Delaunay triangulation of its loops and
+// constraints. Edges are reported in ascending vertex order.
+func CheckMeshDelaunay(m *mesh.Mesh, constraints ...types.Edge) []NonDelaunayEdge {
+	fixed := meshConstraints(m, constraints)
+
+	var out []NonDelaunayEdge
+	for _, e := range sortedMeshEdges(m) {
+		if bad, ok := checkMeshEdge(m, e, fixed); ok {
+			out = append(out, bad)
+		}
+	}
+	return out
+}
+
+// FlipToDelaunay flips non-Delaunay edges of m with mesh.FlipEdge until
+// every unconstrained edge is locally Delaunay, and returns the number of
+// flips. Constraints are handled as in CheckMeshDelaunay.
+//
+// Edges that the mesh refuses to flip because the flip would create a
+// triangle it considers degenerate are left in place; call
+// CheckMeshDelaunay afterwards to find them. Any other error stops the
+// process and is returned with the flips made so far.
+func FlipToDelaunay(m *mesh.Mesh, constraints ...types.Edge) (int, error) {
+	fixed := meshConstraints(m, constraints)
+
+	queue := sortedMeshEdges(m)
+	queued := make(map[types.Edge]bool, len(queue))
+	for _, e := range queue {
+		queued[e] = true
+	}
+
+	flips := 0
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		delete(queued, e)
+
+		bad, ok := checkMeshEdge(m, e, fixed)
+		if !ok {
+			continue
+		}
+		if err := m.FlipEdge(e); err != nil {
+			if errors.Is(err, mesh.ErrNotFlippable) {
+				continue
+			}
+			return flips, err
+		}
+		flips++
+
+		// The four outer edges of the quad may have become illegal.
+		a, b := e.V1(), e.V2()
+		c, d := bad.Opposite[0], bad.Opposite[1]
+		for _, next := range []types.Edge{types.NewEdge(a, c), types.NewEdge(c, b), types.NewEdge(b, d), types.NewEdge(d, a)} {
+			if !queued[next] {
+				queued[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return flips, nil
+}
+
+// meshConstraints returns the perimeter and hole edges of m together with
+// the extra constraints.
+func meshConstraints(m *mesh.Mesh, extra []types.Edge) map[types.Edge]bool {
+	fixed := make(map[types.Edge]bool)
+	for _, loops := range [][]types.PolygonLoop{m.Perimeters(), m.Holes()} {
+		for _, loop := range loops {
+			for _, e := range loop.Edges() {
+				fixed[e] = true
+			}
+		}
+	}
+	for _, e := range extra {
+		fixed[e.Canonical()] = true
+	}
+	return fixed
+}
+
+func sortedMeshEdges(m *mesh.Mesh) []types.Edge {
+	edges := make([]types.Edge, 0, len(m.EdgeSet()))
+	for e := range m.EdgeSet() {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+	return edges
+}
+
+// checkMeshEdge applies the local Delaunay test to edge e of m.
+func checkMeshEdge(m *mesh.Mesh, e types.Edge, fixed map[types.Edge]bool) (NonDelaunayEdge, bool) {
+	if fixed[e] {
+		return NonDelaunayEdge{}, false
+	}
+
+	a, b := e.V1(), e.V2()
+	var tris []int
+	for _, idx := range m.VertexTriangles(a) {
+		tri := m.GetTriangle(idx)
+		if tri.V1() == b || tri.V2() == b || tri.V3() == b {
+			tris = append(tris, idx)
+		}
+	}
+	if len(tris) != 2 {
+		return NonDelaunayEdge{}, false
+	}
+
+	bad := NonDelaunayEdge{Edge: e, Triangles: [2]int{tris[0], tris[1]}}
+	for i, idx := range tris {
+		for _, v := range m.GetTriangle(idx) {
+			if v != a && v != b {
+				bad.Opposite[i] = v
+			}
+		}
+	}
+
+	pa, pb := m.GetVertex(a), m.GetVertex(b)
+	pc, pd := m.GetVertex(bad.Opposite[0]), m.GetVertex(bad.Opposite[1])
+	orient := robust.Orient2D(pa, pb, pc)
+	if orient == 0 {
+		return NonDelaunayEdge{}, false
+	}
+	if orient < 0 {
+		pa, pb = pb, pa
+	}
+	if robust.InCircle(pa, pb, pc, pd) <= 0 {
+		return NonDelaunayEdge{}, false
+	}
+	return bad, true
+}
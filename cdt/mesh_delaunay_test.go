@@ -0,0 +1,83 @@
+package cdt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestCheckMeshDelaunay(t *testing.T) {
+	// A thin quad split along its long diagonal.
+	m := mesh.NewMesh()
+	var ids []types.VertexID
+	for _, p := range []types.Point{{X: 0, Y: 0}, {X: 2, Y: -0.5}, {X: 4, Y: 0}, {X: 2, Y: 0.5}} {
+		id, err := m.AddVertex(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := m.AddTriangle(ids[0], ids[1], ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatal(err)
+	}
+
+	long := types.NewEdge(ids[0], ids[2])
+	bad := CheckMeshDelaunay(m)
+	if len(bad) != 1 || bad[0].Edge != long {
+		t.Fatalf("expected the long diagonal to be reported, got %+v", bad)
+	}
+	if bad[0].Opposite != [2]types.VertexID{ids[1], ids[3]} {
+		t.Fatalf("unexpected opposite vertices %v", bad[0].Opposite)
+	}
+	if bad := CheckMeshDelaunay(m, long); len(bad) != 0 {
+		t.Fatalf("expected constrained diagonal to be exempt, got %+v", bad)
+	}
+
+	flips, err := FlipToDelaunay(m)
+	if err != nil || flips != 1 {
+		t.Fatalf("expected 1 flip, got %d (%v)", flips, err)
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(ids[1], ids[3])]; !ok {
+		t.Fatal("expected the short diagonal after flipping")
+	}
+	if bad := CheckMeshDelaunay(m); len(bad) != 0 {
+		t.Fatalf("expected no non-Delaunay edges, got %+v", bad)
+	}
+}
+
+func TestFlipToDelaunayRestoresCDT(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 6}, {X: 6, Y: 6}, {X: 5, Y: 2}, {X: 4, Y: 6}, {X: 0, Y: 6}}
+	hole := []types.Point{{X: 1, Y: 1}, {X: 1, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 1}}
+	m, err := Build(outer, [][]types.Point{hole}, nil, DefaultBuildOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bad := CheckMeshDelaunay(m); len(bad) != 0 {
+		t.Fatalf("expected the CDT to pass, got %+v", bad)
+	}
+
+	// Scramble the mesh with random flips, then repair it.
+	rng := rand.New(rand.NewSource(3))
+	scrambled := 0
+	for i := 0; i < 200; i++ {
+		edges := sortedMeshEdges(m)
+		if m.FlipEdge(edges[rng.Intn(len(edges))]) == nil {
+			scrambled++
+		}
+	}
+	if scrambled == 0 || len(CheckMeshDelaunay(m)) == 0 {
+		t.Fatal("expected random flips to break the Delaunay property")
+	}
+
+	if _, err := FlipToDelaunay(m); err != nil {
+		t.Fatal(err)
+	}
+	if bad := CheckMeshDelaunay(m); len(bad) != 0 {
+		t.Fatalf("expected no non-Delaunay edges after repair, got %+v", bad)
+	}
+}
@@ -10,14 +10,23 @@ import (
 
 // PSLG represents a Planar Straight-Line Graph with vertices and segments.
 type PSLG struct {
-	Vertices []types.Point // Deduplicated vertices
-	Segments [][2]int      // Segment endpoints (indices into Vertices)
-	Outer    []int         // Indices of outer perimeter vertices
-	Holes    [][]int       // Indices of hole vertices
+	Vertices   []types.Point // Deduplicated vertices, then crossing vertices
+	Segments   [][2]int      // Segment endpoints (indices into Vertices)
+	Outer      []int         // Indices of outer perimeter vertices
+	Holes      [][]int       // Indices of hole vertices
+	Extras     [][2]int      // Endpoints of each extra constraint, in input order
+	ExtraPaths [][]int       // Vertices each extra constraint runs through, in input order
+	Crossings  []int         // Vertices added where segments cross
 }
 
 // NormalizePSLG takes raw input (outer perimeter, holes, extra constraints) and produces
 // a clean, validated PSLG with merged vertices and proper winding.
+//
+// Segments that cross, overlap, or end on another segment's interior are
+// split there, so extra constraints may cross each other and the loops
+// freely. Each crossing adds a vertex, reported in Crossings; loops gain the
+// vertices inserted on their edges. The loops themselves must still not
+// cross each other.
 func NormalizePSLG(outer []types.Point, holes [][]types.Point, extraSegs [][2]types.Point, eps types.Epsilon) (*PSLG, error) {
 	// Validate basic structure
 	if len(outer) < 3 {
@@ -88,21 +97,26 @@ func NormalizePSLG(outer []types.Point, holes [][]types.Point, extraSegs [][2]ty
 	}
 
 	// Extra constraint segments
-	for range extraSegs {
+	extras := make([][2]int, len(extraSegs))
+	for i := range extraSegs {
 		idx0 := remap[offset]
 		idx1 := remap[offset+1]
 		if idx0 != idx1 {
 			segments = append(segments, [2]int{idx0, idx1})
 		}
+		extras[i] = [2]int{idx0, idx1}
 		offset += 2
 	}
 
-	return &PSLG{
+	p := &PSLG{
 		Vertices: merged,
 		Segments: segments,
 		Outer:    outerIndices,
 		Holes:    holeIndices,
-	}, nil
+		Extras:   extras,
+	}
+	splitCrossings(p, eps)
+	return p, nil
 }
 
 // ensureWinding ensures outer loop is CCW and holes are CW.
@@ -0,0 +1,62 @@
+package cdt
+
+import (
+	"fmt"
+
+	"github.com/iceisfun/gomesh/algorithm/snap"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// snapInput snap-rounds the perimeter, holes and extra constraints to grid
+// together, so every crossing or near-touch between them becomes a shared
+// grid vertex. Loops gain the vertices they are rerouted through. Each extra
+// is replaced by its pieces, and extraIndex maps every piece back to the
+// extra it came from.
+func snapInput(outer []types.Point, holes [][]types.Point, extras [][2]types.Point, grid snap.Grid) ([]types.Point, [][]types.Point, [][2]types.Point, []int, error) {
+	var segs [][2]types.Point
+	addLoop := func(loop []types.Point) {
+		for i := range loop {
+			segs = append(segs, [2]types.Point{loop[i], loop[(i+1)%len(loop)]})
+		}
+	}
+	addLoop(outer)
+	for _, hole := range holes {
+		addLoop(hole)
+	}
+	segs = append(segs, extras...)
+
+	res := snap.Round(segs, grid)
+	next := 0
+	loop := func(n int) []types.Point {
+		var out []types.Point
+		for _, chain := range res.Chains[next : next+n] {
+			for _, v := range chain[:len(chain)-1] {
+				out = append(out, res.Vertices[v])
+			}
+		}
+		next += n
+		return out
+	}
+
+	snappedOuter := loop(len(outer))
+	if len(snappedOuter) < 3 {
+		return nil, nil, nil, nil, fmt.Errorf("outer perimeter collapses on the snap grid")
+	}
+	snappedHoles := make([][]types.Point, len(holes))
+	for i, hole := range holes {
+		snappedHoles[i] = loop(len(hole))
+		if len(snappedHoles[i]) < 3 {
+			return nil, nil, nil, nil, fmt.Errorf("hole %d collapses on the snap grid", i)
+		}
+	}
+
+	var pieces [][2]types.Point
+	var extraIndex []int
+	for i, chain := range res.Chains[next:] {
+		for k := 1; k < len(chain); k++ {
+			pieces = append(pieces, [2]types.Point{res.Vertices[chain[k-1]], res.Vertices[chain[k]]})
+			extraIndex = append(extraIndex, i)
+		}
+	}
+	return snappedOuter, snappedHoles, pieces, extraIndex, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/quality"
+)
+
+var (
+	jsonOutput      = flag.Bool("json", false, "Print statistics as JSON")
+	minAngle        = flag.Float64("min-angle", 0, "Fail if any angle is below this many degrees")
+	maxAngle        = flag.Float64("max-angle", 0, "Fail if any angle is above this many degrees")
+	maxAspect       = flag.Float64("max-aspect", 0, "Fail if any aspect ratio is above this")
+	maxRadiusEdge   = flag.Float64("max-radius-edge", 0, "Fail if any radius-edge ratio is above this")
+	allowDegenerate = flag.Bool("allow-degenerate", false, "Do not fail on degenerate triangles")
+	allowInverted   = flag.Bool("allow-inverted", false, "Do not fail on inverted triangles")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] <mesh.json>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Prints triangle quality statistics for a saved mesh and exits with\n")
+		fmt.Fprintf(os.Stderr, "status 1 if it fails any of the given limits.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	m, err := mesh.Load(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load mesh: %v\n", err)
+		os.Exit(2)
+	}
+
+	stats := quality.Analyze(m)
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode statistics: %v\n", err)
+			os.Exit(2)
+		}
+	} else {
+		printStats(m, stats)
+	}
+
+	err = stats.Check(quality.Limits{
+		MinAngle:           *minAngle,
+		MaxAngle:           *maxAngle,
+		MaxAspectRatio:     *maxAspect,
+		MaxRadiusEdgeRatio: *maxRadiusEdge,
+		AllowDegenerate:    *allowDegenerate,
+		AllowInverted:      *allowInverted,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func printStats(m *mesh.Mesh, s quality.Stats) {
+	fmt.Printf("Vertices          : %d\n", m.NumVertices())
+	fmt.Printf("Triangles         : %d (%d CCW, %d CW, %d degenerate, %d inverted)\n",
+		s.Triangles, s.CCW, s.CW, s.Degenerate, s.Inverted)
+	fmt.Printf("Total area        : %.6g\n", s.TotalArea)
+	fmt.Println()
+
+	fmt.Printf("%-18s %12s %12s %12s\n", "Metric", "Min", "Mean", "Max")
+	rows := []struct {
+		name string
+		s    quality.Summary
+	}{
+		{"Area", s.Area},
+		{"Edge length", s.EdgeLength},
+		{"Min angle", s.MinAngle},
+		{"Max angle", s.MaxAngle},
+		{"Aspect ratio", s.AspectRatio},
+		{"Radius-edge ratio", s.RadiusEdgeRatio},
+		{"Edge ratio", s.EdgeRatio},
+	}
+	for _, r := range rows {
+		fmt.Printf("%-18s %12.6g %12.6g %12.6g\n", r.name, r.s.Min, r.s.Mean, r.s.Max)
+	}
+
+	printHistogram("Min angle (degrees)", s.MinAngleHistogram)
+	printHistogram("Max angle (degrees)", s.MaxAngleHistogram)
+	printHistogram("Aspect ratio", s.AspectRatioHistogram)
+}
+
+func printHistogram(title string, h quality.Histogram) {
+	fmt.Printf("\n%s\n", title)
+
+	most := 0
+	for _, c := range h.Counts {
+		if c > most {
+			most = c
+		}
+	}
+
+	for i, c := range h.Counts {
+		label := fmt.Sprintf("[%g, %g)", h.Bounds[i], h.Bounds[i+1])
+		if i == len(h.Counts)-1 {
+			label = fmt.Sprintf("[%g, ...)", h.Bounds[i])
+		}
+		bar := 0
+		if most > 0 {
+			bar = c * 40 / most
+		}
+		fmt.Printf("  %-14s %8d %s\n", label, c, strings.Repeat("#", bar))
+	}
+}
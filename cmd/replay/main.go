@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/rasterize"
+	"github.com/iceisfun/gomesh/recorder"
+)
+
+var (
+	renderDir = flag.String("render", "", "Render each step as a PNG into this directory")
+	width     = flag.Int("width", 1024, "Rendered image width")
+	height    = flag.Int("height", 1024, "Rendered image height")
+	stopAt    = flag.Int("stop", -1, "Stop after this event index (-1 replays everything)")
+	output    = flag.String("output", "", "Save the final replayed mesh to this JSON file")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] <recording.json>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Replays a mesh recording step by step.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	rec, err := recorder.Load(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to load recording: %v", err)
+	}
+	log.Printf("Loaded recording: %d events", len(rec.Events))
+
+	if *renderDir != "" {
+		if err := os.MkdirAll(*renderDir, 0o755); err != nil {
+			log.Fatalf("Failed to create render directory: %v", err)
+		}
+	}
+
+	errStop := errors.New("stopped")
+	m, err := rec.Replay(func(i int, e recorder.Event, m *mesh.Mesh) error {
+		log.Printf("[%4d] %-15s %s", i, e.Kind, describe(e))
+
+		if *renderDir != "" {
+			if err := render(m, filepath.Join(*renderDir, fmt.Sprintf("step_%05d.png", i))); err != nil {
+				return err
+			}
+		}
+
+		if *stopAt >= 0 && i >= *stopAt {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && err != errStop {
+		log.Printf("❌ Replay failed: %v", err)
+	}
+
+	log.Printf("Replayed mesh: %d vertices, %d triangles, %d perimeters, %d holes",
+		m.NumVertices(), m.NumTriangles(), len(m.Perimeters()), len(m.Holes()))
+
+	if *output != "" {
+		if err := m.Save(*output); err != nil {
+			log.Fatalf("Failed to save mesh: %v", err)
+		}
+		log.Printf("Saved replayed mesh to %s", *output)
+	}
+
+	if err != nil && err != errStop {
+		os.Exit(1)
+	}
+}
+
+func describe(e recorder.Event) string {
+	switch e.Kind {
+	case recorder.EventVertex:
+		return fmt.Sprintf("v%d at (%.6g, %.6g)", e.Vertex, e.Point.X, e.Point.Y)
+	case recorder.EventTriangle:
+		return fmt.Sprintf("%v", *e.Triangle)
+	case recorder.EventRejectTriangle:
+		return fmt.Sprintf("%v: %s", *e.Triangle, e.Error)
+	case recorder.EventPerimeter, recorder.EventHole:
+		return fmt.Sprintf("%d vertices %v", len(e.Loop), e.Loop)
+	case recorder.EventFlipEdge, recorder.EventSplitEdge, recorder.EventCollapseEdge,
+		recorder.EventSplitTriangle, recorder.EventMoveVertex:
+		if e.Error != "" {
+			return "failed: " + e.Error
+		}
+		return fmt.Sprintf("v%d", e.Vertex)
+	default:
+		return ""
+	}
+}
+
+func render(m *mesh.Mesh, filename string) error {
+	img, err := rasterize.Rasterize(m, rasterize.WithDimensions(*width, *height))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return png.Encode(f, img)
+}
@@ -0,0 +1,537 @@
+// Package dual extracts Voronoi diagrams and median-dual meshes from
+// triangle meshes, giving one control-volume polygon per vertex.
+//
+// Voronoi cells are computed from the Delaunay triangulation of the mesh
+// vertices and clipped to the region covered by the triangles, so the cells
+// of boundary vertices are bounded by the perimeter and the holes, and the
+// cells tile the meshed region whatever its triangulation. Their edges are
+// dual to the mesh edges only when the mesh is itself Delaunay, such as the
+// output of cdt.Build in conforming mode.
+//
+// Median-dual (also called barycentric-dual) cells join triangle centroids
+// and edge midpoints instead. They follow the mesh edges, always lie inside
+// the mesh and tile it for any valid triangulation.
+package dual
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/clip"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/cdt"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+var (
+	// ErrNoTriangles is returned when the input has no triangles.
+	ErrNoTriangles = errors.New("no triangles")
+
+	// ErrDegenerateTriangle is returned when a triangle has collinear
+	// corners and therefore no circumcenter.
+	ErrDegenerateTriangle = errors.New("degenerate triangle")
+)
+
+// Cell is the dual polygon of one vertex.
+type Cell struct {
+	// Site is the position of the vertex that owns the cell.
+	Site types.Point
+
+	// Polygons is the cell region. It holds one polygon for most vertices;
+	// a vertex where several fans of triangles meet, or a Voronoi cell cut
+	// apart by the boundary, has several. Vertices not used by any triangle
+	// have none.
+	Polygons []clip.Polygon
+
+	// Boundary reports whether the vertex lies on the mesh boundary.
+	Boundary bool
+}
+
+// Area returns the total area of the cell polygons.
+func (c Cell) Area() float64 {
+	area := 0.0
+	for _, p := range c.Polygons {
+		area += p.Area()
+	}
+	return area
+}
+
+// Diagram is a dual of a triangulation.
+type Diagram struct {
+	// Centers holds the dual point of each triangle, indexed like the
+	// triangles of the input: its circumcenter for a Voronoi diagram and its
+	// centroid for a median dual.
+	Centers []types.Point
+
+	// Cells holds one cell per vertex, indexed by vertex ID.
+	Cells []Cell
+}
+
+// Voronoi returns the Voronoi diagram of the vertices of m, clipped to the
+// region covered by its triangles. Centers holds the circumcenters of the
+// mesh triangles.
+func Voronoi(m *mesh.Mesh) (*Diagram, error) {
+	return newTriangulation(m.GetVertices(), meshTriangles(m)).voronoi()
+}
+
+// VoronoiTriSoup returns the Voronoi diagram of the live triangles of ts.
+// Cells are indexed by TriSoup vertex index and Centers by TriID, with a
+// zero point for deleted triangles. Vertices used by no live triangle get
+// no cell, so pruned cover vertices are ignored.
+func VoronoiTriSoup(ts *cdt.TriSoup) (*Diagram, error) {
+	return newTriangulation(ts.V, soupTriangles(ts)).voronoi()
+}
+
+// MedianDual returns the median-dual mesh of m.
+func MedianDual(m *mesh.Mesh) (*Diagram, error) {
+	return newTriangulation(m.GetVertices(), meshTriangles(m)).medianDual()
+}
+
+// MedianDualTriSoup returns the median-dual mesh of the live triangles of
+// ts, indexed as in VoronoiTriSoup.
+func MedianDualTriSoup(ts *cdt.TriSoup) (*Diagram, error) {
+	return newTriangulation(ts.V, soupTriangles(ts)).medianDual()
+}
+
+// Circumcenter returns the center of the circle through a, b and c. It
+// reports false when the points are collinear.
+func Circumcenter(a, b, c types.Point) (types.Point, bool) {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		return types.Point{}, false
+	}
+	b2 := bx*bx + by*by
+	c2 := cx*cx + cy*cy
+	return types.Point{
+		X: a.X + (cy*b2-by*c2)/d,
+		Y: a.Y + (bx*c2-cx*b2)/d,
+	}, true
+}
+
+func meshTriangles(m *mesh.Mesh) [][3]int {
+	tris := make([][3]int, m.NumTriangles())
+	for i, tri := range m.GetTriangles() {
+		tris[i] = [3]int{int(tri.V1()), int(tri.V2()), int(tri.V3())}
+	}
+	return tris
+}
+
+// soupTriangles returns the triangles of ts by TriID, with deleted
+// triangles marked by a negative first vertex.
+func soupTriangles(ts *cdt.TriSoup) [][3]int {
+	tris := make([][3]int, len(ts.Tri))
+	for i := range ts.Tri {
+		if ts.IsDeleted(cdt.TriID(i)) {
+			tris[i] = [3]int{-1, -1, -1}
+			continue
+		}
+		tris[i] = ts.Tri[i].V
+	}
+	return tris
+}
+
+// triangulation is the common input of both duals: counter-clockwise
+// triangles and, for every vertex, the triangles around it.
+type triangulation struct {
+	pts  []types.Point
+	tris [][3]int
+
+	// around maps a vertex to the incident triangles keyed by the vertex
+	// that follows it counter-clockwise in each triangle.
+	around []map[int]int
+}
+
+func newTriangulation(pts []types.Point, tris [][3]int) *triangulation {
+	t := &triangulation{pts: pts, tris: tris, around: make([]map[int]int, len(pts))}
+	for i, tri := range tris {
+		if tri[0] < 0 {
+			continue
+		}
+		if robust.Orient2D(pts[tri[0]], pts[tri[1]], pts[tri[2]]) < 0 {
+			tri[1], tri[2] = tri[2], tri[1]
+			tris[i] = tri
+		}
+		for k, v := range tri {
+			if t.around[v] == nil {
+				t.around[v] = make(map[int]int)
+			}
+			t.around[v][tri[(k+1)%3]] = i
+		}
+	}
+	return t
+}
+
+// live reports whether triangle i exists.
+func (t *triangulation) live(i int) bool {
+	return t.tris[i][0] >= 0
+}
+
+// fan is a maximal run of triangles around a vertex, in counter-clockwise
+// order. spokes[i] and spokes[i+1] are the other corners of triangle
+// tris[i]; a closed fan repeats its first spoke at the end.
+type fan struct {
+	tris   []int
+	spokes []int
+	closed bool
+}
+
+// fans returns the triangle fans around v.
+func (t *triangulation) fans(v int) []fan {
+	around := t.around[v]
+	if len(around) == 0 {
+		return nil
+	}
+
+	// next returns the spoke that follows a around v.
+	next := func(a int) int {
+		tri := t.tris[around[a]]
+		for k := range tri {
+			if tri[k] == v {
+				return tri[(k+2)%3]
+			}
+		}
+		return -1
+	}
+
+	// Open fans start at a spoke that no triangle ends at.
+	ends := make(map[int]bool, len(around))
+	for a := range around {
+		ends[next(a)] = true
+	}
+	var starts []int
+	for a := range around {
+		if !ends[a] {
+			starts = append(starts, a)
+		}
+	}
+	sort.Ints(starts)
+	rest := make([]int, 0, len(around))
+	for a := range around {
+		rest = append(rest, a)
+	}
+	sort.Ints(rest)
+
+	used := make(map[int]bool, len(around))
+	var out []fan
+	walk := func(a int) {
+		f := fan{spokes: []int{a}}
+		for {
+			tri, ok := around[a]
+			if !ok || used[a] {
+				break
+			}
+			used[a] = true
+			a = next(a)
+			f.tris = append(f.tris, tri)
+			f.spokes = append(f.spokes, a)
+		}
+		f.closed = a == f.spokes[0]
+		out = append(out, f)
+	}
+	for _, a := range starts {
+		walk(a)
+	}
+	for _, a := range rest {
+		if !used[a] {
+			walk(a)
+		}
+	}
+	return out
+}
+
+func (t *triangulation) voronoi() (*Diagram, error) {
+	d, _, err := t.newDiagram()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tri := range t.tris {
+		if !t.live(i) {
+			continue
+		}
+		center, ok := Circumcenter(t.pts[tri[0]], t.pts[tri[1]], t.pts[tri[2]])
+		if !ok {
+			return nil, fmt.Errorf("triangle %d: %w", i, ErrDegenerateTriangle)
+		}
+		d.Centers[i] = center
+	}
+
+	dt, sites, err := t.delaunay()
+	if err != nil {
+		return nil, err
+	}
+	centers := make([]types.Point, len(dt.tris))
+	for i, tri := range dt.tris {
+		if dt.live(i) {
+			centers[i], _ = Circumcenter(dt.pts[tri[0]], dt.pts[tri[1]], dt.pts[tri[2]])
+		}
+	}
+
+	// Every cell contains its site, which lies in the meshed region, so a
+	// cell that no boundary edge comes near lies entirely inside it. Only
+	// the others are clipped, against the triangles they overlap.
+	var boundary [][2]int
+	for i, tri := range t.tris {
+		if !t.live(i) {
+			continue
+		}
+		for k := range tri {
+			a, b := tri[k], tri[(k+1)%3]
+			if _, shared := t.around[b][a]; !shared {
+				boundary = append(boundary, [2]int{a, b})
+			}
+		}
+	}
+	edgeBoxes := make([]types.AABB, len(boundary))
+	for i, e := range boundary {
+		edgeBoxes[i] = box(t.pts[e[0]], t.pts[e[1]])
+	}
+	edges := newBoxGrid(edgeBoxes)
+
+	var tris *boxGrid
+	for v, site := range sites {
+		if site < 0 {
+			continue
+		}
+
+		var loop []types.Point
+		for _, f := range dt.fans(site) {
+			for _, tri := range f.tris {
+				loop = append(loop, centers[tri])
+			}
+		}
+
+		cellBox := box(loop...)
+		near := false
+		edges.query(cellBox, func(int) { near = true })
+		if !near {
+			d.Cells[v].Polygons = []clip.Polygon{{Outer: loop}}
+			continue
+		}
+
+		if tris == nil {
+			triBoxes := make([]types.AABB, len(t.tris))
+			for i, tri := range t.tris {
+				if t.live(i) {
+					triBoxes[i] = box(t.pts[tri[0]], t.pts[tri[1]], t.pts[tri[2]])
+				} else {
+					triBoxes[i] = types.AABB{Min: types.Point{X: math.NaN(), Y: math.NaN()}, Max: types.Point{X: math.NaN(), Y: math.NaN()}}
+				}
+			}
+			tris = newBoxGrid(triBoxes)
+		}
+		var covered []clip.Polygon
+		tris.query(cellBox, func(i int) {
+			tri := t.tris[i]
+			covered = append(covered, clip.Polygon{Outer: []types.Point{t.pts[tri[0]], t.pts[tri[1]], t.pts[tri[2]]}})
+		})
+		d.Cells[v].Polygons = clip.Intersection([]clip.Polygon{{Outer: loop}}, covered)
+	}
+	return d, nil
+}
+
+// delaunay triangulates the vertices used by the mesh inside a cover far
+// enough away that no point of the mesh is closer to a cover vertex than to
+// a mesh vertex. sites maps each mesh vertex to its index in the result, or
+// -1 for unused vertices and for vertices at the same position as an
+// earlier one.
+func (t *triangulation) delaunay() (*triangulation, []int, error) {
+	sites := make([]int, len(t.pts))
+	var pts []types.Point
+	index := make(map[types.Point]int)
+	for v, p := range t.pts {
+		sites[v] = -1
+		if len(t.around[v]) == 0 {
+			continue
+		}
+		if _, ok := index[p]; ok {
+			continue
+		}
+		index[p] = len(pts)
+		sites[v] = len(pts)
+		pts = append(pts, p)
+	}
+
+	ts, _, err := cdt.SeedTriangulation(pts, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	locator := cdt.NewLocator(ts)
+	for i, p := range pts {
+		loc, err := locator.LocatePoint(p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to locate vertex %d: %w", i, err)
+		}
+		_, edges, err := cdt.InsertPoint(ts, loc, i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to insert vertex %d: %w", i, err)
+		}
+		cdt.LegalizeAround(ts, edges, nil)
+	}
+	return newTriangulation(ts.V, soupTriangles(ts)), sites, nil
+}
+
+func sortedTriple(tri [3]int) [3]int {
+	sort.Ints(tri[:])
+	return tri
+}
+
+func (t *triangulation) medianDual() (*Diagram, error) {
+	d, fans, err := t.newDiagram()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tri := range t.tris {
+		if !t.live(i) {
+			continue
+		}
+		a, b, c := t.pts[tri[0]], t.pts[tri[1]], t.pts[tri[2]]
+		d.Centers[i] = types.Point{X: (a.X + b.X + c.X) / 3, Y: (a.Y + b.Y + c.Y) / 3}
+	}
+
+	for v, site := range t.pts {
+		for _, f := range fans[v] {
+			var loop []types.Point
+			if !f.closed {
+				loop = append(loop, site)
+			}
+			for i, tri := range f.tris {
+				loop = append(loop, midpoint(site, t.pts[f.spokes[i]]), d.Centers[tri])
+			}
+			if !f.closed {
+				loop = append(loop, midpoint(site, t.pts[f.spokes[len(f.spokes)-1]]))
+			}
+			d.Cells[v].Polygons = append(d.Cells[v].Polygons, clip.Polygon{Outer: loop})
+		}
+	}
+	return d, nil
+}
+
+// newDiagram allocates a diagram, finds the fans around every vertex and
+// marks the boundary vertices.
+func (t *triangulation) newDiagram() (*Diagram, [][]fan, error) {
+	d := &Diagram{
+		Centers: make([]types.Point, len(t.tris)),
+		Cells:   make([]Cell, len(t.pts)),
+	}
+
+	live := 0
+	for i := range t.tris {
+		if t.live(i) {
+			live++
+		}
+	}
+	if live == 0 {
+		return nil, nil, ErrNoTriangles
+	}
+
+	fans := make([][]fan, len(t.pts))
+	for v, p := range t.pts {
+		d.Cells[v].Site = p
+		fans[v] = t.fans(v)
+		for _, f := range fans[v] {
+			if !f.closed {
+				d.Cells[v].Boundary = true
+			}
+		}
+	}
+	return d, fans, nil
+}
+
+// boxGrid buckets bounding boxes so the boxes overlapping a query box can
+// be found quickly. Boxes with NaN coordinates are skipped.
+type boxGrid struct {
+	boxes   []types.AABB
+	bounds  types.AABB
+	size    float64
+	buckets map[[2]int][]int
+}
+
+func newBoxGrid(boxes []types.AABB) *boxGrid {
+	g := &boxGrid{boxes: boxes, size: 1, buckets: make(map[[2]int][]int)}
+
+	// Use the mean box extent as the bucket size, so a box usually lands
+	// in a handful of buckets.
+	total, n := 0.0, 0
+	for _, b := range boxes {
+		if extent := math.Max(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y); extent > 0 {
+			total += extent
+			n++
+		}
+	}
+	if n > 0 {
+		g.size = total / float64(n)
+	}
+
+	first := true
+	for i, b := range boxes {
+		if math.IsNaN(b.Min.X) {
+			continue
+		}
+		if first {
+			g.bounds, first = b, false
+		}
+		g.bounds = box(g.bounds.Min, g.bounds.Max, b.Min, b.Max)
+		g.visit(b, func(key [2]int) {
+			g.buckets[key] = append(g.buckets[key], i)
+		})
+	}
+	return g
+}
+
+func (g *boxGrid) visit(b types.AABB, fn func([2]int)) {
+	if math.IsNaN(b.Min.X) {
+		return
+	}
+	x0, y0 := int(math.Floor(b.Min.X/g.size)), int(math.Floor(b.Min.Y/g.size))
+	x1, y1 := int(math.Floor(b.Max.X/g.size)), int(math.Floor(b.Max.Y/g.size))
+	for x := x0; x <= x1; x++ {
+		for y := y0; y <= y1; y++ {
+			fn([2]int{x, y})
+		}
+	}
+}
+
+// query calls fn once for each box overlapping b.
+func (g *boxGrid) query(b types.AABB, fn func(int)) {
+	// Only the part of b that overlaps some box needs visiting.
+	b.Min.X, b.Min.Y = math.Max(b.Min.X, g.bounds.Min.X), math.Max(b.Min.Y, g.bounds.Min.Y)
+	b.Max.X, b.Max.Y = math.Min(b.Max.X, g.bounds.Max.X), math.Min(b.Max.Y, g.bounds.Max.Y)
+	if b.Min.X > b.Max.X || b.Min.Y > b.Max.Y {
+		return
+	}
+
+	seen := make(map[int]bool)
+	g.visit(b, func(key [2]int) {
+		for _, i := range g.buckets[key] {
+			if seen[i] {
+				continue
+			}
+			seen[i] = true
+			o := g.boxes[i]
+			if o.Max.X >= b.Min.X && o.Min.X <= b.Max.X && o.Max.Y >= b.Min.Y && o.Min.Y <= b.Max.Y {
+				fn(i)
+			}
+		}
+	})
+}
+
+// box returns the bounding box of pts.
+func box(pts ...types.Point) types.AABB {
+	b := types.AABB{Min: pts[0], Max: pts[0]}
+	for _, p := range pts[1:] {
+		b.Min.X, b.Min.Y = math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y)
+		b.Max.X, b.Max.Y = math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y)
+	}
+	return b
+}
+
+func midpoint(a, b types.Point) types.Point {
+	return types.Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
@@ -0,0 +1,163 @@
+package dual
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/cdt"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// grid builds an n by n grid of unit squares, each split into two right
+// triangles.
+func grid(t *testing.T, n int) *mesh.Mesh {
+	t.Helper()
+	m := mesh.NewMesh()
+	ids := make([][]types.VertexID, n+1)
+	for y := 0; y <= n; y++ {
+		ids[y] = make([]types.VertexID, n+1)
+		for x := 0; x <= n; x++ {
+			id, err := m.AddVertex(types.Point{X: float64(x), Y: float64(y)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids[y][x] = id
+		}
+	}
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if err := m.AddTriangle(ids[y][x], ids[y][x+1], ids[y+1][x+1]); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.AddTriangle(ids[y][x], ids[y+1][x+1], ids[y+1][x]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return m
+}
+
+func totalArea(d *Diagram) float64 {
+	total := 0.0
+	for _, c := range d.Cells {
+		total += c.Area()
+	}
+	return total
+}
+
+func cellAt(t *testing.T, m *mesh.Mesh, d *Diagram, p types.Point) Cell {
+	t.Helper()
+	for i, q := range m.GetVertices() {
+		if q == p {
+			return d.Cells[i]
+		}
+	}
+	t.Fatalf("no vertex at %v", p)
+	return Cell{}
+}
+
+func TestVoronoiGrid(t *testing.T) {
+	m := grid(t, 2)
+	d, err := Voronoi(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		site     types.Point
+		area     float64
+		boundary bool
+	}{
+		{types.Point{X: 1, Y: 1}, 1, false},
+		{types.Point{X: 0, Y: 1}, 0.5, true},
+		{types.Point{X: 0, Y: 0}, 0.25, true},
+	}
+	for _, tc := range cases {
+		c := cellAt(t, m, d, tc.site)
+		if math.Abs(c.Area()-tc.area) > 1e-12 || c.Boundary != tc.boundary {
+			t.Fatalf("cell at %v: area %v boundary %v, expected %v %v", tc.site, c.Area(), c.Boundary, tc.area, tc.boundary)
+		}
+	}
+	if got := totalArea(d); math.Abs(got-4) > 1e-12 {
+		t.Fatalf("expected cells to cover area 4, got %v", got)
+	}
+}
+
+func TestMedianDualGrid(t *testing.T) {
+	m := grid(t, 2)
+	d, err := MedianDual(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An interior vertex owns a third of each of its six triangles.
+	if c := cellAt(t, m, d, types.Point{X: 1, Y: 1}); math.Abs(c.Area()-1) > 1e-12 || len(c.Polygons) != 1 {
+		t.Fatalf("unexpected interior cell %+v", c)
+	}
+	if got := totalArea(d); math.Abs(got-4) > 1e-12 {
+		t.Fatalf("expected cells to cover area 4, got %v", got)
+	}
+	if d.Centers[0] != (types.Point{X: 2.0 / 3, Y: 1.0 / 3}) {
+		t.Fatalf("unexpected centroid %v", d.Centers[0])
+	}
+}
+
+func TestVoronoiClipsToDomain(t *testing.T) {
+	// Triangulations of an L shape with a hole have cells that reach past
+	// the reflex corner and across the hole.
+	outer := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 10}, {X: 0, Y: 10}}
+	hole := []types.Point{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 8, Y: 2}, {X: 8, Y: 1}}
+	want := 10.0*3 + 3*7 - 7
+
+	for _, conforming := range []bool{false, true} {
+		opts := cdt.DefaultBuildOptions()
+		opts.Conforming = conforming
+		m, err := cdt.BuildWithOptions(outer, [][]types.Point{hole}, nil, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, build := range map[string]func(*mesh.Mesh) (*Diagram, error){"voronoi": Voronoi, "median": MedianDual} {
+			d, err := build(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := totalArea(d); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("%s (conforming %v): expected cells to cover area %v, got %v", name, conforming, want, got)
+			}
+		}
+	}
+}
+
+func TestTriSoup(t *testing.T) {
+	pts := []types.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 9, Y: 9}}
+	ts := cdt.NewTriSoup(pts, 3)
+	ts.AddTri(0, 1, 2)
+	ts.AddTri(0, 2, 3)
+	ts.RemoveTri(ts.AddTri(2, 4, 3))
+
+	d, err := VoronoiTriSoup(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Cells) != 5 || len(d.Cells[4].Polygons) != 0 {
+		t.Fatalf("expected vertex 4 to have no cell, got %+v", d.Cells)
+	}
+	if got := totalArea(d); math.Abs(got-4) > 1e-12 {
+		t.Fatalf("expected cells to cover area 4, got %v", got)
+	}
+
+	d, err = MedianDualTriSoup(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(d.Cells[0].Area()-4.0/3) > 1e-12 {
+		t.Fatalf("expected vertex 0 to own a third of both triangles, got %v", d.Cells[0].Area())
+	}
+
+	if _, err := VoronoiTriSoup(cdt.NewTriSoup(pts, 0)); !errors.Is(err, ErrNoTriangles) {
+		t.Fatalf("expected ErrNoTriangles, got %v", err)
+	}
+}
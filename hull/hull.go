@@ -0,0 +1,308 @@
+// Package hull invents outlines for point clouds that have no explicit
+// perimeter.
+//
+// ConvexHull returns the smallest convex loop around the points. AlphaShape
+// and ConcaveHull start from the Delaunay triangulation of the points,
+// built with the cdt package, and carve it back: AlphaShape keeps the
+// triangles whose circumradius is at most alpha, which can produce several
+// polygons with holes, while ConcaveHull peels long boundary edges off the
+// convex hull one triangle at a time and always returns a single simple
+// loop through the boundary points.
+//
+// Loops are returned counter-clockwise for perimeters and clockwise for
+// holes, ready for mesh.AddPerimeter, mesh.AddHole or cdt.Build.
+package hull
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/clip"
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/cdt"
+	"github.com/iceisfun/gomesh/types"
+)
+
+var (
+	// ErrTooFewPoints is returned when the input has fewer than three
+	// distinct points or all of them are collinear.
+	ErrTooFewPoints = errors.New("need at least three non-collinear points")
+
+	// ErrInvalidAlpha is returned when alpha is not positive.
+	ErrInvalidAlpha = errors.New("alpha must be positive")
+)
+
+// coverMargin places the cover of the Delaunay triangulation far from the
+// points, so only extremely flat hull triangles lose out to cover triangles.
+const coverMargin = 10
+
+// ConvexHull returns the convex hull of points as a counter-clockwise loop
+// without collinear vertices.
+func ConvexHull(points []types.Point) ([]types.Point, error) {
+	pts := unique(points)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+
+	// Andrew's monotone chain: build the lower hull left to right and the
+	// upper hull right to left.
+	hull := make([]types.Point, 0, 2*len(pts))
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range pts {
+			for len(hull) >= start+2 && robust.Orient2D(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+
+	if len(hull) < 3 {
+		return nil, ErrTooFewPoints
+	}
+	return hull, nil
+}
+
+// AlphaShape returns the union of the Delaunay triangles of points whose
+// circumradius is at most alpha, as polygons with holes.
+//
+// Smaller alpha follows the points more tightly; once alpha exceeds the
+// largest circumradius the result is the convex hull. Points not covered
+// by any kept triangle are left out, and collinear boundary vertices are
+// dropped from the loops.
+func AlphaShape(points []types.Point, alpha float64) ([]clip.Polygon, error) {
+	if !(alpha > 0) {
+		return nil, ErrInvalidAlpha
+	}
+
+	d, err := delaunay(points)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]bool, len(d.tris))
+	for i, tri := range d.tris {
+		kept[i] = circumradius(d.pts[tri[0]], d.pts[tri[1]], d.pts[tri[2]]) <= alpha
+	}
+	return clip.Simplify(d.boundary(kept), clip.Positive), nil
+}
+
+// ConcaveHull returns a simple counter-clockwise loop around points that
+// hugs them more closely than the convex hull.
+//
+// Starting from the Delaunay triangulation, the longest boundary edge is
+// removed together with its triangle as long as it is longer than maxEdge
+// and removing the triangle keeps the outline a simple polygon. Every point
+// stays inside or on the loop. A maxEdge of zero carves as far as possible;
+// one at least as long as the longest hull edge returns the convex hull.
+func ConcaveHull(points []types.Point, maxEdge float64) ([]types.Point, error) {
+	d, err := delaunay(points)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]bool, len(d.tris))
+	for i := range kept {
+		kept[i] = true
+	}
+
+	onBoundary := make([]bool, len(d.pts))
+	var queue edgeQueue
+	push := func(a, b int) {
+		onBoundary[a], onBoundary[b] = true, true
+		pa, pb := d.pts[a], d.pts[b]
+		if length := math.Hypot(pb.X-pa.X, pb.Y-pa.Y); length > maxEdge {
+			heap.Push(&queue, boundaryEdge{a: a, b: b, length: length})
+		}
+	}
+	for e := range d.edges {
+		if _, ok := d.edges[[2]int{e[1], e[0]}]; !ok {
+			push(e[0], e[1])
+		}
+	}
+
+	for queue.Len() > 0 {
+		e := heap.Pop(&queue).(boundaryEdge)
+		t := d.edges[[2]int{e.a, e.b}]
+		tri := d.tris[t]
+		c := tri[0] + tri[1] + tri[2] - e.a - e.b
+
+		// Removing the triangle would pinch the outline at c.
+		if onBoundary[c] {
+			continue
+		}
+		kept[t] = false
+		push(e.a, c)
+		push(c, e.b)
+	}
+
+	loops := d.boundary(kept)
+	return loops[0], nil
+}
+
+// triangulation is a Delaunay triangulation of distinct points with
+// counter-clockwise triangles.
+type triangulation struct {
+	pts  []types.Point
+	tris [][3]int
+
+	// edges maps each directed triangle edge to its triangle.
+	edges map[[2]int]int
+}
+
+// delaunay triangulates the distinct points with the cdt primitives and
+// drops the cover triangles.
+func delaunay(points []types.Point) (*triangulation, error) {
+	pts := unique(points)
+	if len(pts) < 3 {
+		return nil, ErrTooFewPoints
+	}
+	collinear := true
+	for _, p := range pts[2:] {
+		if robust.Orient2D(pts[0], pts[1], p) != 0 {
+			collinear = false
+			break
+		}
+	}
+	if collinear {
+		return nil, ErrTooFewPoints
+	}
+
+	ts, _, err := cdt.SeedTriangulation(pts, coverMargin)
+	if err != nil {
+		return nil, err
+	}
+	locator := cdt.NewLocator(ts)
+	for i, p := range pts {
+		loc, err := locator.LocatePoint(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate point %d: %w", i, err)
+		}
+		_, edges, err := cdt.InsertPoint(ts, loc, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert point %d: %w", i, err)
+		}
+		cdt.LegalizeAround(ts, edges, nil)
+	}
+
+	d := &triangulation{pts: pts, edges: make(map[[2]int]int)}
+	for i := range ts.Tri {
+		if ts.IsDeleted(cdt.TriID(i)) {
+			continue
+		}
+		tri := ts.Tri[i].V
+		if tri[0] >= len(pts) || tri[1] >= len(pts) || tri[2] >= len(pts) {
+			continue
+		}
+		for k := range tri {
+			d.edges[[2]int{tri[k], tri[(k+1)%3]}] = len(d.tris)
+		}
+		d.tris = append(d.tris, tri)
+	}
+	return d, nil
+}
+
+// boundary returns the loops separating the kept triangles from the rest,
+// each with the kept triangles on its left.
+func (d *triangulation) boundary(kept []bool) [][]types.Point {
+	out := make(map[int][]int)
+	for e, t := range d.edges {
+		if !kept[t] {
+			continue
+		}
+		if u, ok := d.edges[[2]int{e[1], e[0]}]; !ok || !kept[u] {
+			out[e[0]] = append(out[e[0]], e[1])
+		}
+	}
+
+	starts := make([]int, 0, len(out))
+	for v := range out {
+		starts = append(starts, v)
+	}
+	sort.Ints(starts)
+
+	// Every vertex has as many boundary edges leaving as entering, so
+	// following unused edges always returns to the start.
+	var loops [][]types.Point
+	for _, start := range starts {
+		for len(out[start]) > 0 {
+			var loop []types.Point
+			for v := start; ; {
+				loop = append(loop, d.pts[v])
+				next := out[v][len(out[v])-1]
+				out[v] = out[v][:len(out[v])-1]
+				v = next
+				if v == start {
+					break
+				}
+			}
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// unique returns the distinct points in input order.
+func unique(points []types.Point) []types.Point {
+	seen := make(map[types.Point]bool, len(points))
+	out := make([]types.Point, 0, len(points))
+	for _, p := range points {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// circumradius returns the radius of the circle through a, b and c, or
+// +Inf when they are collinear.
+func circumradius(a, b, c types.Point) float64 {
+	ab := math.Hypot(b.X-a.X, b.Y-a.Y)
+	bc := math.Hypot(c.X-b.X, c.Y-b.Y)
+	ca := math.Hypot(a.X-c.X, a.Y-c.Y)
+	area2 := math.Abs((b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X))
+	if area2 == 0 {
+		return math.Inf(1)
+	}
+	return ab * bc * ca / (2 * area2)
+}
+
+// boundaryEdge is a directed outline edge waiting to be peeled.
+type boundaryEdge struct {
+	a, b   int
+	length float64
+}
+
+// edgeQueue is a max-heap of boundary edges by length.
+type edgeQueue []boundaryEdge
+
+func (q edgeQueue) Len() int { return len(q) }
+func (q edgeQueue) Less(i, j int) bool {
+	if q[i].length != q[j].length {
+		return q[i].length > q[j].length
+	}
+	if q[i].a != q[j].a {
+		return q[i].a < q[j].a
+	}
+	return q[i].b < q[j].b
+}
+func (q edgeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *edgeQueue) Push(x any)   { *q = append(*q, x.(boundaryEdge)) }
+func (q *edgeQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
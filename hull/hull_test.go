@@ -0,0 +1,109 @@
+package hull
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/cdt"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// gridPoints returns the integer points of [0,w]x[0,h] for which keep
+// returns true.
+func gridPoints(w, h int, keep func(x, y int) bool) []types.Point {
+	var pts []types.Point
+	for y := 0; y <= h; y++ {
+		for x := 0; x <= w; x++ {
+			if keep(x, y) {
+				pts = append(pts, types.Point{X: float64(x), Y: float64(y)})
+			}
+		}
+	}
+	return pts
+}
+
+func TestConvexHull(t *testing.T) {
+	pts := []types.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 1, Y: 1}, {X: 3, Y: 2}, {X: 4, Y: 4}}
+	hull, err := ConvexHull(pts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hull) != 4 || polygon.SignedArea(hull) != 16 {
+		t.Fatalf("expected the CCW square without collinear points, got %v", hull)
+	}
+
+	if _, err := ConvexHull([]types.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}); !errors.Is(err, ErrTooFewPoints) {
+		t.Fatalf("expected ErrTooFewPoints, got %v", err)
+	}
+}
+
+func TestAlphaShape(t *testing.T) {
+	// A 10x10 grid with a gap in the middle and a separate cluster. The
+	// gap is 6x6 less the four grid triangles filling its corners.
+	pts := gridPoints(10, 10, func(x, y int) bool { return x < 3 || x > 7 || y < 3 || y > 7 })
+	pts = append(pts, gridPoints(2, 2, func(int, int) bool { return true })...)
+	for i := len(pts) - 9; i < len(pts); i++ {
+		pts[i].X += 20
+	}
+
+	shapes, err := AlphaShape(pts, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shapes) != 2 {
+		t.Fatalf("expected two polygons, got %d", len(shapes))
+	}
+	var ring, island = shapes[0], shapes[1]
+	if len(ring.Holes) == 0 {
+		ring, island = island, ring
+	}
+	if len(ring.Holes) != 1 || math.Abs(ring.Area()-66) > 1e-9 || math.Abs(island.Area()-4) > 1e-9 {
+		t.Fatalf("unexpected shapes: ring area %v with %d holes, island area %v", ring.Area(), len(ring.Holes), island.Area())
+	}
+	if polygon.SignedArea(ring.Outer) <= 0 || polygon.SignedArea(ring.Holes[0]) >= 0 {
+		t.Fatal("expected a CCW outer and a CW hole")
+	}
+	if _, err := cdt.BuildSimple(ring.Outer, ring.Holes); err != nil {
+		t.Fatalf("alpha shape is not meshable: %v", err)
+	}
+
+	shapes, err = AlphaShape(pts, 1e9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shapes) != 1 || len(shapes[0].Holes) != 0 || math.Abs(shapes[0].Area()-172) > 1e-9 {
+		t.Fatalf("expected the convex hull for a huge alpha, got %+v", shapes)
+	}
+
+	if _, err := AlphaShape(pts, 0); !errors.Is(err, ErrInvalidAlpha) {
+		t.Fatalf("expected ErrInvalidAlpha, got %v", err)
+	}
+}
+
+func TestConcaveHull(t *testing.T) {
+	// An L shape of grid points.
+	pts := gridPoints(10, 10, func(x, y int) bool { return x <= 3 || y <= 3 })
+
+	loop, err := ConcaveHull(pts, 1.2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := polygon.SignedArea(loop); math.Abs(area-(10*3+3*7)) > 1e-9 {
+		t.Fatalf("expected the L shape area 51, got %v", area)
+	}
+	for _, p := range pts {
+		if polygon.PointInPolygon(p, loop) == polygon.Outside {
+			t.Fatalf("point %v is outside the concave hull", p)
+		}
+	}
+
+	convex, err := ConcaveHull(pts, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := polygon.SignedArea(convex); math.Abs(area-(100-7*7/2.0)) > 1e-9 {
+		t.Fatalf("expected the convex hull area, got %v", area)
+	}
+}
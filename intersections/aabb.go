@@ -10,7 +10,7 @@ import (
 func MeshIntersectsAABB(m *mesh.Mesh, box types.AABB) bool {
 	for i := 0; i < m.NumTriangles(); i++ {
 		a, b, c := m.GetTriangleCoords(i)
-		if predicates.TriangleAABBIntersect(a, b, c, box, m.Epsilon()) {
+		if predicates.TriangleAABBIntersect(a, b, c, box, m.Predicates().Distance(a, b, c, box.Min, box.Max)) {
 			return true
 		}
 	}
@@ -23,5 +23,5 @@ func TriangleIntersectsAABB(m *mesh.Mesh, triIndex int, box types.AABB) (bool, e
 		return false, mesh.ErrInvalidTriangleIndex
 	}
 	a, b, c := m.GetTriangleCoords(triIndex)
-	return predicates.TriangleAABBIntersect(a, b, c, box, m.Epsilon()), nil
+	return predicates.TriangleAABBIntersect(a, b, c, box, m.Predicates().Distance(a, b, c, box.Min, box.Max)), nil
 }
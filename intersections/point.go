@@ -2,7 +2,6 @@ package intersections
 
 import (
 	"github.com/iceisfun/gomesh/mesh"
-	"github.com/iceisfun/gomesh/predicates"
 	"github.com/iceisfun/gomesh/types"
 )
 
@@ -10,7 +9,7 @@ import (
 func PointInMesh(m *mesh.Mesh, p types.Point) bool {
 	for i := 0; i < m.NumTriangles(); i++ {
 		a, b, c := m.GetTriangleCoords(i)
-		if predicates.PointInTriangle(p, a, b, c, m.Epsilon()) {
+		if m.Predicates().PointInTriangle(p, a, b, c) {
 			return true
 		}
 	}
@@ -2,7 +2,6 @@ package intersections
 
 import (
 	"github.com/iceisfun/gomesh/mesh"
-	"github.com/iceisfun/gomesh/predicates"
 	"github.com/iceisfun/gomesh/types"
 )
 
@@ -17,6 +16,6 @@ func SegmentIntersection(m *mesh.Mesh, a1, a2, b1, b2 types.VertexID) (types.Poi
 	p3 := m.GetVertex(b1)
 	p4 := m.GetVertex(b2)
 
-	point, kind := predicates.SegmentIntersectionPoint(p1, p2, p3, p4, m.Epsilon())
+	point, kind := m.Predicates().SegmentIntersectionPoint(p1, p2, p3, p4)
 	return point, kind, nil
 }
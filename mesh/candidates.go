@@ -3,7 +3,6 @@ package mesh
 import (
 	"sync"
 
-	"github.com/iceisfun/gomesh/predicates"
 	"github.com/iceisfun/gomesh/types"
 	"github.com/iceisfun/gomesh/validation"
 )
@@ -131,6 +130,10 @@ func (m *Mesh) VertexFindTriangleCandidates(v types.VertexID) []CandidateTriangl
 
 	numVertices := m.NumVertices()
 
+	// Validators may call VertexTriangles from the workers. Build the lazy
+	// incidence cache now so that they only read it.
+	m.incidentTriangles(v)
+
 	// Channel to collect results
 	resultsChan := make(chan CandidateTriangle, numVertices*numVertices)
 	var wg sync.WaitGroup
@@ -210,7 +213,7 @@ func (m *Mesh) edgeCrossesAnyPerimeter(v1, v2 types.VertexID) bool {
 			// Check for proper intersection
 			p1 := m.vertices[perim[i]]
 			p2 := m.vertices[perim[next]]
-			intersects, proper := predicates.SegmentsIntersect(a, b, p1, p2, m.cfg.epsilon)
+			intersects, proper := m.cfg.mode().SegmentsIntersect(a, b, p1, p2)
 			if intersects && proper {
 				return true
 			}
@@ -239,7 +242,7 @@ func (m *Mesh) edgeCrossesAnyHole(v1, v2 types.VertexID) bool {
 			// Check for proper intersection
 			p1 := m.vertices[hole[i]]
 			p2 := m.vertices[hole[next]]
-			intersects, proper := predicates.SegmentsIntersect(a, b, p1, p2, m.cfg.epsilon)
+			intersects, proper := m.cfg.mode().SegmentsIntersect(a, b, p1, p2)
 			if intersects && proper {
 				return true
 			}
@@ -272,7 +275,7 @@ func (m *Mesh) edgeCrossesAnyTriangleEdge(v1, v2 types.VertexID) bool {
 			// Check for proper intersection
 			p1 := m.vertices[triEdge.V1()]
 			p2 := m.vertices[triEdge.V2()]
-			intersects, proper := predicates.SegmentsIntersect(a, b, p1, p2, m.cfg.epsilon)
+			intersects, proper := m.cfg.mode().SegmentsIntersect(a, b, p1, p2)
 			if intersects && proper {
 				return true
 			}
@@ -298,10 +301,10 @@ func (m *Mesh) validateTriangleCandidate(tri types.Triangle, a, b, c types.Point
 
 	// Check if triangle's interior stays inside perimeter
 	if m.triangleGoesOutsidePerimeter(a, b, c) {
-		return ErrEdgeCrossesPerimeter // Reuse this error for consistency
+		return m.triangleOutsideError(tri, a, b, c)
 	}
 
-	return nil
+	return m.runTriangleValidators(tri, a, b, c)
 }
 
 // edgeGoesOutsidePerimeter checks if an edge goes outside the perimeter boundary.
@@ -359,7 +362,7 @@ func (m *Mesh) edgeGoesOutsidePerimeter(v1, v2 types.VertexID) bool {
 			perimPoints[i] = m.vertices[vid]
 		}
 
-		if predicates.PointInPolygonRayCast(midpoint, perimPoints, m.cfg.epsilon) {
+		if m.cfg.mode().PointInPolygon(midpoint, perimPoints) {
 			insideAnyPerimeter = true
 			break
 		}
@@ -377,7 +380,7 @@ func (m *Mesh) edgeGoesOutsidePerimeter(v1, v2 types.VertexID) bool {
 			holePoints[i] = m.vertices[vid]
 		}
 
-		if predicates.PointInPolygonRayCast(midpoint, holePoints, m.cfg.epsilon) {
+		if m.cfg.mode().PointInPolygon(midpoint, holePoints) {
 			return true // Edge goes through a hole
 		}
 	}
@@ -413,7 +416,7 @@ func (m *Mesh) triangleGoesOutsidePerimeter(a, b, c types.Point) bool {
 			perimPoints[i] = m.vertices[vid]
 		}
 
-		if predicates.PointInPolygonRayCast(centroid, perimPoints, m.cfg.epsilon) {
+		if m.cfg.mode().PointInPolygon(centroid, perimPoints) {
 			insideAnyPerimeter = true
 			break
 		}
@@ -431,7 +434,7 @@ func (m *Mesh) triangleGoesOutsidePerimeter(a, b, c types.Point) bool {
 			holePoints[i] = m.vertices[vid]
 		}
 
-		if predicates.PointInPolygonRayCast(centroid, holePoints, m.cfg.epsilon) {
+		if m.cfg.mode().PointInPolygon(centroid, holePoints) {
 			return true // Triangle centroid is in a hole
 		}
 	}
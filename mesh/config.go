@@ -1,12 +1,23 @@
 package mesh
 
-import "github.com/iceisfun/gomesh/types"
+import (
+	"math"
+
+	"github.com/iceisfun/gomesh/algorithm/snap"
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
 
 type config struct {
-	epsilon float64
+	// Geometric tolerance; Rel scales it with coordinate magnitude
+	tolerance types.Epsilon
+	// Decide orientation and containment exactly, ignoring tolerance
+	exactPredicates bool
 
 	mergeVertices bool
 	mergeDistance float64
+	// Grid that vertex coordinates are rounded to (disabled when Cell is 0)
+	snapGrid snap.Grid
 
 	validateVertexInside             bool
 	validateEdgeIntersection         bool
@@ -15,9 +26,30 @@ type config struct {
 	errorOnDuplicateTriangle         bool
 	errorOnOpposingDuplicate         bool
 
+	// Polygon feature limits applied by AddPerimeter and AddHole (0 = off)
+	minEdgeLength  float64
+	minAngle       float64
+	minFeatureSize float64
+	minClearance   float64
+
+	// User-defined checks run by AddTriangle after the built-in ones
+	triangleValidators []namedValidator
+
+	journal bool
+
 	debugAddVertex   func(types.VertexID, types.Point)
 	debugAddEdge     func(types.Edge)
 	debugAddTriangle func(types.Triangle)
+
+	debugRejectTriangle func(types.Triangle, error)
+	debugAddPerimeter   func(types.PolygonLoop)
+	debugAddHole        func(types.PolygonLoop)
+
+	debugFlipEdge      func(types.Edge, error)
+	debugSplitEdge     func(types.Edge, types.Point, types.VertexID, error)
+	debugCollapseEdge  func(types.Edge, types.VertexID, error)
+	debugSplitTriangle func(int, types.Point, types.VertexID, error)
+	debugMoveVertex    func(types.VertexID, types.Point, error)
 }
 
 // DefaultEpsilon is the default tolerance for geometric operations.
@@ -25,7 +57,7 @@ const DefaultEpsilon = 1e-9
 
 func newDefaultConfig() config {
 	return config{
-		epsilon:       DefaultEpsilon,
+		tolerance:     types.Epsilon{Abs: DefaultEpsilon},
 		mergeVertices: false,
 		mergeDistance: 0,
 	}
@@ -35,5 +67,21 @@ func (c *config) effectiveMergeDistance() float64 {
 	if c.mergeDistance > 0 {
 		return c.mergeDistance
 	}
-	return c.epsilon
+	return c.tolerance.Abs
+}
+
+// mode returns the predicate mode used by every geometric check.
+func (c *config) mode() predicates.Mode {
+	return predicates.Mode{Epsilon: c.tolerance, Exact: c.exactPredicates}
+}
+
+// overlapTolerance returns the intersection area below which triangles t1
+// and t2 only touch: a sliver as thin as the distance tolerance along the
+// longest edge of either triangle.
+func (m *Mesh) overlapTolerance(t1, t2 types.Triangle) float64 {
+	v := m.vertices
+	return math.Max(
+		predicates.AreaTolerance(m.cfg.tolerance, v[t1.V1()], v[t1.V2()], v[t1.V3()]),
+		predicates.AreaTolerance(m.cfg.tolerance, v[t2.V1()], v[t2.V2()], v[t2.V3()]),
+	)
 }
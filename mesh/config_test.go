@@ -4,8 +4,8 @@ import "testing"
 
 func TestNewDefaultConfig(t *testing.T) {
 	cfg := newDefaultConfig()
-	if cfg.epsilon != DefaultEpsilon {
-		t.Fatalf("expected default epsilon, got %v", cfg.epsilon)
+	if cfg.tolerance.Abs != DefaultEpsilon {
+		t.Fatalf("expected default epsilon, got %v", cfg.tolerance.Abs)
 	}
 	if cfg.mergeVertices {
 		t.Fatalf("mergeVertices should be disabled by default")
@@ -14,8 +14,8 @@ func TestNewDefaultConfig(t *testing.T) {
 
 func TestEffectiveMergeDistance(t *testing.T) {
 	cfg := newDefaultConfig()
-	cfg.epsilon = 1e-6
-	if dist := cfg.effectiveMergeDistance(); dist != cfg.epsilon {
+	cfg.tolerance.Abs = 1e-6
+	if dist := cfg.effectiveMergeDistance(); dist != cfg.tolerance.Abs {
 		t.Fatalf("expected epsilon merge distance, got %v", dist)
 	}
 
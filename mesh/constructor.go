@@ -20,11 +20,18 @@ func NewMesh(opts ...Option) *Mesh {
 		cfg:         cfg,
 		edgeSet:     make(map[types.Edge]struct{}),
 		triangleSet: make(map[[3]types.VertexID]types.Triangle),
+
+		edgeUses:     make(map[types.Edge]int),
+		triangleUses: make(map[[3]types.VertexID]int),
 	}
 
 	if cfg.mergeVertices {
 		m.vertexIndex = spatial.NewHashGrid(cfg.effectiveMergeDistance())
 	}
 
+	if cfg.journal {
+		m.journal = newJournal()
+	}
+
 	return m
 }
@@ -0,0 +1,472 @@
+package mesh
+
+import (
+	"slices"
+
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Local topological operators.
+//
+// The operators modify existing triangles in place, keep perimeter and hole
+// loops consistent, and reject any change that would invert or degenerate a
+// triangle. Every triangle they create must also pass the checks AddTriangle
+// applies, including triangle validators; otherwise the mesh is left
+// unchanged. They are recorded by the journal like the Add* methods but do not
+// call the debug hooks. Vertex IDs stay stable: CollapseEdge leaves the
+// removed vertex in place, unreferenced.
+
+// FlipEdge replaces the two triangles sharing e with the two triangles
+// sharing the opposite diagonal of their quad.
+//
+// The quad must be strictly convex and e must not lie on a perimeter or hole.
+func (m *Mesh) FlipEdge(e types.Edge) error {
+	m.beginCommand(OpFlipEdge, []types.VertexID{e.V1(), e.V2()}, nil)
+	err := m.flipEdge(e)
+	m.endCommand(err)
+	if m.cfg.debugFlipEdge != nil {
+		m.cfg.debugFlipEdge(e, err)
+	}
+	return err
+}
+
+func (m *Mesh) flipEdge(e types.Edge) error {
+	a, b := e.V1(), e.V2()
+	if !m.IsValidVertexID(a) || !m.IsValidVertexID(b) {
+		return ErrInvalidVertexID
+	}
+	if len(m.loopEdgeRefs(e)) > 0 {
+		return ErrConstrainedEdge
+	}
+
+	tris := m.trianglesWithEdge(e)
+	if len(tris) == 0 {
+		return ErrEdgeNotFound
+	}
+	if len(tris) != 2 {
+		return ErrNotFlippable
+	}
+
+	t1, t2 := m.triangles[tris[0]], m.triangles[tris[1]]
+	c, d := thirdVertex(t1, a, b), thirdVertex(t2, a, b)
+	if c == d {
+		return ErrNotFlippable
+	}
+	if _, exists := m.edgeSet[types.NewEdge(c, d)]; exists {
+		return ErrNotFlippable
+	}
+
+	// The quad is strictly convex iff each diagonal separates the other's endpoints.
+	pa, pb, pc, pd := m.vertices[a], m.vertices[b], m.vertices[c], m.vertices[d]
+	mode := m.cfg.mode()
+	if mode.Orient(pa, pb, pc)*mode.Orient(pa, pb, pd) != -1 ||
+		mode.Orient(pc, pd, pa)*mode.Orient(pc, pd, pb) != -1 {
+		return ErrNotFlippable
+	}
+
+	n1 := substituteVertex(t1, b, d)
+	n2 := substituteVertex(t2, a, c)
+	if m.triangleOrient(n1, types.NilVertex, types.Point{}) != m.triangleOrient(t1, types.NilVertex, types.Point{}) ||
+		m.triangleOrient(n2, types.NilVertex, types.Point{}) != m.triangleOrient(t2, types.NilVertex, types.Point{}) {
+		return ErrNotFlippable
+	}
+
+	return m.commitLocal([]JournalEffect{
+		{Kind: EffectSetTriangle, Index: tris[0], Triangle: &n1, PreviousTriangle: &t1},
+		{Kind: EffectSetTriangle, Index: tris[1], Triangle: &n2, PreviousTriangle: &t2},
+	})
+}
+
+// SplitEdge inserts a new vertex at p on edge e and splits every triangle
+// using e in two. If e lies on a perimeter or hole, the vertex is inserted
+// into that loop as well.
+//
+// p must lie on e, strictly between its endpoints. Returns the new vertex ID.
+func (m *Mesh) SplitEdge(e types.Edge, p types.Point) (types.VertexID, error) {
+	m.beginCommand(OpSplitEdge, []types.VertexID{e.V1(), e.V2()}, []types.Point{p})
+	id, err := m.splitEdge(e, p)
+	m.endCommand(err)
+	if m.cfg.debugSplitEdge != nil {
+		m.cfg.debugSplitEdge(e, p, id, err)
+	}
+	return id, err
+}
+
+func (m *Mesh) splitEdge(e types.Edge, p types.Point) (types.VertexID, error) {
+	a, b := e.V1(), e.V2()
+	if !m.IsValidVertexID(a) || !m.IsValidVertexID(b) {
+		return types.NilVertex, ErrInvalidVertexID
+	}
+	p = m.cfg.snapGrid.Snap(p)
+
+	pa, pb := m.vertices[a], m.vertices[b]
+	mode := m.cfg.mode()
+	da, db := mode.Distance(p, pa), mode.Distance(p, pb)
+	if !mode.PointOnSegment(p, pa, pb) ||
+		predicates.Dist2(p, pa) <= da*da || predicates.Dist2(p, pb) <= db*db {
+		return types.NilVertex, ErrPointNotOnEdge
+	}
+
+	tris := m.trianglesWithEdge(e)
+	refs := m.loopEdgeRefs(e)
+	if len(tris) == 0 && len(refs) == 0 {
+		return types.NilVertex, ErrEdgeNotFound
+	}
+
+	v := types.VertexID(len(m.vertices))
+	for _, idx := range tris {
+		tri := m.triangles[idx]
+		want := m.triangleOrient(tri, types.NilVertex, types.Point{})
+		if want == 0 ||
+			m.triangleOrient(substituteVertex(tri, b, v), v, p) != want ||
+			m.triangleOrient(substituteVertex(tri, a, v), v, p) != want {
+			return types.NilVertex, ErrInvertedTriangle
+		}
+	}
+
+	effects := []JournalEffect{{Kind: EffectAddVertex, Vertex: v, Point: &p}}
+	for i, idx := range tris {
+		old := m.triangles[idx]
+		first := substituteVertex(old, b, v)
+		second := substituteVertex(old, a, v)
+		effects = append(effects,
+			JournalEffect{Kind: EffectSetTriangle, Index: idx, Triangle: &first, PreviousTriangle: &old},
+			JournalEffect{Kind: EffectAddTriangle, Index: len(m.triangles) + i, Triangle: &second},
+		)
+	}
+	for _, ref := range refs {
+		old := ref.loop
+		loop := slices.Insert(slices.Clone(old), ref.pos+1, v)
+		effects = append(effects, JournalEffect{Kind: ref.setKind(), Index: ref.index, Loop: loop, PreviousLoop: old})
+	}
+
+	if err := m.commitLocal(effects); err != nil {
+		return types.NilVertex, err
+	}
+	return v, nil
+}
+
+// CollapseEdge merges the endpoints of e into a single vertex and removes the
+// triangles that used e.
+//
+// Interior edges collapse to their midpoint. If exactly one endpoint lies on a
+// perimeter or hole, the edge collapses onto that endpoint. An edge with both
+// endpoints on a boundary may only be collapsed if it is itself a boundary
+// edge, in which case it collapses onto its first endpoint and the loop loses
+// a vertex. The collapse is rejected if it would create non-manifold topology,
+// reduce a loop below three vertices, or invert a triangle.
+//
+// Removed triangles are replaced by the last triangles in the list, so
+// triangle indices at or above the lowest removed index may change.
+//
+// Returns the ID of the surviving vertex.
+func (m *Mesh) CollapseEdge(e types.Edge) (types.VertexID, error) {
+	m.beginCommand(OpCollapseEdge, []types.VertexID{e.V1(), e.V2()}, nil)
+	id, err := m.collapseEdge(e)
+	m.endCommand(err)
+	if m.cfg.debugCollapseEdge != nil {
+		m.cfg.debugCollapseEdge(e, id, err)
+	}
+	return id, err
+}
+
+func (m *Mesh) collapseEdge(e types.Edge) (types.VertexID, error) {
+	a, b := e.V1(), e.V2()
+	if !m.IsValidVertexID(a) || !m.IsValidVertexID(b) || a == b {
+		return types.NilVertex, ErrInvalidVertexID
+	}
+
+	tris := m.trianglesWithEdge(e)
+	refs := m.loopEdgeRefs(e)
+	if len(tris) == 0 && len(refs) == 0 {
+		return types.NilVertex, ErrEdgeNotFound
+	}
+
+	aBoundary, bBoundary := m.loopOccurrences(a) > 0, m.loopOccurrences(b) > 0
+	keep, drop := a, b
+	if bBoundary && !aBoundary {
+		keep, drop = b, a
+	}
+	target := m.vertices[keep]
+	switch {
+	case !aBoundary && !bBoundary:
+		pa, pb := m.vertices[a], m.vertices[b]
+		target = m.cfg.snapGrid.Snap(types.Point{X: (pa.X + pb.X) / 2, Y: (pa.Y + pb.Y) / 2})
+	case aBoundary && bBoundary:
+		// Collapsing a chord between two boundary vertices would pinch the domain.
+		if len(refs) == 0 || m.loopOccurrences(drop) != len(refs) {
+			return types.NilVertex, ErrInvalidCollapse
+		}
+	}
+	for _, ref := range refs {
+		if len(ref.loop) <= 3 {
+			return types.NilVertex, ErrInvalidCollapse
+		}
+	}
+
+	// Link condition: the only vertices adjacent to both endpoints are the
+	// apexes of the triangles being removed.
+	apexes := make(map[types.VertexID]struct{}, len(tris))
+	for _, idx := range tris {
+		apexes[thirdVertex(m.triangles[idx], a, b)] = struct{}{}
+	}
+	common := 0
+	keepNeighbors := m.vertexNeighbors(keep)
+	for n := range m.vertexNeighbors(drop) {
+		if _, ok := keepNeighbors[n]; ok {
+			if _, ok := apexes[n]; !ok {
+				return types.NilVertex, ErrInvalidCollapse
+			}
+			common++
+		}
+	}
+	if common != len(apexes) {
+		return types.NilVertex, ErrInvalidCollapse
+	}
+
+	// Every surviving triangle around either endpoint must keep its orientation.
+	// The incidence lists are copied: committing effects invalidates them.
+	around := append(slices.Clone(m.incidentTriangles(keep)), m.incidentTriangles(drop)...)
+	var rewired []int
+	for _, idx := range around {
+		if slices.Contains(tris, idx) {
+			continue
+		}
+		tri := m.triangles[idx]
+		want := m.triangleOrient(tri, types.NilVertex, types.Point{})
+		moved := tri
+		if triangleHas(tri, drop) {
+			moved = substituteVertex(tri, drop, keep)
+			rewired = append(rewired, idx)
+		}
+		if want == 0 || m.triangleOrient(moved, keep, target) != want {
+			return types.NilVertex, ErrInvertedTriangle
+		}
+	}
+
+	var effects []JournalEffect
+	for _, idx := range rewired {
+		old := m.triangles[idx]
+		tri := substituteVertex(old, drop, keep)
+		effects = append(effects, JournalEffect{Kind: EffectSetTriangle, Index: idx, Triangle: &tri, PreviousTriangle: &old})
+	}
+	// Removing in descending order keeps the remaining slots in place.
+	slices.Sort(tris)
+	for i := len(tris) - 1; i >= 0; i-- {
+		old := m.triangles[tris[i]]
+		effects = append(effects, JournalEffect{Kind: EffectRemoveTriangle, Index: tris[i], Triangle: &old})
+	}
+	if prev := m.vertices[keep]; prev != target {
+		effects = append(effects, JournalEffect{Kind: EffectMoveVertex, Vertex: keep, Point: &target, PreviousPoint: &prev})
+	}
+	for _, ref := range refs {
+		old := ref.loop
+		loop := slices.DeleteFunc(slices.Clone(old), func(v types.VertexID) bool { return v == drop })
+		effects = append(effects, JournalEffect{Kind: ref.setKind(), Index: ref.index, Loop: loop, PreviousLoop: old})
+	}
+	effects = append(effects, JournalEffect{Kind: EffectRemoveVertex, Vertex: drop})
+
+	if err := m.commitLocal(effects); err != nil {
+		return types.NilVertex, err
+	}
+	return keep, nil
+}
+
+// SplitTriangle inserts a new vertex at p, which must lie strictly inside the
+// triangle at idx, and replaces the triangle with three triangles fanning
+// around it. Returns the new vertex ID.
+func (m *Mesh) SplitTriangle(idx int, p types.Point) (types.VertexID, error) {
+	m.beginCommand(OpSplitTriangle, nil, []types.Point{p})
+	m.setCommandIndex(idx)
+	id, err := m.splitTriangle(idx, p)
+	m.endCommand(err)
+	if m.cfg.debugSplitTriangle != nil {
+		m.cfg.debugSplitTriangle(idx, p, id, err)
+	}
+	return id, err
+}
+
+func (m *Mesh) splitTriangle(idx int, p types.Point) (types.VertexID, error) {
+	if idx < 0 || idx >= len(m.triangles) {
+		return types.NilVertex, ErrInvalidTriangleIndex
+	}
+	p = m.cfg.snapGrid.Snap(p)
+
+	tri := m.triangles[idx]
+	a, b, c := m.GetTriangleCoords(idx)
+	if !m.cfg.mode().PointStrictlyInTriangle(p, a, b, c) {
+		return types.NilVertex, ErrPointNotInTriangle
+	}
+
+	v := types.VertexID(len(m.vertices))
+	fan := [3]types.Triangle{
+		types.NewTriangle(tri.V1(), tri.V2(), v),
+		types.NewTriangle(tri.V2(), tri.V3(), v),
+		types.NewTriangle(tri.V3(), tri.V1(), v),
+	}
+	want := m.triangleOrient(tri, types.NilVertex, types.Point{})
+	for _, t := range fan {
+		if m.triangleOrient(t, v, p) != want {
+			return types.NilVertex, ErrInvertedTriangle
+		}
+	}
+
+	effects := []JournalEffect{
+		{Kind: EffectAddVertex, Vertex: v, Point: &p},
+		{Kind: EffectSetTriangle, Index: idx, Triangle: &fan[0], PreviousTriangle: &tri},
+		{Kind: EffectAddTriangle, Index: len(m.triangles), Triangle: &fan[1]},
+		{Kind: EffectAddTriangle, Index: len(m.triangles) + 1, Triangle: &fan[2]},
+	}
+	if err := m.commitLocal(effects); err != nil {
+		return types.NilVertex, err
+	}
+	return v, nil
+}
+
+// commitLocal applies the effects of a local operator and then validates
+// every triangle they create against the rest of the resulting mesh, the
+// same way AddTriangle would. If any triangle is rejected the effects are
+// reverted and nothing is recorded.
+func (m *Mesh) commitLocal(effects []JournalEffect) error {
+	for _, e := range effects {
+		m.applyEffect(e)
+	}
+	for _, e := range effects {
+		if e.Kind != EffectSetTriangle && e.Kind != EffectAddTriangle {
+			continue
+		}
+		if err := m.validateInPlace(*e.Triangle); err != nil {
+			for i := len(effects) - 1; i >= 0; i-- {
+				m.revertEffect(effects[i])
+			}
+			return err
+		}
+	}
+	for _, e := range effects {
+		m.recordEffect(e)
+	}
+	return nil
+}
+
+// validateInPlace validates tri, which is already in the mesh, as if it were
+// being added to the mesh without it.
+func (m *Mesh) validateInPlace(tri types.Triangle) error {
+	incident := m.incidentTriangles(tri.V1())
+	k := slices.IndexFunc(incident, func(j int) bool { return m.triangles[j] == tri })
+	idx := incident[k]
+
+	m.removeTriangleAt(idx)
+	err := m.validateNewTriangle(tri)
+	m.insertTriangleAt(idx, tri)
+	return err
+}
+
+// loopEdgeRef locates an edge within a perimeter or hole loop.
+type loopEdgeRef struct {
+	hole  bool
+	index int
+	pos   int // the edge runs from loop[pos] to loop[pos+1] (wrapping)
+	loop  types.PolygonLoop
+}
+
+func (r loopEdgeRef) setKind() EffectKind {
+	if r.hole {
+		return EffectSetHole
+	}
+	return EffectSetPerimeter
+}
+
+// loopEdgeRefs returns every occurrence of e in the perimeter and hole loops.
+func (m *Mesh) loopEdgeRefs(e types.Edge) []loopEdgeRef {
+	var refs []loopEdgeRef
+	scan := func(loops []types.PolygonLoop, hole bool) {
+		for li, loop := range loops {
+			for i := range loop {
+				if types.NewEdge(loop[i], loop[(i+1)%len(loop)]) == e {
+					refs = append(refs, loopEdgeRef{hole: hole, index: li, pos: i, loop: loop})
+				}
+			}
+		}
+	}
+	scan(m.perimeters, false)
+	scan(m.holes, true)
+	return refs
+}
+
+// loopOccurrences counts how often v appears in perimeter and hole loops.
+func (m *Mesh) loopOccurrences(v types.VertexID) int {
+	n := 0
+	for _, loops := range [][]types.PolygonLoop{m.perimeters, m.holes} {
+		for _, loop := range loops {
+			for _, id := range loop {
+				if id == v {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// trianglesWithEdge returns the indices of triangles that use e.
+func (m *Mesh) trianglesWithEdge(e types.Edge) []int {
+	var out []int
+	for _, idx := range m.incidentTriangles(e.V1()) {
+		if triangleHas(m.triangles[idx], e.V2()) {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
+
+// vertexNeighbors returns the vertices connected to v by a triangle edge.
+func (m *Mesh) vertexNeighbors(v types.VertexID) map[types.VertexID]struct{} {
+	out := make(map[types.VertexID]struct{})
+	for _, idx := range m.incidentTriangles(v) {
+		for _, id := range m.triangles[idx] {
+			if id != v {
+				out[id] = struct{}{}
+			}
+		}
+	}
+	return out
+}
+
+// triangleOrient returns the orientation of tri, using p as the position of
+// vertex override (which may not exist yet) when override is not NilVertex.
+func (m *Mesh) triangleOrient(tri types.Triangle, override types.VertexID, p types.Point) int {
+	var pts [3]types.Point
+	for i, id := range tri {
+		if id == override {
+			pts[i] = p
+		} else {
+			pts[i] = m.vertices[id]
+		}
+	}
+	return m.cfg.mode().Orient(pts[0], pts[1], pts[2])
+}
+
+func triangleHas(tri types.Triangle, v types.VertexID) bool {
+	return tri[0] == v || tri[1] == v || tri[2] == v
+}
+
+// thirdVertex returns the vertex of tri that is neither a nor b.
+func thirdVertex(tri types.Triangle, a, b types.VertexID) types.VertexID {
+	for _, id := range tri {
+		if id != a && id != b {
+			return id
+		}
+	}
+	return types.NilVertex
+}
+
+// substituteVertex replaces from with to, preserving vertex order.
+func substituteVertex(tri types.Triangle, from, to types.VertexID) types.Triangle {
+	for i := range tri {
+		if tri[i] == from {
+			tri[i] = to
+		}
+	}
+	return tri
+}
@@ -0,0 +1,441 @@
+package mesh
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+	"github.com/iceisfun/gomesh/validation"
+)
+
+// buildFan creates a square perimeter with a center vertex and four triangles.
+func buildFan(t *testing.T, opts ...Option) (*Mesh, types.VertexID) {
+	t.Helper()
+	m := NewMesh(opts...)
+	if _, err := m.AddPerimeter([]types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}); err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	c, _ := m.AddVertex(types.Point{X: 5, Y: 5})
+	for i := 0; i < 4; i++ {
+		if err := m.AddTriangle(types.VertexID(i), types.VertexID((i+1)%4), c); err != nil {
+			t.Fatalf("AddTriangle failed: %v", err)
+		}
+	}
+	return m, c
+}
+
+func countOrientation(m *Mesh, sign int) int {
+	n := 0
+	for _, tri := range m.triangles {
+		if m.triangleOrient(tri, types.NilVertex, types.Point{}) == sign {
+			n++
+		}
+	}
+	return n
+}
+
+func TestFlipEdge(t *testing.T) {
+	m := NewMesh()
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 10, Y: 0})
+	c, _ := m.AddVertex(types.Point{X: 10, Y: 10})
+	d, _ := m.AddVertex(types.Point{X: 0, Y: 10})
+	_ = m.AddTriangle(a, b, c)
+	_ = m.AddTriangle(a, c, d)
+
+	if err := m.FlipEdge(types.NewEdge(a, c)); err != nil {
+		t.Fatalf("FlipEdge failed: %v", err)
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(b, d)]; !ok {
+		t.Fatalf("expected flipped diagonal")
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(a, c)]; ok {
+		t.Fatalf("expected old diagonal to be removed")
+	}
+	if countOrientation(m, 1) != 2 {
+		t.Fatalf("expected both triangles to stay counter-clockwise")
+	}
+
+	if err := m.FlipEdge(types.NewEdge(a, b)); err != ErrNotFlippable {
+		t.Fatalf("expected ErrNotFlippable for a hull edge, got %v", err)
+	}
+}
+
+func TestFlipEdgeRejectsConcaveQuad(t *testing.T) {
+	m := NewMesh()
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 10, Y: 0})
+	c, _ := m.AddVertex(types.Point{X: 2, Y: 2})
+	d, _ := m.AddVertex(types.Point{X: 0, Y: 10})
+	_ = m.AddTriangle(a, b, c)
+	_ = m.AddTriangle(a, c, d)
+
+	if err := m.FlipEdge(types.NewEdge(a, c)); err != ErrNotFlippable {
+		t.Fatalf("expected ErrNotFlippable, got %v", err)
+	}
+}
+
+func TestFlipEdgeRejectsBoundary(t *testing.T) {
+	m, _ := buildFan(t)
+	if err := m.FlipEdge(types.NewEdge(0, 1)); err != ErrConstrainedEdge {
+		t.Fatalf("expected ErrConstrainedEdge, got %v", err)
+	}
+}
+
+func TestSplitBoundaryEdgeUpdatesLoop(t *testing.T) {
+	m, _ := buildFan(t)
+	v, err := m.SplitEdge(types.NewEdge(0, 1), types.Point{X: 4, Y: 0})
+	if err != nil {
+		t.Fatalf("SplitEdge failed: %v", err)
+	}
+	if m.NumTriangles() != 5 {
+		t.Fatalf("expected 5 triangles, got %d", m.NumTriangles())
+	}
+	want := types.PolygonLoop{0, v, 1, 2, 3}
+	got := m.Perimeters()[0]
+	if len(got) != len(want) {
+		t.Fatalf("expected loop %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected loop %v, got %v", want, got)
+		}
+	}
+	if countOrientation(m, 1) != 5 {
+		t.Fatalf("expected all triangles to stay counter-clockwise")
+	}
+
+	if _, err := m.SplitEdge(types.NewEdge(1, 2), types.Point{X: 11, Y: 5}); err != ErrPointNotOnEdge {
+		t.Fatalf("expected ErrPointNotOnEdge, got %v", err)
+	}
+}
+
+func TestSplitInteriorEdge(t *testing.T) {
+	m, c := buildFan(t)
+	if _, err := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5}); err != nil {
+		t.Fatalf("SplitEdge failed: %v", err)
+	}
+	if m.NumTriangles() != 6 {
+		t.Fatalf("expected 6 triangles, got %d", m.NumTriangles())
+	}
+	if len(m.Perimeters()[0]) != 4 {
+		t.Fatalf("interior split must not change the perimeter")
+	}
+}
+
+func TestSplitTriangle(t *testing.T) {
+	m, c := buildFan(t)
+	v, err := m.SplitTriangle(0, types.Point{X: 5, Y: 2})
+	if err != nil {
+		t.Fatalf("SplitTriangle failed: %v", err)
+	}
+	if m.NumTriangles() != 6 {
+		t.Fatalf("expected 6 triangles, got %d", m.NumTriangles())
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(v, c)]; !ok {
+		t.Fatalf("expected edge from new vertex to fan center")
+	}
+	if countOrientation(m, 1) != 6 {
+		t.Fatalf("expected all triangles to stay counter-clockwise")
+	}
+	if _, err := m.SplitTriangle(0, types.Point{X: 50, Y: 50}); err != ErrPointNotInTriangle {
+		t.Fatalf("expected ErrPointNotInTriangle, got %v", err)
+	}
+}
+
+func TestCollapseInteriorEdge(t *testing.T) {
+	m, c := buildFan(t)
+	v, _ := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5})
+
+	keep, err := m.CollapseEdge(types.NewEdge(v, c))
+	if err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+	if m.NumTriangles() != 4 {
+		t.Fatalf("expected 4 triangles, got %d", m.NumTriangles())
+	}
+	if m.GetVertex(keep) != (types.Point{X: 3.75, Y: 3.75}) {
+		t.Fatalf("expected collapse to midpoint, got %v", m.GetVertex(keep))
+	}
+	if countOrientation(m, 1) != 4 {
+		t.Fatalf("expected all triangles to stay counter-clockwise")
+	}
+}
+
+func TestCollapseRemovesVertexFromMergeIndex(t *testing.T) {
+	m, c := buildFan(t, WithMergeVertices(true), WithJournal(true))
+	v, _ := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5})
+
+	keep, err := m.CollapseEdge(types.NewEdge(v, c))
+	if err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+	drop := v
+	if keep == v {
+		drop = c
+	}
+	at := m.GetVertex(drop)
+
+	if id, ok := m.FindVertexNear(at); ok {
+		t.Fatalf("expected no vertex near the dropped one, found %d", id)
+	}
+	id, err := m.AddVertex(at)
+	if err != nil {
+		t.Fatalf("AddVertex failed: %v", err)
+	}
+	if id == drop {
+		t.Fatalf("AddVertex merged with dropped vertex %d", drop)
+	}
+
+	// Undoing the collapse brings the vertex back into the index.
+	if err := m.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := m.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if id, ok := m.FindVertexNear(at); !ok || id != drop {
+		t.Fatalf("expected vertex %d after undo, got %d, %v", drop, id, ok)
+	}
+}
+
+func TestCollapseInvalidatesVertex(t *testing.T) {
+	m, c := buildFan(t)
+	v, _ := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5})
+
+	// The corner triangle contains v strictly until v is collapsed away.
+	corner := types.NewTriangle(0, 1, 3)
+	cfg := validation.Config{ValidateVertexInside: true}
+	a, b, d := m.GetVertex(0), m.GetVertex(1), m.GetVertex(3)
+	if err := validation.ValidateTriangle(corner, a, b, d, cfg, m); err == nil {
+		t.Fatalf("expected vertex %d inside the corner triangle", v)
+	}
+
+	if keep, err := m.CollapseEdge(types.NewEdge(v, 0)); err != nil || keep != 0 {
+		t.Fatalf("CollapseEdge = %d, %v; want 0, nil", keep, err)
+	}
+	if m.IsValidVertexID(v) {
+		t.Fatalf("expected collapsed vertex %d to be invalid", v)
+	}
+	if err := m.MoveVertex(v, types.Point{X: 1, Y: 1}); err != ErrInvalidVertexID {
+		t.Fatalf("expected ErrInvalidVertexID, got %v", err)
+	}
+	if err := validation.ValidateTriangle(corner, a, b, d, cfg, m); err != nil {
+		t.Fatalf("collapsed vertex still checked: %v", err)
+	}
+}
+
+func TestCollapseBoundaryEdge(t *testing.T) {
+	m, _ := buildFan(t)
+	v, _ := m.SplitEdge(types.NewEdge(0, 1), types.Point{X: 4, Y: 0})
+
+	keep, err := m.CollapseEdge(types.NewEdge(v, 1))
+	if err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+	if keep != 1 || m.GetVertex(keep) != (types.Point{X: 10, Y: 0}) {
+		t.Fatalf("expected collapse onto first endpoint, got %d at %v", keep, m.GetVertex(keep))
+	}
+	if len(m.Perimeters()[0]) != 4 {
+		t.Fatalf("expected loop to lose a vertex, got %v", m.Perimeters()[0])
+	}
+
+}
+
+func TestCollapseKeepsLoopsValid(t *testing.T) {
+	m := NewMesh()
+	if _, err := m.AddPerimeter([]types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 10}}); err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	c, _ := m.AddVertex(types.Point{X: 5, Y: 3})
+	for i := 0; i < 3; i++ {
+		_ = m.AddTriangle(types.VertexID(i), types.VertexID((i+1)%3), c)
+	}
+
+	if _, err := m.CollapseEdge(types.NewEdge(0, 1)); err != ErrInvalidCollapse {
+		t.Fatalf("expected ErrInvalidCollapse, got %v", err)
+	}
+}
+
+func TestCollapseRejectsInversion(t *testing.T) {
+	// An L-shaped perimeter fanned around an interior vertex near the
+	// inner corner. Collapsing onto the far corner would invert the
+	// triangles on the other side of the reflex vertex.
+	m := NewMesh()
+	if _, err := m.AddPerimeter([]types.Point{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 10}, {X: 0, Y: 10},
+	}); err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	c, _ := m.AddVertex(types.Point{X: 2, Y: 2})
+	for i := 0; i < 6; i++ {
+		if err := m.AddTriangle(types.VertexID(i), types.VertexID((i+1)%6), c); err != nil {
+			t.Fatalf("AddTriangle failed: %v", err)
+		}
+	}
+	before := m.Snapshot()
+
+	if _, err := m.CollapseEdge(types.NewEdge(1, c)); err != ErrInvertedTriangle {
+		t.Fatalf("expected ErrInvertedTriangle, got %v", err)
+	}
+	if !Diff(before, m.Snapshot()).IsEmpty() {
+		t.Fatalf("rejected collapse modified the mesh")
+	}
+}
+
+func TestFlipEdgeRejectsVertexInside(t *testing.T) {
+	m := NewMesh(WithTriangleEnforceNoVertexInside(true))
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 10, Y: 0})
+	c, _ := m.AddVertex(types.Point{X: 10, Y: 10})
+	d, _ := m.AddVertex(types.Point{X: 0, Y: 10})
+	_ = m.AddTriangle(a, b, c)
+	_ = m.AddTriangle(a, c, d)
+	if _, err := m.AddVertex(types.Point{X: 3, Y: 1}); err != nil {
+		t.Fatalf("AddVertex failed: %v", err)
+	}
+	before := m.Snapshot()
+
+	var inside VertexInsideTriangleError
+	if err := m.FlipEdge(types.NewEdge(a, c)); !errors.As(err, &inside) {
+		t.Fatalf("expected VertexInsideTriangleError, got %v", err)
+	}
+	if !Diff(before, m.Snapshot()).IsEmpty() {
+		t.Fatalf("rejected flip modified the mesh")
+	}
+	checkIndex(t, m)
+}
+
+func TestEdgeOpsRunValidators(t *testing.T) {
+	reject := false
+	m, c := buildFan(t, WithJournal(true), WithTriangleValidator("toggle", func(types.Triangle, types.Point, types.Point, types.Point, MeshView) error {
+		if reject {
+			return errors.New("rejected")
+		}
+		return nil
+	}))
+	v, err := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5})
+	if err != nil {
+		t.Fatalf("SplitEdge failed: %v", err)
+	}
+	before := m.Snapshot()
+	recorded, _ := m.Journal()
+	reject = true
+
+	if _, err := m.SplitEdge(types.NewEdge(1, c), types.Point{X: 7.5, Y: 2.5}); !errors.Is(err, ErrTriangleRejected) {
+		t.Fatalf("SplitEdge: expected ErrTriangleRejected, got %v", err)
+	}
+	if _, err := m.CollapseEdge(types.NewEdge(v, 0)); !errors.Is(err, ErrTriangleRejected) {
+		t.Fatalf("CollapseEdge: expected ErrTriangleRejected, got %v", err)
+	}
+	if _, err := m.SplitTriangle(0, m.triangleCentroid(0)); !errors.Is(err, ErrTriangleRejected) {
+		t.Fatalf("SplitTriangle: expected ErrTriangleRejected, got %v", err)
+	}
+
+	if d := Diff(before, m.Snapshot()); !d.IsEmpty() {
+		t.Fatalf("rejected operations modified the mesh: %+v", d)
+	}
+	if !m.IsValidVertexID(v) || len(m.Perimeters()[0]) != 4 {
+		t.Fatalf("rejected operations modified vertices or loops")
+	}
+	checkIndex(t, m)
+
+	if data, _ := m.Journal(); len(data.Commands) != len(recorded.Commands) {
+		t.Fatalf("expected rejected operations to leave no journal entries")
+	}
+}
+
+func TestEdgeOpsUndo(t *testing.T) {
+	m, c := buildFan(t, WithJournal(true))
+	before := m.Snapshot()
+	_ = m.Checkpoint("fan")
+
+	v, _ := m.SplitEdge(types.NewEdge(0, 1), types.Point{X: 4, Y: 0})
+	if _, err := m.CollapseEdge(types.NewEdge(v, c)); err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+	if _, err := m.SplitTriangle(0, types.Point{X: 6, Y: 8}); err != nil && err != ErrPointNotInTriangle {
+		t.Fatalf("SplitTriangle failed: %v", err)
+	}
+	checkIndex(t, m)
+
+	if err := m.RestoreCheckpoint("fan"); err != nil {
+		t.Fatalf("RestoreCheckpoint failed: %v", err)
+	}
+	checkIndex(t, m)
+	if !Diff(before, m.Snapshot()).IsEmpty() {
+		t.Fatalf("expected undo to restore the original mesh, diff %+v", Diff(before, m.Snapshot()))
+	}
+	if len(m.Perimeters()[0]) != 4 {
+		t.Fatalf("expected original perimeter, got %v", m.Perimeters()[0])
+	}
+}
+
+func TestEdgeOpsReplay(t *testing.T) {
+	m := NewMesh(WithJournal(true))
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 10, Y: 0})
+	c, _ := m.AddVertex(types.Point{X: 10, Y: 10})
+	d, _ := m.AddVertex(types.Point{X: 0, Y: 10})
+	_ = m.AddTriangle(a, b, c)
+	_ = m.AddTriangle(a, c, d)
+
+	if err := m.FlipEdge(types.NewEdge(a, c)); err != nil {
+		t.Fatalf("FlipEdge failed: %v", err)
+	}
+	v, err := m.SplitEdge(types.NewEdge(b, d), types.Point{X: 5, Y: 5})
+	if err != nil {
+		t.Fatalf("SplitEdge failed: %v", err)
+	}
+	if _, err := m.SplitTriangle(0, m.triangleCentroid(0)); err != nil {
+		t.Fatalf("SplitTriangle failed: %v", err)
+	}
+	if _, err := m.CollapseEdge(types.NewEdge(v, types.VertexID(m.NumVertices()-1))); err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+	data, _ := m.Journal()
+	r, err := data.Replay()
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !Diff(m.Snapshot(), r.Snapshot()).IsEmpty() {
+		t.Fatalf("replayed mesh differs")
+	}
+}
+
+// checkIndex compares the incrementally maintained lookup state with a
+// rebuild from the triangle list.
+func checkIndex(t *testing.T, m *Mesh) {
+	t.Helper()
+	edges := make(map[types.Edge]int)
+	keys := make(map[[3]types.VertexID]int)
+	incidence := make([][]int, len(m.vertices))
+	for i, tri := range m.triangles {
+		for _, e := range tri.Edges() {
+			edges[e]++
+		}
+		keys[validation.CanonicalTriangleKey(tri)]++
+		for _, v := range tri {
+			incidence[v] = append(incidence[v], i)
+		}
+	}
+	if !maps.Equal(edges, m.edgeUses) || len(m.edgeSet) != len(edges) {
+		t.Fatalf("edge counts %v, want %v", m.edgeUses, edges)
+	}
+	if !maps.Equal(keys, m.triangleUses) || len(m.triangleSet) != len(keys) {
+		t.Fatalf("triangle counts %v, want %v", m.triangleUses, keys)
+	}
+	for v := range incidence {
+		got := slices.Sorted(slices.Values(m.incidentTriangles(types.VertexID(v))))
+		if !slices.Equal(got, incidence[v]) {
+			t.Fatalf("incidence of %d is %v, want %v", v, got, incidence[v])
+		}
+	}
+}
+
+func (m *Mesh) triangleCentroid(idx int) types.Point {
+	a, b, c := m.GetTriangleCoords(idx)
+	return types.Point{X: (a.X + b.X + c.X) / 3, Y: (a.Y + b.Y + c.Y) / 3}
+}
@@ -3,6 +3,8 @@ package mesh
 import (
 	"errors"
 	"fmt"
+
+	"github.com/iceisfun/gomesh/types"
 )
 
 var (
@@ -29,6 +31,51 @@ var (
 
 	// ErrEdgeCrossesPerimeter indicates a triangle edge would cross a perimeter or hole boundary.
 	ErrEdgeCrossesPerimeter = errors.New("gomesh: edge crosses perimeter or hole boundary")
+
+	// ErrPolygonSelfIntersection indicates a perimeter or hole loop crosses itself.
+	ErrPolygonSelfIntersection = errors.New("gomesh: polygon self-intersects")
+
+	// ErrHoleOutsidePerimeter indicates a hole does not lie inside any perimeter.
+	ErrHoleOutsidePerimeter = errors.New("gomesh: hole must be inside a perimeter")
+
+	// ErrHoleInMultiplePerimeters indicates a hole lies inside more than one perimeter.
+	ErrHoleInMultiplePerimeters = errors.New("gomesh: hole is inside multiple perimeters (ambiguous)")
+
+	// ErrEdgeNotFound indicates an edge is not used by any triangle, perimeter or hole.
+	ErrEdgeNotFound = errors.New("gomesh: edge not found")
+
+	// ErrConstrainedEdge indicates an operation that would remove a perimeter or hole edge.
+	ErrConstrainedEdge = errors.New("gomesh: edge lies on a perimeter or hole")
+
+	// ErrNotFlippable indicates an edge is not shared by two triangles forming a strictly convex quad.
+	ErrNotFlippable = errors.New("gomesh: edge cannot be flipped")
+
+	// ErrPointNotOnEdge indicates a split point does not lie strictly inside the edge.
+	ErrPointNotOnEdge = errors.New("gomesh: point does not lie on edge")
+
+	// ErrPointNotInTriangle indicates a split point does not lie strictly inside the triangle.
+	ErrPointNotInTriangle = errors.New("gomesh: point does not lie inside triangle")
+
+	// ErrInvalidCollapse indicates an edge collapse would produce invalid topology.
+	ErrInvalidCollapse = errors.New("gomesh: edge collapse would produce invalid topology")
+
+	// ErrInvertedTriangle indicates an operation would invert or degenerate a triangle.
+	ErrInvertedTriangle = errors.New("gomesh: operation would invert or degenerate a triangle")
+
+	// ErrJournalDisabled indicates a journal operation on a mesh created without WithJournal.
+	ErrJournalDisabled = errors.New("gomesh: journal not enabled")
+
+	// ErrNothingToUndo indicates Undo was called with no recorded commands.
+	ErrNothingToUndo = errors.New("gomesh: nothing to undo")
+
+	// ErrNothingToRedo indicates Redo was called with no undone commands.
+	ErrNothingToRedo = errors.New("gomesh: nothing to redo")
+
+	// ErrUnknownCheckpoint indicates a checkpoint label that was never recorded.
+	ErrUnknownCheckpoint = errors.New("gomesh: unknown checkpoint")
+
+	// ErrReplayDiverged indicates a replayed journal produced different results than recorded.
+	ErrReplayDiverged = errors.New("gomesh: journal replay diverged")
 )
 
 // ErrTriangleOverlap indicates a triangle would overlap with an existing triangle.
@@ -42,3 +89,164 @@ func (e ErrTriangleOverlap) Error() string {
 	return fmt.Sprintf("gomesh: triangle overlaps with existing triangle #%d (intersection area: %.4f)",
 		e.TriangleIndex, e.IntersectionArea)
 }
+
+// VertexInsideTriangleError reports the existing vertex that lies strictly
+// inside a rejected triangle. It matches ErrVertexInsideTriangle with
+// errors.Is.
+type VertexInsideTriangleError struct {
+	Triangle types.Triangle
+	Vertex   types.VertexID
+	Point    types.Point
+}
+
+func (e VertexInsideTriangleError) Error() string {
+	return fmt.Sprintf("gomesh: vertex %d at (%g, %g) lies inside triangle %v",
+		e.Vertex, e.Point.X, e.Point.Y, e.Triangle)
+}
+
+// Unwrap returns ErrVertexInsideTriangle.
+func (e VertexInsideTriangleError) Unwrap() error {
+	return ErrVertexInsideTriangle
+}
+
+// EdgeIntersectionError reports the existing mesh edge that an edge of a
+// rejected triangle crosses or overlaps. It matches ErrEdgeIntersection with
+// errors.Is.
+//
+// Point is the crossing point, or the middle of the shared stretch for
+// collinear overlaps. When Existing equals Edge, the edge already belongs to
+// two triangles.
+type EdgeIntersectionError struct {
+	Triangle types.Triangle
+	Edge     types.Edge
+	Existing types.Edge
+	Point    types.Point
+	Kind     types.IntersectionType
+}
+
+func (e EdgeIntersectionError) Error() string {
+	if e.Existing == e.Edge {
+		return fmt.Sprintf("gomesh: edge %d-%d of triangle %v already has two triangles",
+			e.Edge.V1(), e.Edge.V2(), e.Triangle)
+	}
+	return fmt.Sprintf("gomesh: edge %d-%d of triangle %v intersects existing edge %d-%d at (%g, %g)",
+		e.Edge.V1(), e.Edge.V2(), e.Triangle, e.Existing.V1(), e.Existing.V2(), e.Point.X, e.Point.Y)
+}
+
+// Unwrap returns ErrEdgeIntersection.
+func (e EdgeIntersectionError) Unwrap() error {
+	return ErrEdgeIntersection
+}
+
+// EdgeCrossesPerimeterError reports where a rejected triangle leaves the
+// meshable domain. It matches ErrEdgeCrossesPerimeter with errors.Is.
+//
+// When a triangle edge crosses a boundary edge, Boundary is that edge, Loop
+// indexes GetPerimeters or, if Hole is set, GetHoles, and Point is the
+// crossing point. Otherwise Boundary is {NilVertex, NilVertex} and Point is
+// the sample that fell outside: the midpoint of Edge, or the triangle
+// centroid when Edge is also {NilVertex, NilVertex}. Such a sample lies in
+// hole Loop when Hole is set, or outside every perimeter with Loop -1.
+type EdgeCrossesPerimeterError struct {
+	Triangle types.Triangle
+	Edge     types.Edge
+	Boundary types.Edge
+	Hole     bool
+	Loop     int
+	Point    types.Point
+}
+
+func (e EdgeCrossesPerimeterError) Error() string {
+	what := fmt.Sprintf("triangle %v", e.Triangle)
+	if e.Edge.V1() != types.NilVertex {
+		what = fmt.Sprintf("edge %d-%d of triangle %v", e.Edge.V1(), e.Edge.V2(), e.Triangle)
+	}
+	loop := fmt.Sprintf("perimeter %d", e.Loop)
+	if e.Hole {
+		loop = fmt.Sprintf("hole %d", e.Loop)
+	}
+
+	switch {
+	case e.Boundary.V1() != types.NilVertex:
+		return fmt.Sprintf("gomesh: %s crosses %s edge %d-%d at (%g, %g)",
+			what, loop, e.Boundary.V1(), e.Boundary.V2(), e.Point.X, e.Point.Y)
+	case e.Hole:
+		return fmt.Sprintf("gomesh: %s passes through %s at (%g, %g)", what, loop, e.Point.X, e.Point.Y)
+	default:
+		return fmt.Sprintf("gomesh: %s lies outside every perimeter at (%g, %g)", what, e.Point.X, e.Point.Y)
+	}
+}
+
+// Unwrap returns ErrEdgeCrossesPerimeter.
+func (e EdgeCrossesPerimeterError) Unwrap() error {
+	return ErrEdgeCrossesPerimeter
+}
+
+// SelfIntersectionError reports two crossing edges of a perimeter or hole
+// loop. It matches ErrPolygonSelfIntersection with errors.Is.
+//
+// Positions holds the index of each edge within the loop, where edge i runs
+// from point i to point i+1.
+type SelfIntersectionError struct {
+	Edges     [2]types.Edge
+	Positions [2]int
+	Point     types.Point
+}
+
+func (e SelfIntersectionError) Error() string {
+	return fmt.Sprintf("polygon self-intersects: edge %d (%d-%d) crosses edge %d (%d-%d) at (%g, %g)",
+		e.Positions[0], e.Edges[0].V1(), e.Edges[0].V2(),
+		e.Positions[1], e.Edges[1].V1(), e.Edges[1].V2(), e.Point.X, e.Point.Y)
+}
+
+// Unwrap returns ErrPolygonSelfIntersection.
+func (e SelfIntersectionError) Unwrap() error {
+	return ErrPolygonSelfIntersection
+}
+
+// HoleOutsidePerimeterError reports a hole vertex that keeps a hole from
+// lying inside a perimeter. It matches ErrHoleOutsidePerimeter with
+// errors.Is.
+//
+// Perimeter is the perimeter containing the most hole vertices and Vertex
+// the first hole vertex outside it. Perimeter is -1 when no perimeter
+// contains any hole vertex, and Vertex is NilVertex when the mesh has no
+// perimeters at all.
+type HoleOutsidePerimeterError struct {
+	Perimeter int
+	Vertex    types.VertexID
+	Point     types.Point
+}
+
+func (e HoleOutsidePerimeterError) Error() string {
+	switch {
+	case e.Vertex == types.NilVertex:
+		return "gomesh: cannot add hole without a perimeter"
+	case e.Perimeter < 0:
+		return fmt.Sprintf("gomesh: hole must be inside a perimeter: vertex %d at (%g, %g) is outside every perimeter",
+			e.Vertex, e.Point.X, e.Point.Y)
+	default:
+		return fmt.Sprintf("gomesh: hole must be inside a perimeter: vertex %d at (%g, %g) is outside perimeter %d",
+			e.Vertex, e.Point.X, e.Point.Y, e.Perimeter)
+	}
+}
+
+// Unwrap returns ErrHoleOutsidePerimeter.
+func (e HoleOutsidePerimeterError) Unwrap() error {
+	return ErrHoleOutsidePerimeter
+}
+
+// HoleInMultiplePerimetersError lists the perimeters that each contain a
+// whole hole. It matches ErrHoleInMultiplePerimeters with errors.Is.
+type HoleInMultiplePerimetersError struct {
+	Perimeters []int
+}
+
+func (e HoleInMultiplePerimetersError) Error() string {
+	return fmt.Sprintf("gomesh: hole is inside multiple perimeters (ambiguous): %v", e.Perimeters)
+}
+
+// Unwrap returns ErrHoleInMultiplePerimeters.
+func (e HoleInMultiplePerimetersError) Unwrap() error {
+	return ErrHoleInMultiplePerimeters
+}
@@ -0,0 +1,545 @@
+package mesh
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+// JournalOp names the public Mesh method recorded by a journal command.
+type JournalOp string
+
+const (
+	OpAddVertex     JournalOp = "add_vertex"
+	OpAddTriangle   JournalOp = "add_triangle"
+	OpAddPerimeter  JournalOp = "add_perimeter"
+	OpAddHole       JournalOp = "add_hole"
+	OpFlipEdge      JournalOp = "flip_edge"
+	OpSplitEdge     JournalOp = "split_edge"
+	OpCollapseEdge  JournalOp = "collapse_edge"
+	OpSplitTriangle JournalOp = "split_triangle"
+	OpMoveVertex    JournalOp = "move_vertex"
+)
+
+// EffectKind names a primitive, reversible change to mesh state.
+type EffectKind string
+
+const (
+	EffectAddVertex      EffectKind = "add_vertex"
+	EffectAddTriangle    EffectKind = "add_triangle"
+	EffectAddPerimeter   EffectKind = "add_perimeter"
+	EffectAddHole        EffectKind = "add_hole"
+	EffectSetTriangle    EffectKind = "set_triangle"
+	EffectRemoveTriangle EffectKind = "remove_triangle"
+	EffectSetPerimeter   EffectKind = "set_perimeter"
+	EffectSetHole        EffectKind = "set_hole"
+	EffectMoveVertex     EffectKind = "move_vertex"
+	EffectRemoveVertex   EffectKind = "remove_vertex"
+)
+
+// JournalEffect is a single primitive change made by a command.
+//
+// Only the fields relevant to Kind are set.
+type JournalEffect struct {
+	Kind     EffectKind        `json:"kind"`
+	Vertex   types.VertexID    `json:"vertex,omitempty"`
+	Point    *types.Point      `json:"point,omitempty"`
+	Index    int               `json:"index,omitempty"`
+	Triangle *types.Triangle   `json:"triangle,omitempty"`
+	Loop     types.PolygonLoop `json:"loop,omitempty"`
+
+	// Previous values for effects that replace existing state.
+	PreviousPoint    *types.Point      `json:"previous_point,omitempty"`
+	PreviousTriangle *types.Triangle   `json:"previous_triangle,omitempty"`
+	PreviousLoop     types.PolygonLoop `json:"previous_loop,omitempty"`
+}
+
+// JournalCommand records one call to a public mutating Mesh method: its
+// arguments, the primitive effects it had, and the error it returned if it
+// failed after partially modifying the mesh.
+//
+// Calls that fail without modifying the mesh are not recorded.
+type JournalCommand struct {
+	Op       JournalOp        `json:"op"`
+	Vertices []types.VertexID `json:"vertices,omitempty"`
+	Points   []types.Point    `json:"points,omitempty"`
+	Index    int              `json:"index,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	Effects  []JournalEffect  `json:"effects"`
+}
+
+func (c JournalCommand) clone() JournalCommand {
+	out := c
+	out.Vertices = append([]types.VertexID(nil), c.Vertices...)
+	out.Points = append([]types.Point(nil), c.Points...)
+	out.Effects = make([]JournalEffect, len(c.Effects))
+	for i, e := range c.Effects {
+		if e.Point != nil {
+			p := *e.Point
+			e.Point = &p
+		}
+		if e.Triangle != nil {
+			tri := *e.Triangle
+			e.Triangle = &tri
+		}
+		if e.PreviousPoint != nil {
+			p := *e.PreviousPoint
+			e.PreviousPoint = &p
+		}
+		if e.PreviousTriangle != nil {
+			tri := *e.PreviousTriangle
+			e.PreviousTriangle = &tri
+		}
+		e.Loop = append(types.PolygonLoop(nil), e.Loop...)
+		e.PreviousLoop = append(types.PolygonLoop(nil), e.PreviousLoop...)
+		out.Effects[i] = e
+	}
+	return out
+}
+
+// JournalData is the serializable form of a mesh journal.
+//
+// Only commands that are currently applied are included; undone commands
+// waiting for Redo are dropped.
+type JournalData struct {
+	Config      SavedConfig      `json:"config"`
+	Commands    []JournalCommand `json:"commands"`
+	Checkpoints map[string]int   `json:"checkpoints,omitempty"`
+}
+
+// journal is the undo/redo log attached to a mesh created with WithJournal.
+type journal struct {
+	commands []JournalCommand
+	applied  int
+
+	checkpoints map[string]int
+
+	// pending collects effects for the outermost public call in progress.
+	pending *JournalCommand
+	depth   int
+}
+
+func newJournal() *journal {
+	return &journal{checkpoints: make(map[string]int)}
+}
+
+// clone copies the applied part of the journal. Commands are copied rather
+// than shared: after an Undo, recording a new command overwrites the undone
+// slot in place.
+func (j *journal) clone() *journal {
+	out := &journal{
+		commands:    make([]JournalCommand, j.applied),
+		applied:     j.applied,
+		checkpoints: make(map[string]int, len(j.checkpoints)),
+	}
+	for i, cmd := range j.commands[:j.applied] {
+		out.commands[i] = cmd.clone()
+	}
+	for label, pos := range j.checkpoints {
+		if pos <= j.applied {
+			out.checkpoints[label] = pos
+		}
+	}
+	return out
+}
+
+// beginCommand starts recording a public call. Nested calls (for example the
+// AddVertex calls made by AddPerimeter) are folded into the outermost one.
+func (m *Mesh) beginCommand(op JournalOp, vertices []types.VertexID, points []types.Point) {
+	j := m.journal
+	if j == nil {
+		return
+	}
+	j.depth++
+	if j.depth == 1 {
+		j.pending = &JournalCommand{
+			Op:       op,
+			Vertices: append([]types.VertexID(nil), vertices...),
+			Points:   append([]types.Point(nil), points...),
+		}
+	}
+}
+
+// setCommandIndex records an index argument for the outermost call in progress.
+func (m *Mesh) setCommandIndex(idx int) {
+	if m.journal != nil && m.journal.depth == 1 {
+		m.journal.pending.Index = idx
+	}
+}
+
+// endCommand finishes recording a public call. Commands without effects are
+// discarded; recording a new command drops any undone commands.
+func (m *Mesh) endCommand(err error) {
+	j := m.journal
+	if j == nil {
+		return
+	}
+	j.depth--
+	if j.depth > 0 {
+		return
+	}
+
+	cmd := j.pending
+	j.pending = nil
+	if cmd == nil || len(cmd.Effects) == 0 {
+		return
+	}
+	if err != nil {
+		cmd.Error = err.Error()
+	}
+
+	j.commands = append(j.commands[:j.applied], *cmd)
+	j.applied++
+	for label, pos := range j.checkpoints {
+		if pos >= j.applied {
+			delete(j.checkpoints, label)
+		}
+	}
+}
+
+// recordEffect appends a primitive effect to the command being recorded.
+func (m *Mesh) recordEffect(e JournalEffect) {
+	if m.journal == nil || m.journal.pending == nil {
+		return
+	}
+	m.journal.pending.Effects = append(m.journal.pending.Effects, e)
+}
+
+// CanUndo reports whether there is a recorded command to undo.
+func (m *Mesh) CanUndo() bool {
+	return m.journal != nil && m.journal.applied > 0
+}
+
+// CanRedo reports whether there is an undone command to redo.
+func (m *Mesh) CanRedo() bool {
+	return m.journal != nil && m.journal.applied < len(m.journal.commands)
+}
+
+// Undo reverts the most recent recorded command.
+//
+// Debug hooks are not called while undoing.
+func (m *Mesh) Undo() error {
+	if m.journal == nil {
+		return ErrJournalDisabled
+	}
+	if !m.CanUndo() {
+		return ErrNothingToUndo
+	}
+
+	j := m.journal
+	cmd := j.commands[j.applied-1]
+	for i := len(cmd.Effects) - 1; i >= 0; i-- {
+		m.revertEffect(cmd.Effects[i])
+	}
+	j.applied--
+	return nil
+}
+
+// Redo reapplies the most recently undone command.
+//
+// The command's effects are reapplied verbatim; validation is not repeated
+// and debug hooks are not called.
+func (m *Mesh) Redo() error {
+	if m.journal == nil {
+		return ErrJournalDisabled
+	}
+	if !m.CanRedo() {
+		return ErrNothingToRedo
+	}
+
+	j := m.journal
+	cmd := j.commands[j.applied]
+	for _, e := range cmd.Effects {
+		m.applyEffect(e)
+	}
+	j.applied++
+	return nil
+}
+
+// Checkpoint labels the current position in the journal.
+//
+// Reusing a label moves it to the current position.
+func (m *Mesh) Checkpoint(label string) error {
+	if m.journal == nil {
+		return ErrJournalDisabled
+	}
+	m.journal.checkpoints[label] = m.journal.applied
+	return nil
+}
+
+// RestoreCheckpoint undoes or redoes commands until the journal is back at
+// the position labelled by Checkpoint.
+func (m *Mesh) RestoreCheckpoint(label string) error {
+	if m.journal == nil {
+		return ErrJournalDisabled
+	}
+	pos, ok := m.journal.checkpoints[label]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCheckpoint, label)
+	}
+
+	for m.journal.applied > pos {
+		if err := m.Undo(); err != nil {
+			return err
+		}
+	}
+	for m.journal.applied < pos {
+		if err := m.Redo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Journal returns a serializable copy of the applied commands.
+func (m *Mesh) Journal() (*JournalData, error) {
+	if m.journal == nil {
+		return nil, ErrJournalDisabled
+	}
+
+	j := m.journal
+	data := &JournalData{
+		Config:   m.Config(),
+		Commands: make([]JournalCommand, j.applied),
+	}
+	for i, cmd := range j.commands[:j.applied] {
+		data.Commands[i] = cmd.clone()
+	}
+	for label, pos := range j.checkpoints {
+		if pos <= j.applied {
+			if data.Checkpoints == nil {
+				data.Checkpoints = make(map[string]int)
+			}
+			data.Checkpoints[label] = pos
+		}
+	}
+	return data, nil
+}
+
+// SaveJournal writes the applied commands to a JSON file.
+//
+// Attach the file to a bug report and use LoadJournal and Replay to
+// reproduce the exact sequence of edits.
+func (m *Mesh) SaveJournal(filename string) error {
+	data, err := m.Journal()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// LoadJournal reads a journal written by SaveJournal.
+func LoadJournal(filename string) (*JournalData, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var data JournalData
+	if err := json.NewDecoder(file).Decode(&data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Replay rebuilds a mesh by calling the recorded public methods in order.
+//
+// The new mesh uses the saved configuration plus opts and has journaling
+// enabled, so the replayed history can itself be undone. Replay stops with
+// ErrReplayDiverged if any command behaves differently than recorded; the
+// partially replayed mesh is returned alongside the error for inspection.
+func (d *JournalData) Replay(opts ...Option) (*Mesh, error) {
+	all := append(d.Config.Options(), WithJournal(true))
+	m := NewMesh(append(all, opts...)...)
+
+	for i, cmd := range d.Commands {
+		err := m.replayCommand(cmd)
+		if (err != nil) != (cmd.Error != "") {
+			return m, fmt.Errorf("%w: command %d (%s): got error %v, recorded %q",
+				ErrReplayDiverged, i, cmd.Op, err, cmd.Error)
+		}
+		if m.journal.applied != i+1 ||
+			!reflect.DeepEqual(m.journal.commands[i].Effects, cmd.Effects) {
+			return m, fmt.Errorf("%w: command %d (%s) had different effects",
+				ErrReplayDiverged, i, cmd.Op)
+		}
+	}
+
+	for label, pos := range d.Checkpoints {
+		if pos <= len(d.Commands) {
+			m.journal.checkpoints[label] = pos
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Mesh) replayCommand(cmd JournalCommand) error {
+	switch cmd.Op {
+	case OpAddVertex:
+		if len(cmd.Points) != 1 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		_, err := m.AddVertex(cmd.Points[0])
+		return err
+	case OpAddTriangle:
+		if len(cmd.Vertices) != 3 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		return m.AddTriangle(cmd.Vertices[0], cmd.Vertices[1], cmd.Vertices[2])
+	case OpAddPerimeter:
+		_, err := m.AddPerimeter(cmd.Points)
+		return err
+	case OpAddHole:
+		_, err := m.AddHole(cmd.Points)
+		return err
+	case OpMoveVertex:
+		if len(cmd.Vertices) != 1 || len(cmd.Points) != 1 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		return m.MoveVertex(cmd.Vertices[0], cmd.Points[0])
+	case OpFlipEdge:
+		if len(cmd.Vertices) != 2 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		return m.FlipEdge(types.NewEdge(cmd.Vertices[0], cmd.Vertices[1]))
+	case OpSplitEdge:
+		if len(cmd.Vertices) != 2 || len(cmd.Points) != 1 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		_, err := m.SplitEdge(types.NewEdge(cmd.Vertices[0], cmd.Vertices[1]), cmd.Points[0])
+		return err
+	case OpCollapseEdge:
+		if len(cmd.Vertices) != 2 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		_, err := m.CollapseEdge(types.NewEdge(cmd.Vertices[0], cmd.Vertices[1]))
+		return err
+	case OpSplitTriangle:
+		if len(cmd.Points) != 1 {
+			return fmt.Errorf("gomesh: malformed %s command", cmd.Op)
+		}
+		_, err := m.SplitTriangle(cmd.Index, cmd.Points[0])
+		return err
+	default:
+		return fmt.Errorf("gomesh: unknown journal op %q", cmd.Op)
+	}
+}
+
+// applyEffect reapplies a recorded effect without validation.
+func (m *Mesh) applyEffect(e JournalEffect) {
+	switch e.Kind {
+	case EffectAddVertex:
+		m.vertices = append(m.vertices, *e.Point)
+		if m.vertexIndex != nil {
+			m.vertexIndex.AddVertex(e.Vertex, *e.Point)
+		}
+	case EffectAddTriangle:
+		m.triangles = append(m.triangles, *e.Triangle)
+		m.indexTriangle(len(m.triangles)-1, *e.Triangle)
+	case EffectAddPerimeter:
+		m.perimeters = append(m.perimeters, e.Loop)
+	case EffectAddHole:
+		m.holes = append(m.holes, e.Loop)
+	case EffectSetTriangle:
+		m.replaceTriangle(e.Index, *e.PreviousTriangle, *e.Triangle)
+	case EffectRemoveTriangle:
+		m.removeTriangleAt(e.Index)
+	case EffectSetPerimeter:
+		m.ownLoops()
+		m.perimeters[e.Index] = e.Loop
+	case EffectSetHole:
+		m.ownLoops()
+		m.holes[e.Index] = e.Loop
+	case EffectMoveVertex:
+		m.setVertex(e.Vertex, *e.Point)
+	case EffectRemoveVertex:
+		m.ownRemoved()
+		m.removed[e.Vertex] = struct{}{}
+		m.vertexIndex = nil
+	}
+}
+
+// revertEffect undoes a recorded effect. Effects are always reverted in
+// reverse order, so appended elements are at the end of their slices.
+func (m *Mesh) revertEffect(e JournalEffect) {
+	switch e.Kind {
+	case EffectAddVertex:
+		m.vertices = truncate(m.vertices, len(m.vertices)-1, m.shared.vertices)
+		if len(m.incidence) > len(m.vertices) {
+			m.incidence = m.incidence[:len(m.vertices)]
+		}
+		// The spatial index cannot remove entries; rebuild it lazily.
+		if m.vertexIndex != nil {
+			m.vertexIndex = nil
+		}
+	case EffectAddTriangle:
+		m.unindexTriangle(len(m.triangles)-1, *e.Triangle)
+		m.triangles = truncate(m.triangles, len(m.triangles)-1, m.shared.triangles)
+	case EffectAddPerimeter:
+		m.perimeters = truncate(m.perimeters, len(m.perimeters)-1, m.shared.loops)
+	case EffectAddHole:
+		m.holes = truncate(m.holes, len(m.holes)-1, m.shared.loops)
+	case EffectSetTriangle:
+		m.replaceTriangle(e.Index, *e.Triangle, *e.PreviousTriangle)
+	case EffectRemoveTriangle:
+		m.insertTriangleAt(e.Index, *e.Triangle)
+	case EffectSetPerimeter:
+		m.ownLoops()
+		m.perimeters[e.Index] = e.PreviousLoop
+	case EffectSetHole:
+		m.ownLoops()
+		m.holes[e.Index] = e.PreviousLoop
+	case EffectMoveVertex:
+		m.setVertex(e.Vertex, *e.PreviousPoint)
+	case EffectRemoveVertex:
+		m.ownRemoved()
+		delete(m.removed, e.Vertex)
+		m.vertexIndex = nil
+	}
+}
+
+// commitEffect records e in the journal and applies it.
+func (m *Mesh) commitEffect(e JournalEffect) {
+	m.recordEffect(e)
+	m.applyEffect(e)
+}
+
+// replaceTriangle swaps the triangle at idx and keeps the lookup sets in sync.
+func (m *Mesh) replaceTriangle(idx int, old, tri types.Triangle) {
+	m.ownTriangles()
+	m.unindexTriangle(idx, old)
+	m.triangles[idx] = tri
+	m.indexTriangle(idx, tri)
+}
+
+// setVertex moves a vertex in place. The spatial index cannot move entries,
+// so it is rebuilt lazily on the next lookup.
+func (m *Mesh) setVertex(id types.VertexID, p types.Point) {
+	m.ownVertices()
+	m.vertices[id] = p
+	m.vertexIndex = nil
+}
+
+// truncate shortens s to n elements. When the storage is shared with a
+// snapshot or clone the capacity is clipped so later appends reallocate
+// instead of overwriting shared elements.
+func truncate[T any](s []T, n int, shared bool) []T {
+	if shared {
+		return s[:n:n]
+	}
+	return s[:n]
+}
@@ -0,0 +1,209 @@
+package mesh
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestJournalDisabled(t *testing.T) {
+	m := NewMesh()
+	if err := m.Undo(); err != ErrJournalDisabled {
+		t.Fatalf("expected ErrJournalDisabled, got %v", err)
+	}
+	if _, err := m.Journal(); err != ErrJournalDisabled {
+		t.Fatalf("expected ErrJournalDisabled, got %v", err)
+	}
+}
+
+func TestJournalUndoRedoTriangle(t *testing.T) {
+	m, ids := buildSquareMesh(t, WithJournal(true), WithDuplicateTriangleError(true))
+
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	if err := m.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if m.NumTriangles() != 1 {
+		t.Fatalf("expected 1 triangle after undo, got %d", m.NumTriangles())
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(ids[2], ids[3])]; ok {
+		t.Fatalf("expected edge to be removed by undo")
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(ids[0], ids[2])]; !ok {
+		t.Fatalf("shared edge must survive undo")
+	}
+
+	if err := m.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if m.NumTriangles() != 2 {
+		t.Fatalf("expected 2 triangles after redo, got %d", m.NumTriangles())
+	}
+	if err := m.AddTriangle(ids[2], ids[3], ids[0]); err != ErrDuplicateTriangle {
+		t.Fatalf("expected redo to restore duplicate tracking, got %v", err)
+	}
+	if err := m.Redo(); err != ErrNothingToRedo {
+		t.Fatalf("expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestJournalRejectedCallsNotRecorded(t *testing.T) {
+	m := NewMesh(WithJournal(true))
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 1, Y: 0})
+	c, _ := m.AddVertex(types.Point{X: 2, Y: 0})
+
+	if err := m.AddTriangle(a, b, c); err != ErrDegenerateTriangle {
+		t.Fatalf("expected ErrDegenerateTriangle, got %v", err)
+	}
+	data, _ := m.Journal()
+	if len(data.Commands) != 3 {
+		t.Fatalf("expected 3 recorded commands, got %d", len(data.Commands))
+	}
+}
+
+func TestJournalPerimeterUndoIsOneStep(t *testing.T) {
+	m := NewMesh(WithJournal(true))
+	if _, err := m.AddPerimeter([]types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}); err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	if err := m.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if m.NumVertices() != 0 || len(m.Perimeters()) != 0 {
+		t.Fatalf("expected empty mesh, got %d vertices and %d perimeters", m.NumVertices(), len(m.Perimeters()))
+	}
+	if m.CanUndo() {
+		t.Fatalf("expected nothing left to undo")
+	}
+}
+
+func TestJournalCheckpoints(t *testing.T) {
+	m, ids := buildSquareMesh(t, WithJournal(true))
+	if err := m.Checkpoint("one-triangle"); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	if _, err := m.AddVertex(types.Point{X: 5, Y: 20}); err != nil {
+		t.Fatalf("AddVertex failed: %v", err)
+	}
+	if err := m.Checkpoint("end"); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	if err := m.RestoreCheckpoint("one-triangle"); err != nil {
+		t.Fatalf("RestoreCheckpoint failed: %v", err)
+	}
+	if m.NumTriangles() != 1 || m.NumVertices() != 4 {
+		t.Fatalf("unexpected state after restore: %d triangles, %d vertices", m.NumTriangles(), m.NumVertices())
+	}
+	if err := m.RestoreCheckpoint("end"); err != nil {
+		t.Fatalf("RestoreCheckpoint failed: %v", err)
+	}
+	if m.NumTriangles() != 2 || m.NumVertices() != 5 {
+		t.Fatalf("unexpected state after redo: %d triangles, %d vertices", m.NumTriangles(), m.NumVertices())
+	}
+	if err := m.RestoreCheckpoint("missing"); !errors.Is(err, ErrUnknownCheckpoint) {
+		t.Fatalf("expected ErrUnknownCheckpoint, got %v", err)
+	}
+}
+
+func TestJournalUndoKeepsSnapshot(t *testing.T) {
+	m, ids := buildSquareMesh(t, WithJournal(true))
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	snap := m.Snapshot()
+
+	_ = m.Undo()
+	v, _ := m.AddVertex(types.Point{X: 20, Y: 0})
+	if err := m.AddTriangle(ids[1], v, ids[2]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	if snap.GetTriangle(1) != types.NewTriangle(ids[0], ids[2], ids[3]) {
+		t.Fatalf("snapshot overwritten after undo: %v", snap.GetTriangle(1))
+	}
+}
+
+func TestJournalSaveAndReplay(t *testing.T) {
+	m := NewMesh(WithJournal(true), WithMergeVertices(true), WithEdgeIntersectionCheck(true))
+	if _, err := m.AddPerimeter([]types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}); err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	if err := m.AddTriangle(0, 1, 2); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	_ = m.Checkpoint("half")
+	if err := m.AddTriangle(0, 2, 3); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	if err := m.SaveJournal(path); err != nil {
+		t.Fatalf("SaveJournal failed: %v", err)
+	}
+	data, err := LoadJournal(path)
+	if err != nil {
+		t.Fatalf("LoadJournal failed: %v", err)
+	}
+
+	r, err := data.Replay()
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !Diff(m.Snapshot(), r.Snapshot()).IsEmpty() {
+		t.Fatalf("replayed mesh differs from original")
+	}
+	if err := r.RestoreCheckpoint("half"); err != nil {
+		t.Fatalf("replayed checkpoint missing: %v", err)
+	}
+	if r.NumTriangles() != 1 {
+		t.Fatalf("expected 1 triangle at checkpoint, got %d", r.NumTriangles())
+	}
+}
+
+func TestJournalReplayDiverged(t *testing.T) {
+	m, ids := buildSquareMesh(t, WithJournal(true))
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	data, _ := m.Journal()
+	data.Commands[0].Points[0] = types.Point{X: 10, Y: 10}
+
+	if _, err := data.Replay(); !errors.Is(err, ErrReplayDiverged) {
+		t.Fatalf("expected ErrReplayDiverged, got %v", err)
+	}
+}
+
+func TestJournalCloneIsIndependent(t *testing.T) {
+	m := NewMesh(WithJournal(true))
+	m.AddVertex(types.Point{X: 0, Y: 0})
+	m.AddVertex(types.Point{X: 1, Y: 0})
+	c := m.Clone()
+
+	// Undo then record a new command in the undone slot of the original.
+	if err := m.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	m.AddVertex(types.Point{X: 5, Y: 5})
+
+	data, err := c.Journal()
+	if err != nil {
+		t.Fatalf("Journal failed: %v", err)
+	}
+	if got := data.Commands[1].Points[0]; got != (types.Point{X: 1, Y: 0}) {
+		t.Fatalf("clone journal changed by the original: command 1 adds %v", got)
+	}
+	if err := c.Undo(); err != nil {
+		t.Fatalf("Undo on clone failed: %v", err)
+	}
+	if c.NumVertices() != 1 || c.GetVertex(0) != (types.Point{X: 0, Y: 0}) {
+		t.Fatalf("clone undid the wrong command: %d vertices", c.NumVertices())
+	}
+}
@@ -1,6 +1,8 @@
 package mesh
 
 import (
+	"github.com/iceisfun/gomesh/algorithm/snap"
+	"github.com/iceisfun/gomesh/predicates"
 	"github.com/iceisfun/gomesh/spatial"
 	"github.com/iceisfun/gomesh/types"
 )
@@ -18,8 +20,26 @@ type Mesh struct {
 
 	triangleSet map[[3]types.VertexID]types.Triangle
 
+	// edgeUses and triangleUses count the triangles using each edge and
+	// canonical triangle key, so removals can update the lookup sets
+	// without scanning.
+	edgeUses     map[types.Edge]int
+	triangleUses map[[3]types.VertexID]int
+
 	perimeters []types.PolygonLoop
 	holes      []types.PolygonLoop
+
+	shared sharedState
+
+	// incidence lists the triangles around each vertex; nil until first
+	// used.
+	incidence [][]int
+
+	// removed holds the vertices dropped by CollapseEdge. They keep their
+	// IDs but are no longer valid and are left out of the merge index.
+	removed map[types.VertexID]struct{}
+
+	journal *journal
 }
 
 // NumVertices returns the number of vertices in the mesh.
@@ -72,14 +92,37 @@ func (m *Mesh) GetTriangleCoords(idx int) (types.Point, types.Point, types.Point
 	return m.vertices[t.V1()], m.vertices[t.V2()], m.vertices[t.V3()]
 }
 
-// IsValidVertexID reports whether the supplied ID references an existing vertex.
+// IsValidVertexID reports whether the supplied ID references an existing
+// vertex. Vertices removed by CollapseEdge are not valid.
 func (m *Mesh) IsValidVertexID(id types.VertexID) bool {
-	return id >= 0 && int(id) < len(m.vertices)
+	if id < 0 || int(id) >= len(m.vertices) {
+		return false
+	}
+	_, removed := m.removed[id]
+	return !removed
 }
 
-// Epsilon returns the configured epsilon tolerance.
+// Epsilon returns the absolute part of the configured tolerance.
 func (m *Mesh) Epsilon() float64 {
-	return m.cfg.epsilon
+	return m.cfg.tolerance.Abs
+}
+
+// Tolerance returns the configured tolerance, including its scale-relative
+// part.
+func (m *Mesh) Tolerance() types.Epsilon {
+	return m.cfg.tolerance
+}
+
+// Predicates returns the predicate mode used by the mesh's checks, so that
+// callers can make decisions consistent with it.
+func (m *Mesh) Predicates() predicates.Mode {
+	return m.cfg.mode()
+}
+
+// SnapGrid returns the grid vertex coordinates are rounded to, disabled
+// unless WithSnapGrid was given.
+func (m *Mesh) SnapGrid() snap.Grid {
+	return m.cfg.snapGrid
 }
 
 // EdgeSet exposes the set of edges currently tracked by the mesh.
@@ -1,17 +1,50 @@
 package mesh
 
-import "github.com/iceisfun/gomesh/types"
+import (
+	"github.com/iceisfun/gomesh/algorithm/snap"
+	"github.com/iceisfun/gomesh/types"
+)
 
 // Option configures a Mesh during construction.
 type Option func(*config)
 
-// WithEpsilon sets the geometric tolerance for the mesh.
+// WithEpsilon sets the absolute geometric tolerance for the mesh.
+//
+// It keeps any relative tolerance set by WithTolerance.
 func WithEpsilon(epsilon float64) Option {
 	return func(c *config) {
 		if epsilon < 0 {
 			epsilon = DefaultEpsilon
 		}
-		c.epsilon = epsilon
+		c.tolerance.Abs = epsilon
+	}
+}
+
+// WithTolerance sets a scale-relative geometric tolerance.
+//
+// Distances are compared against eps.Abs + eps.Rel*|v|, where |v| is the
+// largest coordinate magnitude involved, and collinearity against the area of
+// a triangle that thin. This keeps validation stable for meshes far from the
+// origin or in large units, where a fixed epsilon is either too strict or
+// too loose. types.DefaultEpsilon is a good starting point.
+//
+// Vertex merging still uses the absolute part, see WithMergeDistance.
+func WithTolerance(eps types.Epsilon) Option {
+	return func(c *config) {
+		c.tolerance = types.NewEpsilon(eps.Abs, eps.Rel)
+	}
+}
+
+// WithExactPredicates decides orientation, collinearity and containment with
+// the exact predicates of algorithm/robust instead of a tolerance.
+//
+// Validation then agrees with cdt on near-degenerate input: a triangle is
+// degenerate only if its vertices are exactly collinear, and edges touch only
+// if they exactly meet. The tolerance still applies to measured quantities
+// such as overlap areas.
+func WithExactPredicates(enable bool) Option {
+	return func(c *config) {
+		c.exactPredicates = enable
 	}
 }
 
@@ -32,6 +65,25 @@ func WithMergeDistance(distance float64) Option {
 	}
 }
 
+// WithSnapGrid rounds every vertex coordinate to the nearest multiple of
+// cell, so points that should coincide but drifted apart in floating point
+// become the same vertex. It implicitly enables vertex merging.
+//
+// AddVertex, MoveVertex, SplitEdge and SplitTriangle snap the point they are
+// given before anything else, and CollapseEdge snaps the midpoint it moves
+// to; SplitEdge therefore fails if the snapped point is no longer on the
+// edge. Snap constraint segments with algorithm/snap.Round
+// (or cdt.BuildOptions.SnapGrid) to keep their intersections on the grid too.
+// A cell of 0 disables snapping.
+func WithSnapGrid(cell float64) Option {
+	return func(c *config) {
+		if cell >= 0 {
+			c.snapGrid = snap.Grid{Cell: cell}
+			c.mergeVertices = c.mergeVertices || cell > 0
+		}
+	}
+}
+
 // WithTriangleEnforceNoVertexInside enables vertex-inside validation.
 func WithTriangleEnforceNoVertexInside(enable bool) Option {
 	return func(c *config) {
@@ -107,6 +159,82 @@ func WithOverlapTriangle(allow bool) Option {
 	}
 }
 
+// WithMinEdgeLength makes AddPerimeter and AddHole reject loops with a
+// segment shorter than length.
+func WithMinEdgeLength(length float64) Option {
+	return func(c *config) {
+		c.minEdgeLength = length
+	}
+}
+
+// WithMinAngle makes AddPerimeter and AddHole reject loops with an interior
+// angle below degrees.
+func WithMinAngle(degrees float64) Option {
+	return func(c *config) {
+		c.minAngle = degrees
+	}
+}
+
+// WithMinFeatureSize makes AddPerimeter and AddHole reject loops whose
+// facing segments come closer than size, such as thin slivers and narrow
+// necks (see validation.MinFeatureSize).
+func WithMinFeatureSize(size float64) Option {
+	return func(c *config) {
+		c.minFeatureSize = size
+	}
+}
+
+// WithMinClearance makes AddPerimeter and AddHole reject loops that come
+// closer than distance to an existing perimeter or hole.
+//
+// Example:
+//
+//	m := NewMesh(WithMinClearance(0.5))
+//	m.AddPerimeter(outer)
+//	m.AddHole(hole) // Error if the hole is within 0.5 of the perimeter
+func WithMinClearance(distance float64) Option {
+	return func(c *config) {
+		c.minClearance = distance
+	}
+}
+
+// WithTriangleValidator registers a user-defined check that AddTriangle
+// runs after the built-in validation. Validators run in registration order
+// and the first error rejects the triangle; name identifies the validator
+// in the resulting ValidatorError.
+//
+// Validators are not saved with the mesh and must be registered again when
+// a saved mesh is loaded. The candidate search functions call them from
+// several goroutines at once.
+//
+// Example:
+//
+//	maxArea := func(tri types.Triangle, a, b, c types.Point, view mesh.MeshView) error {
+//	    if math.Abs((b.X-a.X)*(c.Y-a.Y)-(b.Y-a.Y)*(c.X-a.X))/2 > 10 {
+//	        return errors.New("triangle too large")
+//	    }
+//	    return nil
+//	}
+//	m := mesh.NewMesh(mesh.WithTriangleValidator("max-area", maxArea))
+func WithTriangleValidator(name string, validator TriangleValidator) Option {
+	return func(c *config) {
+		if validator != nil {
+			c.triangleValidators = append(c.triangleValidators, namedValidator{name: name, fn: validator})
+		}
+	}
+}
+
+// WithJournal records every mutation in an undo/redo journal.
+//
+// Each call to AddVertex, AddTriangle, AddPerimeter or AddHole that changes
+// the mesh becomes one command that can be undone with Undo, redone with
+// Redo, labelled with Checkpoint and saved with SaveJournal for replay.
+func WithJournal(enable bool) Option {
+	return func(c *config) {
+		c.journal = enable
+	}
+}
+
 // WithDebugAddVertex installs a hook called after vertex insertion.
 func WithDebugAddVertex(hook func(types.VertexID, types.Point)) Option {
 	return func(c *config) {
@@ -127,3 +255,69 @@ func WithDebugAddTriangle(hook func(types.Triangle)) Option {
 		c.debugAddTriangle = hook
 	}
 }
+
+// WithDebugRejectTriangle installs a hook called when AddTriangle rejects a
+// triangle, with the error AddTriangle is about to return.
+func WithDebugRejectTriangle(hook func(types.Triangle, error)) Option {
+	return func(c *config) {
+		c.debugRejectTriangle = hook
+	}
+}
+
+// WithDebugAddPerimeter installs a hook called after a perimeter loop is added.
+//
+// The hook runs after the debug vertex hooks for the loop's vertices.
+func WithDebugAddPerimeter(hook func(types.PolygonLoop)) Option {
+	return func(c *config) {
+		c.debugAddPerimeter = hook
+	}
+}
+
+// WithDebugAddHole installs a hook called after a hole loop is added.
+//
+// The hook runs after the debug vertex hooks for the loop's vertices.
+func WithDebugAddHole(hook func(types.PolygonLoop)) Option {
+	return func(c *config) {
+		c.debugAddHole = hook
+	}
+}
+
+// WithDebugFlipEdge installs a hook called after every FlipEdge call with
+// the edge and the error FlipEdge returns.
+func WithDebugFlipEdge(hook func(types.Edge, error)) Option {
+	return func(c *config) {
+		c.debugFlipEdge = hook
+	}
+}
+
+// WithDebugSplitEdge installs a hook called after every SplitEdge call with
+// its arguments and results.
+func WithDebugSplitEdge(hook func(types.Edge, types.Point, types.VertexID, error)) Option {
+	return func(c *config) {
+		c.debugSplitEdge = hook
+	}
+}
+
+// WithDebugCollapseEdge installs a hook called after every CollapseEdge call
+// with the edge and its results.
+func WithDebugCollapseEdge(hook func(types.Edge, types.VertexID, error)) Option {
+	return func(c *config) {
+		c.debugCollapseEdge = hook
+	}
+}
+
+// WithDebugSplitTriangle installs a hook called after every SplitTriangle
+// call with its arguments and results.
+func WithDebugSplitTriangle(hook func(int, types.Point, types.VertexID, error)) Option {
+	return func(c *config) {
+		c.debugSplitTriangle = hook
+	}
+}
+
+// WithDebugMoveVertex installs a hook called after every MoveVertex call with
+// its arguments and the error MoveVertex returns.
+func WithDebugMoveVertex(hook func(types.VertexID, types.Point, error)) Option {
+	return func(c *config) {
+		c.debugMoveVertex = hook
+	}
+}
@@ -1,6 +1,11 @@
 package mesh
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
 
 func TestOptions(t *testing.T) {
 	cfg := newDefaultConfig()
@@ -17,7 +22,7 @@ func TestOptions(t *testing.T) {
 		opt(&cfg)
 	}
 
-	if cfg.epsilon != 1e-5 {
+	if cfg.tolerance.Abs != 1e-5 {
 		t.Fatalf("epsilon not applied")
 	}
 	if !cfg.mergeVertices {
@@ -37,7 +42,81 @@ func TestOptions(t *testing.T) {
 func TestWithEpsilonNegative(t *testing.T) {
 	cfg := newDefaultConfig()
 	WithEpsilon(-1)(&cfg)
-	if cfg.epsilon != DefaultEpsilon {
+	if cfg.tolerance.Abs != DefaultEpsilon {
 		t.Fatalf("negative epsilon should fall back to default")
 	}
 }
+
+func TestWithTolerance(t *testing.T) {
+	cfg := newDefaultConfig()
+	WithTolerance(types.Epsilon{Abs: -1e-6, Rel: 1e-10})(&cfg)
+	if cfg.tolerance != (types.Epsilon{Abs: 1e-6, Rel: 1e-10}) {
+		t.Fatalf("expected a normalized tolerance, got %+v", cfg.tolerance)
+	}
+
+	// WithEpsilon only replaces the absolute part.
+	WithEpsilon(1e-8)(&cfg)
+	if cfg.tolerance != (types.Epsilon{Abs: 1e-8, Rel: 1e-10}) {
+		t.Fatalf("expected the relative tolerance to be kept, got %+v", cfg.tolerance)
+	}
+}
+
+func TestWithToleranceKilometreScale(t *testing.T) {
+	a := types.Point{X: 500000, Y: 4000000}
+	b := types.Point{X: 500100, Y: 4000100}
+	sliver := types.Point{X: 500050, Y: 4000050 + 1e-7}
+
+	m := NewMesh(WithTolerance(types.DefaultEpsilon()))
+	ids := make([]types.VertexID, 3)
+	for i, p := range []types.Point{a, b, sliver} {
+		ids[i], _ = m.AddVertex(p)
+	}
+	if err := m.AddTriangle(ids[0], ids[1], ids[2]); !errors.Is(err, ErrDegenerateTriangle) {
+		t.Fatalf("expected the sliver to be degenerate, got %v", err)
+	}
+
+	c, _ := m.AddVertex(types.Point{X: 500000, Y: 4000100})
+	if err := m.AddTriangle(ids[0], ids[1], c); err != nil {
+		t.Fatalf("expected a valid triangle, got %v", err)
+	}
+}
+
+func TestWithExactPredicates(t *testing.T) {
+	m := NewMesh(WithExactPredicates(true))
+	if !m.Predicates().Exact {
+		t.Fatalf("expected exact predicates")
+	}
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 1, Y: 1})
+	c, _ := m.AddVertex(types.Point{X: 0.5, Y: 0.5 + 1e-12})
+	if err := m.AddTriangle(a, b, c); err != nil {
+		t.Fatalf("expected the exactly non-collinear sliver to be accepted, got %v", err)
+	}
+
+	d, _ := m.AddVertex(types.Point{X: 2, Y: 2})
+	if err := m.AddTriangle(a, b, d); !errors.Is(err, ErrDegenerateTriangle) {
+		t.Fatalf("expected exactly collinear vertices to be degenerate, got %v", err)
+	}
+
+	if opts := m.Config().Options(); !NewMesh(opts...).Predicates().Exact {
+		t.Fatalf("expected exact predicates to survive a config round trip")
+	}
+}
+
+func TestWithSnapGrid(t *testing.T) {
+	cfg := newDefaultConfig()
+	WithSnapGrid(0.25)(&cfg)
+	if cfg.snapGrid.Cell != 0.25 || !cfg.mergeVertices {
+		t.Fatalf("expected snapping with merging, got %+v", cfg)
+	}
+	WithSnapGrid(-1)(&cfg)
+	if cfg.snapGrid.Cell != 0.25 {
+		t.Fatalf("a negative cell should be ignored")
+	}
+
+	m := NewMesh(WithSnapGrid(0.25), WithMergeVertices(false))
+	loaded := NewMesh(m.Config().Options()...)
+	if loaded.SnapGrid() != m.SnapGrid() || loaded.cfg.mergeVertices {
+		t.Fatalf("expected snapping to survive a config round trip without enabling merging")
+	}
+}
@@ -33,7 +33,7 @@ func (m *Mesh) FindOverlappingTriangles() []TriangleOverlap {
 			if overlap := m.checkTriangleOverlap(t1, t2, i, j); overlap != nil {
 				// Only include overlaps with meaningful intersection area
 				// (skip edge-touching cases with zero area)
-				if overlap.IntersectionArea > m.cfg.epsilon {
+				if overlap.IntersectionArea > m.overlapTolerance(t1, t2) {
 					overlaps = append(overlaps, *overlap)
 				}
 			}
@@ -57,7 +57,8 @@ func (m *Mesh) checkTriangleOverlap(t1, t2 types.Triangle, idx1, idx2 int) *Tria
 	b2 := m.vertices[t2.V2()]
 	c2 := m.vertices[t2.V3()]
 
-	eps := m.cfg.epsilon
+	mode := m.cfg.mode()
+	eps := m.cfg.tolerance.TolForPoints(a1, b1, c1, a2, b2, c2)
 
 	// If they share all 3 vertices, they're duplicates
 	if sharedVerts == 3 {
@@ -79,12 +80,12 @@ func (m *Mesh) checkTriangleOverlap(t1, t2 types.Triangle, idx1, idx2 int) *Tria
 	// This is normal mesh topology. Only flag as overlap if there's vertex containment.
 	if len(sharedEdges) > 0 {
 		// Check if any non-shared vertex is strictly inside the other triangle
-		if predicates.PointStrictlyInTriangle(a2, a1, b1, c1, eps) ||
-			predicates.PointStrictlyInTriangle(b2, a1, b1, c1, eps) ||
-			predicates.PointStrictlyInTriangle(c2, a1, b1, c1, eps) ||
-			predicates.PointStrictlyInTriangle(a1, a2, b2, c2, eps) ||
-			predicates.PointStrictlyInTriangle(b1, a2, b2, c2, eps) ||
-			predicates.PointStrictlyInTriangle(c1, a2, b2, c2, eps) {
+		if mode.PointStrictlyInTriangle(a2, a1, b1, c1) ||
+			mode.PointStrictlyInTriangle(b2, a1, b1, c1) ||
+			mode.PointStrictlyInTriangle(c2, a1, b1, c1) ||
+			mode.PointStrictlyInTriangle(a1, a2, b2, c2) ||
+			mode.PointStrictlyInTriangle(b1, a2, b2, c2) ||
+			mode.PointStrictlyInTriangle(c1, a2, b2, c2) {
 			intersectionArea := predicates.TriangleIntersectionArea(a1, b1, c1, a2, b2, c2, eps)
 			return &TriangleOverlap{
 				Tri1:             t1,
@@ -102,9 +103,9 @@ func (m *Mesh) checkTriangleOverlap(t1, t2 types.Triangle, idx1, idx2 int) *Tria
 	}
 
 	// Check if any vertex of t2 is strictly inside t1
-	if predicates.PointStrictlyInTriangle(a2, a1, b1, c1, eps) ||
-		predicates.PointStrictlyInTriangle(b2, a1, b1, c1, eps) ||
-		predicates.PointStrictlyInTriangle(c2, a1, b1, c1, eps) {
+	if mode.PointStrictlyInTriangle(a2, a1, b1, c1) ||
+		mode.PointStrictlyInTriangle(b2, a1, b1, c1) ||
+		mode.PointStrictlyInTriangle(c2, a1, b1, c1) {
 		overlapType := fmt.Sprintf("VERTEX INSIDE (%d shared verts, %d shared edges)", sharedVerts, len(sharedEdges))
 		intersectionArea := predicates.TriangleIntersectionArea(a1, b1, c1, a2, b2, c2, eps)
 		return &TriangleOverlap{
@@ -120,9 +121,9 @@ func (m *Mesh) checkTriangleOverlap(t1, t2 types.Triangle, idx1, idx2 int) *Tria
 	}
 
 	// Check if any vertex of t1 is strictly inside t2
-	if predicates.PointStrictlyInTriangle(a1, a2, b2, c2, eps) ||
-		predicates.PointStrictlyInTriangle(b1, a2, b2, c2, eps) ||
-		predicates.PointStrictlyInTriangle(c1, a2, b2, c2, eps) {
+	if mode.PointStrictlyInTriangle(a1, a2, b2, c2) ||
+		mode.PointStrictlyInTriangle(b1, a2, b2, c2) ||
+		mode.PointStrictlyInTriangle(c1, a2, b2, c2) {
 		overlapType := fmt.Sprintf("VERTEX INSIDE (%d shared verts, %d shared edges)", sharedVerts, len(sharedEdges))
 		intersectionArea := predicates.TriangleIntersectionArea(a1, b1, c1, a2, b2, c2, eps)
 		return &TriangleOverlap{
@@ -154,7 +155,7 @@ func (m *Mesh) checkTriangleOverlap(t1, t2 types.Triangle, idx1, idx2 int) *Tria
 			p4 := m.vertices[e2.V2()]
 
 			// Check for proper intersection (edges cross)
-			intersects, proper := predicates.SegmentsIntersect(p1, p2, p3, p4, eps)
+			intersects, proper := mode.SegmentsIntersect(p1, p2, p3, p4)
 			if intersects && proper {
 				overlapType := fmt.Sprintf("EDGE CROSSING (%d shared verts, %d shared edges)", sharedVerts, len(sharedEdges))
 				intersectionArea := predicates.TriangleIntersectionArea(a1, b1, c1, a2, b2, c2, eps)
@@ -90,7 +90,7 @@ func TestOverlapCase_CollinearEdges(t *testing.T) {
 
 	// Verify config
 	t.Logf("Mesh config: validateTriangleOverlapArea=%v, epsilon=%.10f",
-		m.cfg.validateTriangleOverlapArea, m.cfg.epsilon)
+		m.cfg.validateTriangleOverlapArea, m.cfg.tolerance.Abs)
 
 	mv0, _ := m.AddVertex(v0)
 	mv1, _ := m.AddVertex(v1)
@@ -53,7 +53,7 @@ func TestOpposingWindingWithDifferentOptions(t *testing.T) {
 				WithTriangleOverlapCheck(tc.checkGeometricOverlap),
 			)
 
-			t.Logf("Config: epsilon=%.10f, validateTriangleOverlapArea=%v", m.cfg.epsilon, m.cfg.validateTriangleOverlapArea)
+			t.Logf("Config: epsilon=%.10f, validateTriangleOverlapArea=%v", m.cfg.tolerance.Abs, m.cfg.validateTriangleOverlapArea)
 
 			// Add vertices
 			v0, _ := m.AddVertex(types.Point{X: 120.00, Y: 65.00})
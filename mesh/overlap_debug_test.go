@@ -49,17 +49,17 @@ func TestOverlapDebug(t *testing.T) {
 	b1 := m.vertices[tri1.V2()]
 	c1 := m.vertices[tri1.V3()]
 
-	intersectionArea1 := predicates.TriangleIntersectionArea(a, b, c, a1, b1, c1, m.cfg.epsilon)
-	intersectionArea2 := predicates.TriangleIntersectionArea(a1, b1, c1, a, b, c, m.cfg.epsilon)
+	intersectionArea1 := predicates.TriangleIntersectionArea(a, b, c, a1, b1, c1, m.cfg.tolerance.Abs)
+	intersectionArea2 := predicates.TriangleIntersectionArea(a1, b1, c1, a, b, c, m.cfg.tolerance.Abs)
 
 	t.Logf("\nDirect intersection area calculation:")
 	t.Logf("  Triangle 1: (%v, %v, %v)", a1, b1, c1)
 	t.Logf("  Triangle 2: (%v, %v, %v)", a, b, c)
 	t.Logf("  Intersection area (tri2 vs tri1): %.10f", intersectionArea1)
 	t.Logf("  Intersection area (tri1 vs tri2): %.10f", intersectionArea2)
-	t.Logf("  Epsilon: %.10f", m.cfg.epsilon)
-	t.Logf("  Area1 > Epsilon? %v", intersectionArea1 > m.cfg.epsilon)
-	t.Logf("  Area2 > Epsilon? %v", intersectionArea2 > m.cfg.epsilon)
+	t.Logf("  Epsilon: %.10f", m.cfg.tolerance.Abs)
+	t.Logf("  Area1 > Epsilon? %v", intersectionArea1 > m.cfg.tolerance.Abs)
+	t.Logf("  Area2 > Epsilon? %v", intersectionArea2 > m.cfg.tolerance.Abs)
 
 	intersectionArea := intersectionArea1
 
@@ -72,6 +72,6 @@ func TestOverlapDebug(t *testing.T) {
 		t.Logf("✓ Validation correctly rejected overlap: %v", err)
 	} else {
 		t.Errorf("✗ Validation did NOT reject overlap even though area=%.4f > epsilon=%.10f",
-			intersectionArea, m.cfg.epsilon)
+			intersectionArea, m.cfg.tolerance.Abs)
 	}
 }
@@ -16,7 +16,7 @@ func TestOverlap_UserReportedCase(t *testing.T) {
 
 	// Verify config
 	t.Logf("Config: validateTriangleOverlapArea=%v, epsilon=%.10f",
-		m.cfg.validateTriangleOverlapArea, m.cfg.epsilon)
+		m.cfg.validateTriangleOverlapArea, m.cfg.tolerance.Abs)
 
 	// Add vertices
 	v273, _ := m.AddVertex(types.Point{X: 15.000, Y: 132.000})
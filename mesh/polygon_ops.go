@@ -3,8 +3,8 @@ package mesh
 import (
 	"fmt"
 
-	"github.com/iceisfun/gomesh/predicates"
 	"github.com/iceisfun/gomesh/types"
+	"github.com/iceisfun/gomesh/validation"
 )
 
 // AddPerimeter adds a perimeter polygon to the mesh.
@@ -14,7 +14,7 @@ import (
 // the polygon loop is tracked for hole validation.
 //
 // If edge intersection checking is enabled, overlapping perimeters will
-// be rejected.
+// be rejected. Use clip.UnionAll to merge overlapping perimeters first.
 //
 // Returns the PolygonLoop of vertex IDs, or an error if validation fails.
 //
@@ -22,6 +22,13 @@ import (
 //   points := []types.Point{{0,0}, {10,0}, {10,10}, {0,10}}
 //   loop, err := m.AddPerimeter(points)
 func (m *Mesh) AddPerimeter(points []types.Point) (types.PolygonLoop, error) {
+	m.beginCommand(OpAddPerimeter, nil, points)
+	loop, err := m.addPerimeter(points)
+	m.endCommand(err)
+	return loop, err
+}
+
+func (m *Mesh) addPerimeter(points []types.Point) (types.PolygonLoop, error) {
 	if len(points) < 3 {
 		return nil, fmt.Errorf("gomesh: perimeter must have at least 3 points")
 	}
@@ -43,17 +50,32 @@ func (m *Mesh) AddPerimeter(points []types.Point) (types.PolygonLoop, error) {
 		return nil, fmt.Errorf("gomesh: perimeter validation failed: %w", err)
 	}
 
+	// Validate the perimeter is thick enough
+	if err := m.validateLoopFeatures(loop); err != nil {
+		return nil, fmt.Errorf("gomesh: perimeter validation failed: %w", err)
+	}
+
 	// Validate the perimeter doesn't overlap with existing perimeters
 	if err := m.validatePerimeterNotOverlapping(loop); err != nil {
 		return nil, err
 	}
 
+	// Validate the perimeter keeps its distance from existing loops
+	if err := m.validateLoopClearance(loop); err != nil {
+		return nil, err
+	}
+
 	// Track this as a perimeter
 	if m.perimeters == nil {
 		m.perimeters = []types.PolygonLoop{}
 	}
+	m.recordEffect(JournalEffect{Kind: EffectAddPerimeter, Index: len(m.perimeters), Loop: loop})
 	m.perimeters = append(m.perimeters, loop)
 
+	if m.cfg.debugAddPerimeter != nil {
+		m.cfg.debugAddPerimeter(loop)
+	}
+
 	return loop, nil
 }
 
@@ -70,6 +92,13 @@ func (m *Mesh) AddPerimeter(points []types.Point) (types.PolygonLoop, error) {
 //   holePoints := []types.Point{{2,2}, {8,2}, {8,8}, {2,8}}
 //   loop, err := m.AddHole(holePoints)
 func (m *Mesh) AddHole(points []types.Point) (types.PolygonLoop, error) {
+	m.beginCommand(OpAddHole, nil, points)
+	loop, err := m.addHole(points)
+	m.endCommand(err)
+	return loop, err
+}
+
+func (m *Mesh) addHole(points []types.Point) (types.PolygonLoop, error) {
 	if len(points) < 3 {
 		return nil, fmt.Errorf("gomesh: hole must have at least 3 points")
 	}
@@ -91,6 +120,11 @@ func (m *Mesh) AddHole(points []types.Point) (types.PolygonLoop, error) {
 		return nil, fmt.Errorf("gomesh: hole validation failed: %w", err)
 	}
 
+	// Validate the hole is thick enough
+	if err := m.validateLoopFeatures(loop); err != nil {
+		return nil, fmt.Errorf("gomesh: hole validation failed: %w", err)
+	}
+
 	// Validate hole is inside a perimeter
 	if err := m.validateHoleInsidePerimeter(loop); err != nil {
 		return nil, err
@@ -111,16 +145,27 @@ func (m *Mesh) AddHole(points []types.Point) (types.PolygonLoop, error) {
 		return nil, err
 	}
 
+	// Validate the hole keeps its distance from existing loops
+	if err := m.validateLoopClearance(loop); err != nil {
+		return nil, err
+	}
+
 	// Track this as a hole
 	if m.holes == nil {
 		m.holes = []types.PolygonLoop{}
 	}
+	m.recordEffect(JournalEffect{Kind: EffectAddHole, Index: len(m.holes), Loop: loop})
 	m.holes = append(m.holes, loop)
 
+	if m.cfg.debugAddHole != nil {
+		m.cfg.debugAddHole(loop)
+	}
+
 	return loop, nil
 }
 
-// validatePolygonLoop checks if a polygon self-intersects
+// validatePolygonLoop checks if a polygon self-intersects, reporting the
+// first crossing as a SelfIntersectionError.
 func (m *Mesh) validatePolygonLoop(loop types.PolygonLoop) error {
 	edges := loop.Edges()
 
@@ -148,9 +193,14 @@ func (m *Mesh) validatePolygonLoop(loop types.PolygonLoop) error {
 			p4 := m.vertices[e2.V2()]
 
 			// Check for intersection
-			intersects, proper := predicates.SegmentsIntersect(p1, p2, p3, p4, m.cfg.epsilon)
+			intersects, proper := m.cfg.mode().SegmentsIntersect(p1, p2, p3, p4)
 			if intersects && proper {
-				return fmt.Errorf("polygon self-intersects")
+				point, _ := m.cfg.mode().SegmentIntersectionPoint(p1, p2, p3, p4)
+				return SelfIntersectionError{
+					Edges:     [2]types.Edge{e1, e2},
+					Positions: [2]int{i, j},
+					Point:     point,
+				}
 			}
 		}
 	}
@@ -158,6 +208,40 @@ func (m *Mesh) validatePolygonLoop(loop types.PolygonLoop) error {
 	return nil
 }
 
+// validateLoopFeatures applies the configured minimum edge length, interior
+// angle and feature size, reporting failures as a validation.FeatureError.
+func (m *Mesh) validateLoopFeatures(loop types.PolygonLoop) error {
+	if m.cfg.minEdgeLength <= 0 && m.cfg.minAngle <= 0 && m.cfg.minFeatureSize <= 0 {
+		return nil
+	}
+	return validation.ValidatePolygon(m.getPolygonPoints(loop),
+		validation.WithPolygonTolerance(m.cfg.tolerance),
+		validation.WithPolygonExact(m.cfg.exactPredicates),
+		validation.WithAllowSelfIntersection(true), // checked by validatePolygonLoop
+		validation.WithPolygonMinEdgeLength(m.cfg.minEdgeLength),
+		validation.WithPolygonMinAngle(m.cfg.minAngle),
+		validation.WithPolygonMinFeatureSize(m.cfg.minFeatureSize),
+	)
+}
+
+// validateLoopClearance checks that the new loop stays the configured
+// minimum clearance away from every existing perimeter and hole.
+func (m *Mesh) validateLoopClearance(loop types.PolygonLoop) error {
+	if m.cfg.minClearance <= 0 {
+		return nil
+	}
+	points := m.getPolygonPoints(loop)
+	for kind, loops := range [][]types.PolygonLoop{m.perimeters, m.holes} {
+		for i, other := range loops {
+			err := validation.ValidateClearance(points, m.getPolygonPoints(other), m.cfg.minClearance, m.cfg.tolerance)
+			if err != nil {
+				return fmt.Errorf("gomesh: loop is too close to %s %d: %w", []string{"perimeter", "hole"}[kind], i, err)
+			}
+		}
+	}
+	return nil
+}
+
 // validatePerimeterNotOverlapping checks that the new perimeter doesn't overlap with existing perimeters
 func (m *Mesh) validatePerimeterNotOverlapping(newPerimeter types.PolygonLoop) error {
 	for _, existingPerimeter := range m.perimeters {
@@ -178,7 +262,7 @@ func (m *Mesh) validatePerimeterNotOverlapping(newPerimeter types.PolygonLoop) e
 				p3 := m.vertices[e2.V1()]
 				p4 := m.vertices[e2.V2()]
 
-				intersects, _ := predicates.SegmentsIntersect(p1, p2, p3, p4, m.cfg.epsilon)
+				intersects, _ := m.cfg.mode().SegmentsIntersect(p1, p2, p3, p4)
 				if intersects {
 					return fmt.Errorf("gomesh: perimeter overlaps with existing perimeter")
 				}
@@ -189,37 +273,46 @@ func (m *Mesh) validatePerimeterNotOverlapping(newPerimeter types.PolygonLoop) e
 	return nil
 }
 
-// validateHoleInsidePerimeter checks if the hole is completely inside exactly one perimeter
+// validateHoleInsidePerimeter checks if the hole is completely inside exactly one perimeter.
+//
+// Failures are reported as HoleOutsidePerimeterError or
+// HoleInMultiplePerimetersError.
 func (m *Mesh) validateHoleInsidePerimeter(hole types.PolygonLoop) error {
 	if len(m.perimeters) == 0 {
-		return fmt.Errorf("gomesh: cannot add hole without a perimeter")
+		return HoleOutsidePerimeterError{Perimeter: -1, Vertex: types.NilVertex}
 	}
 
-	containingPerimeters := 0
+	var containing []int
+	best, bestCount := -1, 0
+	outside := hole[0]
 
-	for _, perim := range m.perimeters {
-		// Check if all hole vertices are inside the perimeter
-		allInside := true
+	for pi, perim := range m.perimeters {
+		// Count the hole vertices inside the perimeter
+		perimPoints := m.getPolygonPoints(perim)
+		count := 0
+		firstOutside := types.NilVertex
 		for _, vid := range hole {
-			p := m.vertices[vid]
-			perimPoints := m.getPolygonPoints(perim)
-			if !predicates.PointInPolygonRayCast(p, perimPoints, m.cfg.epsilon) {
-				allInside = false
-				break
+			if m.cfg.mode().PointInPolygon(m.vertices[vid], perimPoints) {
+				count++
+			} else if firstOutside == types.NilVertex {
+				firstOutside = vid
 			}
 		}
 
-		if allInside {
-			containingPerimeters++
+		if count == len(hole) {
+			containing = append(containing, pi)
+		} else if count > bestCount {
+			best, bestCount = pi, count
+			outside = firstOutside
 		}
 	}
 
-	if containingPerimeters == 0 {
-		return fmt.Errorf("gomesh: hole must be inside a perimeter")
+	if len(containing) == 0 {
+		return HoleOutsidePerimeterError{Perimeter: best, Vertex: outside, Point: m.vertices[outside]}
 	}
 
-	if containingPerimeters > 1 {
-		return fmt.Errorf("gomesh: hole is inside multiple perimeters (ambiguous)")
+	if len(containing) > 1 {
+		return HoleInMultiplePerimetersError{Perimeters: containing}
 	}
 
 	return nil
@@ -245,7 +338,7 @@ func (m *Mesh) validateHoleNotIntersectingHoles(newHole types.PolygonLoop) error
 				p3 := m.vertices[e2.V1()]
 				p4 := m.vertices[e2.V2()]
 
-				intersects, _ := predicates.SegmentsIntersect(p1, p2, p3, p4, m.cfg.epsilon)
+				intersects, _ := m.cfg.mode().SegmentsIntersect(p1, p2, p3, p4)
 				if intersects {
 					return fmt.Errorf("gomesh: hole intersects with existing hole")
 				}
@@ -264,7 +357,7 @@ func (m *Mesh) validateHoleNotContainingHoles(newHole types.PolygonLoop) error {
 		// Check if any vertex of existing hole is inside new hole
 		for _, vid := range existingHole {
 			p := m.vertices[vid]
-			if predicates.PointInPolygonRayCast(p, newHolePoints, m.cfg.epsilon) {
+			if m.cfg.mode().PointInPolygon(p, newHolePoints) {
 				return fmt.Errorf("gomesh: hole cannot contain another hole")
 			}
 		}
@@ -281,7 +374,7 @@ func (m *Mesh) validateHoleNotInsideHole(newHole types.PolygonLoop) error {
 		// Check if any vertex of new hole is inside existing hole
 		for _, vid := range newHole {
 			p := m.vertices[vid]
-			if predicates.PointInPolygonRayCast(p, existingHolePoints, m.cfg.epsilon) {
+			if m.cfg.mode().PointInPolygon(p, existingHolePoints) {
 				return fmt.Errorf("gomesh: hole cannot be inside another hole")
 			}
 		}
@@ -0,0 +1,141 @@
+package mesh
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+	"github.com/iceisfun/gomesh/validation"
+)
+
+func TestAddPerimeterSelfIntersection(t *testing.T) {
+	m := NewMesh()
+
+	// A bow tie: edge 0 (0,0)-(4,4) crosses edge 2 (4,0)-(0,4).
+	_, err := m.AddPerimeter([]types.Point{{0, 0}, {4, 4}, {4, 0}, {0, 4}})
+	if !errors.Is(err, ErrPolygonSelfIntersection) {
+		t.Fatalf("expected self-intersection error, got %v", err)
+	}
+
+	var self SelfIntersectionError
+	if !errors.As(err, &self) {
+		t.Fatalf("expected a SelfIntersectionError, got %T", err)
+	}
+	if self.Positions != [2]int{0, 2} || self.Edges != [2]types.Edge{types.NewEdge(0, 1), types.NewEdge(2, 3)} ||
+		self.Point != (types.Point{2, 2}) {
+		t.Fatalf("unexpected self-intersection details %+v", self)
+	}
+}
+
+func TestAddHoleOutsidePerimeter(t *testing.T) {
+	m := NewMesh()
+
+	_, err := m.AddHole([]types.Point{{1, 1}, {2, 1}, {2, 2}})
+	var outside HoleOutsidePerimeterError
+	if !errors.As(err, &outside) || outside.Vertex != types.NilVertex {
+		t.Fatalf("expected a hole without perimeter error, got %v", err)
+	}
+
+	if _, err := m.AddPerimeter([]types.Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AddPerimeter([]types.Point{{20, 0}, {30, 0}, {30, 10}, {20, 10}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two of three vertices are inside the second perimeter.
+	_, err = m.AddHole([]types.Point{{22, 2}, {24, 2}, {35, 5}})
+	if !errors.Is(err, ErrHoleOutsidePerimeter) {
+		t.Fatalf("expected hole outside perimeter error, got %v", err)
+	}
+	if !errors.As(err, &outside) || outside.Perimeter != 1 || outside.Point != (types.Point{35, 5}) {
+		t.Fatalf("expected vertex (35, 5) outside perimeter 1, got %+v", err)
+	}
+
+	_, err = m.AddHole([]types.Point{{40, 2}, {42, 2}, {42, 4}})
+	if !errors.As(err, &outside) || outside.Perimeter != -1 || outside.Point != (types.Point{40, 2}) {
+		t.Fatalf("expected a hole outside every perimeter, got %+v", err)
+	}
+}
+
+func TestAddHoleInMultiplePerimeters(t *testing.T) {
+	m := NewMesh()
+
+	if _, err := m.AddPerimeter([]types.Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}); err != nil {
+		t.Fatal(err)
+	}
+	// Bypass the perimeter overlap check to build nested perimeters.
+	inner, err := m.AddVertex(types.Point{2, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop := types.PolygonLoop{inner}
+	for _, p := range []types.Point{{8, 2}, {8, 8}, {2, 8}} {
+		v, _ := m.AddVertex(p)
+		loop = append(loop, v)
+	}
+	m.perimeters = append(m.perimeters, loop)
+
+	_, err = m.AddHole([]types.Point{{4, 4}, {6, 4}, {6, 6}})
+	if !errors.Is(err, ErrHoleInMultiplePerimeters) {
+		t.Fatalf("expected ambiguous hole error, got %v", err)
+	}
+	var multi HoleInMultiplePerimetersError
+	if !errors.As(err, &multi) || !reflect.DeepEqual(multi.Perimeters, []int{0, 1}) {
+		t.Fatalf("expected perimeters [0 1], got %+v", err)
+	}
+}
+
+func TestLoopFeatureLimits(t *testing.T) {
+	sliver := []types.Point{{0, 0}, {30, 30}, {29, 31}, {-1, 1}}
+	square := []types.Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	m := NewMesh(WithMinFeatureSize(2), WithMinEdgeLength(1), WithMinAngle(20))
+	_, err := m.AddPerimeter(sliver)
+	var feature validation.FeatureError
+	if !errors.As(err, &feature) || feature.Check != validation.CheckMinFeatureSize {
+		t.Fatalf("expected the sliver to be rejected, got %v", err)
+	}
+	if _, err := m.AddPerimeter(square); err != nil {
+		t.Fatal(err)
+	}
+
+	holes := []struct {
+		points []types.Point
+		check  validation.Check
+	}{
+		{[]types.Point{{2, 2}, {2, 3}, {8, 3}, {8, 2}}, validation.CheckMinFeatureSize},
+		{[]types.Point{{2, 2}, {2, 5}, {2.5, 5}, {5, 2}}, validation.CheckMinEdgeLength},
+		{[]types.Point{{2, 2}, {2, 3}, {8, 2}}, validation.CheckMinAngle},
+	}
+	for _, h := range holes {
+		_, err := m.AddHole(h.points)
+		if !errors.As(err, &feature) || feature.Check != h.check {
+			t.Fatalf("expected hole %v to fail %s, got %v", h.points, h.check, err)
+		}
+	}
+	if _, err := m.AddHole([]types.Point{{2, 2}, {2, 5}, {5, 5}, {5, 2}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoopClearance(t *testing.T) {
+	m := NewMesh(WithMinClearance(1))
+	if _, err := m.AddPerimeter([]types.Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := m.AddHole([]types.Point{{0.5, 2}, {0.5, 8}, {5, 8}, {5, 2}})
+	var feature validation.FeatureError
+	if !errors.As(err, &feature) || feature.Check != validation.CheckMinClearance || feature.Value != 0.5 {
+		t.Fatalf("expected the hole to be 0.5 from the perimeter, got %v", err)
+	}
+
+	if _, err := m.AddHole([]types.Point{{1, 2}, {1, 8}, {4, 8}, {4, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AddHole([]types.Point{{4.5, 2}, {4.5, 8}, {8, 8}, {8, 2}}); !errors.As(err, &feature) {
+		t.Fatalf("expected the second hole to be too close to the first, got %v", err)
+	}
+}
@@ -3,29 +3,91 @@ package mesh
 import (
 	"encoding/json"
 	"os"
+	"slices"
 
 	"github.com/iceisfun/gomesh/types"
 )
 
 // MeshData represents the serializable state of a mesh.
 type MeshData struct {
-	Vertices   []types.Point        `json:"vertices"`
-	Perimeters []types.PolygonLoop  `json:"perimeters"`
-	Holes      []types.PolygonLoop  `json:"holes"`
-	Triangles  []types.Triangle     `json:"triangles"`
-	Config     SavedConfig          `json:"config"`
+	Vertices   []types.Point       `json:"vertices"`
+	Perimeters []types.PolygonLoop `json:"perimeters"`
+	Holes      []types.PolygonLoop `json:"holes"`
+	Triangles  []types.Triangle    `json:"triangles"`
+	Config     SavedConfig         `json:"config"`
+	// Removed lists vertices dropped by CollapseEdge, which keep their IDs.
+	Removed []types.VertexID `json:"removed,omitempty"`
 }
 
 // SavedConfig captures the mesh configuration for reconstruction.
 type SavedConfig struct {
 	Epsilon                          float64 `json:"epsilon"`
+	EpsilonRel                       float64 `json:"epsilon_rel,omitempty"`
+	ExactPredicates                  bool    `json:"exact_predicates,omitempty"`
 	MergeVertices                    bool    `json:"merge_vertices"`
 	MergeDistance                    float64 `json:"merge_distance"`
+	SnapGrid                         float64 `json:"snap_grid,omitempty"`
 	ValidateVertexInside             bool    `json:"validate_vertex_inside"`
 	ValidateEdgeIntersection         bool    `json:"validate_edge_intersection"`
 	ValidateEdgeCannotCrossPerimeter bool    `json:"validate_edge_cannot_cross_perimeter"`
 	ErrorOnDuplicateTriangle         bool    `json:"error_on_duplicate_triangle"`
 	ErrorOnOpposingDuplicate         bool    `json:"error_on_opposing_duplicate"`
+	ValidateTriangleOverlap          bool    `json:"validate_triangle_overlap"`
+	MinEdgeLength                    float64 `json:"min_edge_length,omitempty"`
+	MinAngle                         float64 `json:"min_angle,omitempty"`
+	MinFeatureSize                   float64 `json:"min_feature_size,omitempty"`
+	MinClearance                     float64 `json:"min_clearance,omitempty"`
+}
+
+// Config returns the persistable part of the mesh configuration.
+//
+// Debug hooks are not included.
+func (m *Mesh) Config() SavedConfig {
+	return SavedConfig{
+		Epsilon:                          m.cfg.tolerance.Abs,
+		EpsilonRel:                       m.cfg.tolerance.Rel,
+		ExactPredicates:                  m.cfg.exactPredicates,
+		MergeVertices:                    m.cfg.mergeVertices,
+		MergeDistance:                    m.cfg.mergeDistance,
+		SnapGrid:                         m.cfg.snapGrid.Cell,
+		ValidateVertexInside:             m.cfg.validateVertexInside,
+		ValidateEdgeIntersection:         m.cfg.validateEdgeIntersection,
+		ValidateEdgeCannotCrossPerimeter: m.cfg.validateEdgeCannotCrossPerimeter,
+		ErrorOnDuplicateTriangle:         m.cfg.errorOnDuplicateTriangle,
+		ErrorOnOpposingDuplicate:         m.cfg.errorOnOpposingDuplicate,
+		ValidateTriangleOverlap:          m.cfg.validateTriangleOverlapArea,
+		MinEdgeLength:                    m.cfg.minEdgeLength,
+		MinAngle:                         m.cfg.minAngle,
+		MinFeatureSize:                   m.cfg.minFeatureSize,
+		MinClearance:                     m.cfg.minClearance,
+	}
+}
+
+// Options returns the mesh options that reproduce the saved configuration.
+func (c SavedConfig) Options() []Option {
+	opts := []Option{WithTolerance(types.Epsilon{Abs: c.Epsilon, Rel: c.EpsilonRel})}
+	// WithMergeDistance and WithSnapGrid implicitly enable merging, so they
+	// must come first.
+	if c.MergeDistance > 0 {
+		opts = append(opts, WithMergeDistance(c.MergeDistance))
+	}
+	if c.SnapGrid > 0 {
+		opts = append(opts, WithSnapGrid(c.SnapGrid))
+	}
+	return append(opts,
+		WithMergeVertices(c.MergeVertices),
+		WithExactPredicates(c.ExactPredicates),
+		WithTriangleEnforceNoVertexInside(c.ValidateVertexInside),
+		WithEdgeIntersectionCheck(c.ValidateEdgeIntersection),
+		WithEdgeCannotCrossPerimeter(c.ValidateEdgeCannotCrossPerimeter),
+		WithDuplicateTriangleError(c.ErrorOnDuplicateTriangle),
+		WithDuplicateTriangleOpposingWinding(c.ErrorOnOpposingDuplicate),
+		WithTriangleOverlapCheck(c.ValidateTriangleOverlap),
+		WithMinEdgeLength(c.MinEdgeLength),
+		WithMinAngle(c.MinAngle),
+		WithMinFeatureSize(c.MinFeatureSize),
+		WithMinClearance(c.MinClearance),
+	)
 }
 
 // Save writes the mesh state to a JSON file.
@@ -42,17 +104,12 @@ func (m *Mesh) Save(filename string) error {
 		Perimeters: m.perimeters,
 		Holes:      m.holes,
 		Triangles:  m.triangles,
-		Config: SavedConfig{
-			Epsilon:                          m.cfg.epsilon,
-			MergeVertices:                    m.cfg.mergeVertices,
-			MergeDistance:                    m.cfg.mergeDistance,
-			ValidateVertexInside:             m.cfg.validateVertexInside,
-			ValidateEdgeIntersection:         m.cfg.validateEdgeIntersection,
-			ValidateEdgeCannotCrossPerimeter: m.cfg.validateEdgeCannotCrossPerimeter,
-			ErrorOnDuplicateTriangle:         m.cfg.errorOnDuplicateTriangle,
-			ErrorOnOpposingDuplicate:         m.cfg.errorOnOpposingDuplicate,
-		},
+		Config:     m.Config(),
+	}
+	for id := range m.removed {
+		data.Removed = append(data.Removed, id)
 	}
+	slices.Sort(data.Removed)
 
 	file, err := os.Create(filename)
 	if err != nil {
@@ -86,47 +143,23 @@ func Load(filename string) (*Mesh, error) {
 	}
 
 	// Create mesh with saved config
-	m := NewMesh(
-		WithEpsilon(data.Config.Epsilon),
-		WithMergeVertices(data.Config.MergeVertices),
-		WithMergeDistance(data.Config.MergeDistance),
-		WithTriangleEnforceNoVertexInside(data.Config.ValidateVertexInside),
-		WithEdgeIntersectionCheck(data.Config.ValidateEdgeIntersection),
-		WithEdgeCannotCrossPerimeter(data.Config.ValidateEdgeCannotCrossPerimeter),
-		WithDuplicateTriangleError(data.Config.ErrorOnDuplicateTriangle),
-		WithDuplicateTriangleOpposingWinding(data.Config.ErrorOnOpposingDuplicate),
-	)
+	m := NewMesh(data.Config.Options()...)
 
 	// Restore state directly (bypassing validation)
 	m.vertices = data.Vertices
 	m.perimeters = data.Perimeters
 	m.holes = data.Holes
 	m.triangles = data.Triangles
-
-	// Rebuild edge set
-	m.edgeSet = make(map[types.Edge]struct{})
-	for _, tri := range m.triangles {
-		edges := tri.Edges()
-		for _, edge := range edges {
-			m.edgeSet[edge] = struct{}{}
+	if len(data.Removed) > 0 {
+		m.removed = make(map[types.VertexID]struct{}, len(data.Removed))
+		for _, id := range data.Removed {
+			m.removed[id] = struct{}{}
 		}
 	}
 
-	// Rebuild triangle set
-	m.triangleSet = make(map[[3]types.VertexID]types.Triangle)
-	for _, tri := range m.triangles {
-		key := [3]types.VertexID{tri.V1(), tri.V2(), tri.V3()}
-		// Sort the key for canonical form
-		if key[0] > key[1] {
-			key[0], key[1] = key[1], key[0]
-		}
-		if key[1] > key[2] {
-			key[1], key[2] = key[2], key[1]
-		}
-		if key[0] > key[1] {
-			key[0], key[1] = key[1], key[0]
-		}
-		m.triangleSet[key] = tri
+	// Rebuild the lookup sets
+	for i, tri := range m.triangles {
+		m.indexTriangle(i, tri)
 	}
 
 	return m, nil
@@ -2,6 +2,7 @@ package mesh
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/iceisfun/gomesh/types"
@@ -83,8 +84,8 @@ func TestSaveLoad(t *testing.T) {
 	}
 
 	// Verify config was preserved
-	if m2.cfg.epsilon != m.cfg.epsilon {
-		t.Errorf("epsilon mismatch: got %v, want %v", m2.cfg.epsilon, m.cfg.epsilon)
+	if m2.cfg.tolerance != m.cfg.tolerance {
+		t.Errorf("tolerance mismatch: got %v, want %v", m2.cfg.tolerance, m.cfg.tolerance)
 	}
 
 	if m2.cfg.validateEdgeCannotCrossPerimeter != m.cfg.validateEdgeCannotCrossPerimeter {
@@ -101,6 +102,83 @@ func TestSaveLoad(t *testing.T) {
 	_, _ = v2, v3
 }
 
+func TestSaveLoadTolerance(t *testing.T) {
+	m := NewMesh(
+		WithTolerance(types.NewEpsilon(1e-9, 1e-12)),
+		WithExactPredicates(true),
+		WithSnapGrid(0.25),
+		WithTriangleOverlapCheck(true),
+	)
+
+	tmpfile := filepath.Join(t.TempDir(), "tolerance.json")
+	if err := m.Save(tmpfile); err != nil {
+		t.Fatalf("failed to save mesh: %v", err)
+	}
+	m2, err := Load(tmpfile)
+	if err != nil {
+		t.Fatalf("failed to load mesh: %v", err)
+	}
+
+	if got, want := m2.Config(), m.Config(); got != want {
+		t.Fatalf("config mismatch: got %+v, want %+v", got, want)
+	}
+	if m2.Tolerance() != types.NewEpsilon(1e-9, 1e-12) || !m2.Predicates().Exact || m2.SnapGrid().Cell != 0.25 {
+		t.Fatalf("tolerance not restored: %+v", m2.cfg)
+	}
+}
+
+func TestSaveLoadFeatureLimits(t *testing.T) {
+	m := NewMesh(WithMinEdgeLength(0.5), WithMinAngle(15), WithMinFeatureSize(2), WithMinClearance(1))
+
+	tmpfile := filepath.Join(t.TempDir(), "limits.json")
+	if err := m.Save(tmpfile); err != nil {
+		t.Fatalf("failed to save mesh: %v", err)
+	}
+	m2, err := Load(tmpfile)
+	if err != nil {
+		t.Fatalf("failed to load mesh: %v", err)
+	}
+
+	if got, want := m2.Config(), m.Config(); got != want {
+		t.Fatalf("config mismatch: got %+v, want %+v", got, want)
+	}
+	if m2.cfg.minEdgeLength != 0.5 || m2.cfg.minAngle != 15 || m2.cfg.minFeatureSize != 2 || m2.cfg.minClearance != 1 {
+		t.Fatalf("feature limits not restored: %+v", m2.cfg)
+	}
+
+	// The restored limits are enforced.
+	sliver := []types.Point{{X: 0, Y: 0}, {X: 30, Y: 30}, {X: 29, Y: 31}, {X: -1, Y: 1}}
+	if _, err := m2.AddPerimeter(sliver); err == nil {
+		t.Fatalf("expected the loaded mesh to reject a sliver")
+	}
+}
+
+func TestSaveLoadRemovedVertices(t *testing.T) {
+	m, c := buildFan(t)
+	v, _ := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5})
+	if _, err := m.CollapseEdge(types.NewEdge(v, 0)); err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "mesh.json")
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	m2, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if m2.NumVertices() != m.NumVertices() {
+		t.Fatalf("expected %d vertices, got %d", m.NumVertices(), m2.NumVertices())
+	}
+	if m2.IsValidVertexID(v) {
+		t.Fatalf("expected vertex %d to stay removed after load", v)
+	}
+	if !m2.IsValidVertexID(c) {
+		t.Fatalf("expected vertex %d to stay valid after load", c)
+	}
+}
+
 func TestSaveLoadPreservesGeometry(t *testing.T) {
 	// Create a simple mesh
 	m := NewMesh()
@@ -0,0 +1,322 @@
+package mesh
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/iceisfun/gomesh/types"
+	"github.com/iceisfun/gomesh/validation"
+)
+
+// sharedState tracks which pieces of mesh storage may be referenced by a
+// clone or snapshot. Shared storage is copied before it is modified in
+// place (copy-on-write); appends never need a copy because shared slices
+// are handed out with their capacity clipped to their length.
+type sharedState struct {
+	vertices  bool
+	triangles bool
+	loops     bool
+	sets      bool
+	removed   bool
+}
+
+// Snapshot is an immutable view of a mesh at a point in time.
+//
+// Taking a snapshot is O(1): it shares storage with the mesh, and the mesh
+// copies that storage lazily the first time it would modify it in place.
+type Snapshot struct {
+	vertices   []types.Point
+	triangles  []types.Triangle
+	perimeters []types.PolygonLoop
+	holes      []types.PolygonLoop
+	removed    map[types.VertexID]struct{}
+}
+
+// SnapshotDiff describes the changes between two snapshots.
+type SnapshotDiff struct {
+	// AddedVertices are vertex IDs live in the newer snapshot only.
+	AddedVertices []types.VertexID
+	// RemovedVertices are vertex IDs live in the older snapshot only,
+	// including vertices removed by CollapseEdge.
+	RemovedVertices []types.VertexID
+	// MovedVertices are vertex IDs live in both snapshots whose coordinates differ.
+	MovedVertices []types.VertexID
+	// AddedTriangles are triangles present in the newer snapshot only.
+	AddedTriangles []types.Triangle
+	// RemovedTriangles are triangles present in the older snapshot only.
+	RemovedTriangles []types.Triangle
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d SnapshotDiff) IsEmpty() bool {
+	return len(d.AddedVertices) == 0 && len(d.RemovedVertices) == 0 &&
+		len(d.MovedVertices) == 0 && len(d.AddedTriangles) == 0 &&
+		len(d.RemovedTriangles) == 0
+}
+
+// Snapshot captures the current state of the mesh without copying it.
+//
+// Example:
+//
+//	before := m.Snapshot()
+//	_ = m.AddTriangle(a, b, c)
+//	diff := mesh.Diff(before, m.Snapshot())
+func (m *Mesh) Snapshot() Snapshot {
+	m.shared.vertices = true
+	m.shared.triangles = true
+	m.shared.loops = true
+	m.shared.removed = true
+
+	return Snapshot{
+		vertices:   clip(m.vertices),
+		triangles:  clip(m.triangles),
+		perimeters: clip(m.perimeters),
+		holes:      clip(m.holes),
+		removed:    m.removed,
+	}
+}
+
+// Clone returns an independent mesh that initially shares all storage with m.
+//
+// Both meshes may be modified freely afterwards; storage is copied the first
+// time either side would modify it in place. The clone keeps m's options,
+// including debug hooks, and a copy of its journal.
+func (m *Mesh) Clone() *Mesh {
+	m.shared = sharedState{vertices: true, triangles: true, loops: true, sets: true, removed: true}
+
+	c := &Mesh{
+		vertices:     clip(m.vertices),
+		triangles:    clip(m.triangles),
+		cfg:          m.cfg,
+		edgeSet:      m.edgeSet,
+		triangleSet:  m.triangleSet,
+		edgeUses:     m.edgeUses,
+		triangleUses: m.triangleUses,
+		removed:      m.removed,
+		perimeters:   clip(m.perimeters),
+		holes:        clip(m.holes),
+		shared:       m.shared,
+		// The spatial index is rebuilt lazily on the first lookup.
+	}
+	if m.journal != nil {
+		c.journal = m.journal.clone()
+	}
+	return c
+}
+
+// NumVertices returns the number of vertices in the snapshot.
+func (s Snapshot) NumVertices() int {
+	return len(s.vertices)
+}
+
+// NumTriangles returns the number of triangles in the snapshot.
+func (s Snapshot) NumTriangles() int {
+	return len(s.triangles)
+}
+
+// GetVertex returns the coordinates of a vertex by ID.
+func (s Snapshot) GetVertex(id types.VertexID) types.Point {
+	return s.vertices[id]
+}
+
+// GetTriangle returns a triangle by index.
+func (s Snapshot) GetTriangle(idx int) types.Triangle {
+	return s.triangles[idx]
+}
+
+// GetVertices returns a copy of all vertex coordinates.
+func (s Snapshot) GetVertices() []types.Point {
+	out := make([]types.Point, len(s.vertices))
+	copy(out, s.vertices)
+	return out
+}
+
+// GetTriangles returns a copy of all triangles.
+func (s Snapshot) GetTriangles() []types.Triangle {
+	out := make([]types.Triangle, len(s.triangles))
+	copy(out, s.triangles)
+	return out
+}
+
+// Perimeters returns the perimeter loops captured by the snapshot.
+//
+// The returned slice must not be modified.
+func (s Snapshot) Perimeters() []types.PolygonLoop {
+	return s.perimeters
+}
+
+// Holes returns the hole loops captured by the snapshot.
+//
+// The returned slice must not be modified.
+func (s Snapshot) Holes() []types.PolygonLoop {
+	return s.holes
+}
+
+// Diff compares two snapshots and reports what changed going from older to newer.
+//
+// Vertices are compared by ID; a vertex removed by CollapseEdge counts as
+// absent even though its ID stays allocated. Triangles are compared by their canonical
+// vertex key, so a triangle whose winding changed is reported as removed and
+// added only if its vertex set changed. When newer was derived from older by
+// appends alone the comparison is proportional to the number of changes.
+func Diff(older, newer Snapshot) SnapshotDiff {
+	var d SnapshotDiff
+
+	// Vertices
+	common := len(older.vertices)
+	if len(newer.vertices) < common {
+		common = len(newer.vertices)
+	}
+	if !sharesPrefix(older.vertices, newer.vertices, common) {
+		for i := 0; i < common; i++ {
+			if older.vertices[i] != newer.vertices[i] {
+				d.MovedVertices = append(d.MovedVertices, types.VertexID(i))
+			}
+		}
+	}
+	for i := common; i < len(newer.vertices); i++ {
+		d.AddedVertices = append(d.AddedVertices, types.VertexID(i))
+	}
+	for i := common; i < len(older.vertices); i++ {
+		d.RemovedVertices = append(d.RemovedVertices, types.VertexID(i))
+	}
+	if len(older.removed) > 0 || len(newer.removed) > 0 {
+		diffRemoved(&d, older, newer, common)
+	}
+
+	// Triangles: fast path when newer only appended to older's storage.
+	if len(older.triangles) <= len(newer.triangles) &&
+		sharesPrefix(older.triangles, newer.triangles, len(older.triangles)) {
+		d.AddedTriangles = append(d.AddedTriangles, newer.triangles[len(older.triangles):]...)
+		return d
+	}
+
+	counts := make(map[[3]types.VertexID]int, len(older.triangles))
+	for _, tri := range older.triangles {
+		counts[validation.CanonicalTriangleKey(tri)]++
+	}
+	for _, tri := range newer.triangles {
+		key := validation.CanonicalTriangleKey(tri)
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		d.AddedTriangles = append(d.AddedTriangles, tri)
+	}
+	for _, tri := range older.triangles {
+		key := validation.CanonicalTriangleKey(tri)
+		if counts[key] > 0 {
+			counts[key]--
+			d.RemovedTriangles = append(d.RemovedTriangles, tri)
+		}
+	}
+
+	return d
+}
+
+// diffRemoved folds the removed-vertex sets of both snapshots into d.
+// A removed vertex is treated as absent from its snapshot.
+func diffRemoved(d *SnapshotDiff, older, newer Snapshot, common int) {
+	gone := func(s Snapshot, v types.VertexID) bool {
+		if int(v) >= len(s.vertices) {
+			return true
+		}
+		_, ok := s.removed[v]
+		return ok
+	}
+	d.MovedVertices = slices.DeleteFunc(d.MovedVertices, func(v types.VertexID) bool {
+		return gone(older, v) || gone(newer, v)
+	})
+	d.AddedVertices = slices.DeleteFunc(d.AddedVertices, func(v types.VertexID) bool { return gone(newer, v) })
+	d.RemovedVertices = slices.DeleteFunc(d.RemovedVertices, func(v types.VertexID) bool { return gone(older, v) })
+
+	for v := range newer.removed {
+		if int(v) < common && !gone(older, v) {
+			d.RemovedVertices = append(d.RemovedVertices, v)
+		}
+	}
+	for v := range older.removed {
+		if int(v) < common && !gone(newer, v) {
+			d.AddedVertices = append(d.AddedVertices, v)
+		}
+	}
+	slices.Sort(d.AddedVertices)
+	slices.Sort(d.RemovedVertices)
+}
+
+// sharesPrefix reports whether a and b have the same backing storage for
+// their first n elements. Because shared storage is never modified in place,
+// identical backing storage implies identical contents.
+func sharesPrefix[T any](a, b []T, n int) bool {
+	if n == 0 {
+		return true
+	}
+	return &a[0] == &b[0]
+}
+
+// clip returns s with its capacity limited to its length so that appends
+// through the returned slice never write into storage shared with s.
+func clip[T any](s []T) []T {
+	return s[:len(s):len(s)]
+}
+
+// ownVertices makes the vertex slice safe to modify in place.
+func (m *Mesh) ownVertices() {
+	if !m.shared.vertices {
+		return
+	}
+	m.vertices = append([]types.Point(nil), m.vertices...)
+	m.shared.vertices = false
+}
+
+// ownTriangles makes the triangle slice safe to modify in place.
+func (m *Mesh) ownTriangles() {
+	if !m.shared.triangles {
+		return
+	}
+	m.triangles = append([]types.Triangle(nil), m.triangles...)
+	m.shared.triangles = false
+}
+
+// ownLoops makes the perimeter and hole lists safe to modify in place.
+//
+// Individual loops are never modified in place; they are replaced.
+func (m *Mesh) ownLoops() {
+	if !m.shared.loops {
+		return
+	}
+	m.perimeters = append([]types.PolygonLoop(nil), m.perimeters...)
+	m.holes = append([]types.PolygonLoop(nil), m.holes...)
+	m.shared.loops = false
+}
+
+// ownSets makes the edge and triangle lookup maps safe to modify.
+func (m *Mesh) ownSets() {
+	if !m.shared.sets {
+		return
+	}
+	edgeSet := make(map[types.Edge]struct{}, len(m.edgeSet))
+	for e := range m.edgeSet {
+		edgeSet[e] = struct{}{}
+	}
+	triangleSet := make(map[[3]types.VertexID]types.Triangle, len(m.triangleSet))
+	for k, v := range m.triangleSet {
+		triangleSet[k] = v
+	}
+	m.edgeSet = edgeSet
+	m.triangleSet = triangleSet
+	m.edgeUses = maps.Clone(m.edgeUses)
+	m.triangleUses = maps.Clone(m.triangleUses)
+	m.shared.sets = false
+}
+
+// ownRemoved makes the removed-vertex set safe to modify, creating it if
+// needed.
+func (m *Mesh) ownRemoved() {
+	if m.removed == nil {
+		m.removed = make(map[types.VertexID]struct{})
+	} else if m.shared.removed {
+		m.removed = maps.Clone(m.removed)
+	}
+	m.shared.removed = false
+}
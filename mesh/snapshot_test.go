@@ -0,0 +1,173 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+func buildSquareMesh(t *testing.T, opts ...Option) (*Mesh, [4]types.VertexID) {
+	t.Helper()
+	m := NewMesh(opts...)
+	var ids [4]types.VertexID
+	pts := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	for i, p := range pts {
+		id, err := m.AddVertex(p)
+		if err != nil {
+			t.Fatalf("AddVertex failed: %v", err)
+		}
+		ids[i] = id
+	}
+	if err := m.AddTriangle(ids[0], ids[1], ids[2]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	return m, ids
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	m, ids := buildSquareMesh(t, WithEdgeIntersectionCheck(true))
+	c := m.Clone()
+
+	if err := c.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle on clone failed: %v", err)
+	}
+	if m.NumTriangles() != 1 || c.NumTriangles() != 2 {
+		t.Fatalf("expected 1 and 2 triangles, got %d and %d", m.NumTriangles(), c.NumTriangles())
+	}
+	if _, ok := m.EdgeSet()[types.NewEdge(ids[2], ids[3])]; ok {
+		t.Fatalf("clone edge leaked into original edge set")
+	}
+
+	// The original can keep growing without affecting the clone.
+	v, _ := m.AddVertex(types.Point{X: 20, Y: 0})
+	if err := m.AddTriangle(ids[1], v, ids[2]); err != nil {
+		t.Fatalf("AddTriangle on original failed: %v", err)
+	}
+	if c.NumVertices() != 4 {
+		t.Fatalf("expected clone to keep 4 vertices, got %d", c.NumVertices())
+	}
+	if c.GetTriangle(1) != types.NewTriangle(ids[0], ids[2], ids[3]) {
+		t.Fatalf("clone triangle overwritten: %v", c.GetTriangle(1))
+	}
+}
+
+func TestCloneMergesVertices(t *testing.T) {
+	m, ids := buildSquareMesh(t, WithMergeVertices(true), WithMergeDistance(0.5))
+	c := m.Clone()
+
+	id, err := c.AddVertex(types.Point{X: 10.1, Y: 0})
+	if err != nil {
+		t.Fatalf("AddVertex failed: %v", err)
+	}
+	if id != ids[1] {
+		t.Fatalf("expected clone to merge with vertex %d, got %d", ids[1], id)
+	}
+}
+
+func TestSnapshotIsImmutable(t *testing.T) {
+	m, ids := buildSquareMesh(t)
+	snap := m.Snapshot()
+
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	if snap.NumTriangles() != 1 {
+		t.Fatalf("expected snapshot to keep 1 triangle, got %d", snap.NumTriangles())
+	}
+
+	m.ownVertices()
+	m.vertices[0] = types.Point{X: -1, Y: -1}
+	if snap.GetVertex(ids[0]) != (types.Point{X: 0, Y: 0}) {
+		t.Fatalf("snapshot vertex changed: %v", snap.GetVertex(ids[0]))
+	}
+}
+
+func TestDiffAppendOnly(t *testing.T) {
+	m, ids := buildSquareMesh(t)
+	before := m.Snapshot()
+
+	v, _ := m.AddVertex(types.Point{X: 20, Y: 0})
+	if err := m.AddTriangle(ids[1], v, ids[2]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+
+	d := Diff(before, m.Snapshot())
+	if len(d.AddedVertices) != 1 || d.AddedVertices[0] != v {
+		t.Fatalf("unexpected added vertices: %v", d.AddedVertices)
+	}
+	if len(d.AddedTriangles) != 1 || len(d.RemovedTriangles) != 0 {
+		t.Fatalf("unexpected triangle diff: %+v", d)
+	}
+	if !Diff(before, before).IsEmpty() {
+		t.Fatalf("expected empty diff for identical snapshots")
+	}
+}
+
+func TestDiffReplacedTriangles(t *testing.T) {
+	m, ids := buildSquareMesh(t)
+	if err := m.AddTriangle(ids[0], ids[2], ids[3]); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	before := m.Snapshot()
+
+	m.ownTriangles()
+	m.triangles[1] = types.NewTriangle(ids[1], ids[3], ids[0])
+	m.ownVertices()
+	m.vertices[3] = types.Point{X: 0, Y: 11}
+
+	d := Diff(before, m.Snapshot())
+	if len(d.AddedTriangles) != 1 || len(d.RemovedTriangles) != 1 {
+		t.Fatalf("expected one added and one removed triangle, got %+v", d)
+	}
+	if d.RemovedTriangles[0] != types.NewTriangle(ids[0], ids[2], ids[3]) {
+		t.Fatalf("unexpected removed triangle %v", d.RemovedTriangles[0])
+	}
+	if len(d.MovedVertices) != 1 || d.MovedVertices[0] != ids[3] {
+		t.Fatalf("unexpected moved vertices: %v", d.MovedVertices)
+	}
+	if before.GetTriangle(1) != types.NewTriangle(ids[0], ids[2], ids[3]) {
+		t.Fatalf("snapshot modified by in-place edit")
+	}
+}
+
+func TestDiffCollapsedVertex(t *testing.T) {
+	m, c := buildFan(t, WithJournal(true))
+	v, err := m.SplitEdge(types.NewEdge(0, c), types.Point{X: 2.5, Y: 2.5})
+	if err != nil {
+		t.Fatalf("SplitEdge failed: %v", err)
+	}
+	before := m.Snapshot()
+	_ = m.Checkpoint("split")
+
+	keep, err := m.CollapseEdge(types.NewEdge(v, c))
+	if err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+	drop := v
+	if keep == v {
+		drop = c
+	}
+	after := m.Snapshot()
+
+	d := Diff(before, after)
+	if len(d.RemovedVertices) != 1 || d.RemovedVertices[0] != drop {
+		t.Fatalf("expected removed vertex %d, got %v", drop, d.RemovedVertices)
+	}
+	if len(d.MovedVertices) != 1 || d.MovedVertices[0] != keep {
+		t.Fatalf("expected moved vertex %d, got %v", keep, d.MovedVertices)
+	}
+	if len(d.AddedVertices) != 0 {
+		t.Fatalf("unexpected added vertices %v", d.AddedVertices)
+	}
+
+	if err := m.RestoreCheckpoint("split"); err != nil {
+		t.Fatalf("RestoreCheckpoint failed: %v", err)
+	}
+	d = Diff(after, m.Snapshot())
+	if len(d.AddedVertices) != 1 || d.AddedVertices[0] != drop || len(d.RemovedVertices) != 0 {
+		t.Fatalf("expected undo to restore vertex %d, got %+v", drop, d)
+	}
+	if !Diff(before, m.Snapshot()).IsEmpty() {
+		t.Fatalf("expected undo to match the earlier snapshot")
+	}
+}
@@ -10,18 +10,52 @@ import (
 
 // AddTriangle adds a triangle to the mesh with validation.
 func (m *Mesh) AddTriangle(v1, v2, v3 types.VertexID) error {
+	m.beginCommand(OpAddTriangle, []types.VertexID{v1, v2, v3}, nil)
+	err := m.addTriangle(v1, v2, v3)
+	m.endCommand(err)
+	if err != nil && m.cfg.debugRejectTriangle != nil {
+		m.cfg.debugRejectTriangle(types.NewTriangle(v1, v2, v3), err)
+	}
+	return err
+}
+
+func (m *Mesh) addTriangle(v1, v2, v3 types.VertexID) error {
 	if !m.IsValidVertexID(v1) || !m.IsValidVertexID(v2) || !m.IsValidVertexID(v3) {
 		return ErrInvalidVertexID
 	}
 
 	tri := types.NewTriangle(v1, v2, v3)
-	a := m.vertices[v1]
-	b := m.vertices[v2]
-	c := m.vertices[v3]
+	if err := m.validateNewTriangle(tri); err != nil {
+		return err
+	}
+
+	idx := len(m.triangles)
+	m.recordEffect(JournalEffect{Kind: EffectAddTriangle, Index: idx, Triangle: &tri})
+	m.triangles = append(m.triangles, tri)
+
+	for _, edge := range m.indexTriangle(idx, tri) {
+		if m.cfg.debugAddEdge != nil {
+			m.cfg.debugAddEdge(edge)
+		}
+	}
+
+	if m.cfg.debugAddTriangle != nil {
+		m.cfg.debugAddTriangle(tri)
+	}
+
+	return nil
+}
+
+// validateNewTriangle runs the checks a triangle must pass before it is
+// added to the mesh.
+func (m *Mesh) validateNewTriangle(tri types.Triangle) error {
+	a := m.vertices[tri.V1()]
+	b := m.vertices[tri.V2()]
+	c := m.vertices[tri.V3()]
 
 	err := validation.ValidateTriangle(tri, a, b, c, m.validationConfig(), m)
 	if err != nil {
-		return m.translateValidationError(err)
+		return m.translateValidationError(tri, err)
 	}
 
 	// Check if edges cross perimeter or hole boundaries
@@ -33,38 +67,110 @@ func (m *Mesh) AddTriangle(v1, v2, v3 types.VertexID) error {
 
 	// Check for volumetric triangle overlap
 	if m.cfg.validateTriangleOverlapArea {
-		// DEBUG: Log that we're checking
-		_ = m.cfg.validateTriangleOverlapArea // Prevent unused error
 		if err := m.validateTriangleDoesNotOverlap(tri, a, b, c); err != nil {
 			return err
 		}
 	}
 
-	m.triangles = append(m.triangles, tri)
+	// Run user-defined validators
+	return m.runTriangleValidators(tri, a, b, c)
+}
+
+// indexTriangle records the triangle at idx in the edge and triangle lookup
+// sets and the incidence lists, and returns the edges that were not tracked
+// before.
+func (m *Mesh) indexTriangle(idx int, tri types.Triangle) []types.Edge {
+	m.ownSets()
 
-	edges := tri.Edges()
-	for _, edge := range edges {
-		if _, exists := m.edgeSet[edge]; !exists {
+	var added []types.Edge
+	for _, edge := range tri.Edges() {
+		m.edgeUses[edge]++
+		if m.edgeUses[edge] == 1 {
 			m.edgeSet[edge] = struct{}{}
-			if m.cfg.debugAddEdge != nil {
-				m.cfg.debugAddEdge(edge)
-			}
+			added = append(added, edge)
 		}
 	}
 
 	key := validation.CanonicalTriangleKey(tri)
+	m.triangleUses[key]++
 	m.triangleSet[key] = tri
 
-	if m.cfg.debugAddTriangle != nil {
-		m.cfg.debugAddTriangle(tri)
+	for _, v := range tri {
+		m.addIncidence(v, idx)
 	}
+	return added
+}
 
-	return nil
+// unindexTriangle removes the triangle at idx from the lookup sets and the
+// incidence lists before it leaves the triangle list. Edges and keys still
+// used by other triangles are kept.
+func (m *Mesh) unindexTriangle(idx int, tri types.Triangle) {
+	m.ownSets()
+
+	for _, edge := range tri.Edges() {
+		if m.edgeUses[edge]--; m.edgeUses[edge] == 0 {
+			delete(m.edgeUses, edge)
+			delete(m.edgeSet, edge)
+		}
+	}
+
+	key := validation.CanonicalTriangleKey(tri)
+	if m.triangleUses[key]--; m.triangleUses[key] == 0 {
+		delete(m.triangleUses, key)
+		delete(m.triangleSet, key)
+	} else if m.triangleSet[key] == tri {
+		// A duplicate remains; it shares every vertex with tri.
+		for _, j := range m.incidentTriangles(tri[0]) {
+			if j != idx && validation.CanonicalTriangleKey(m.triangles[j]) == key {
+				m.triangleSet[key] = m.triangles[j]
+				break
+			}
+		}
+	}
+
+	for _, v := range tri {
+		m.removeIncidence(v, idx)
+	}
+}
+
+// removeTriangleAt removes the triangle at idx. The last triangle takes its
+// place, so only that triangle changes index.
+func (m *Mesh) removeTriangleAt(idx int) {
+	m.ownTriangles()
+	last := len(m.triangles) - 1
+	m.unindexTriangle(idx, m.triangles[idx])
+	if idx != last {
+		moved := m.triangles[last]
+		for _, v := range moved {
+			m.relabelIncidence(v, last, idx)
+		}
+		m.triangles[idx] = moved
+	}
+	m.triangles = truncate(m.triangles, last, m.shared.triangles)
+}
+
+// insertTriangleAt reverses removeTriangleAt: the triangle at idx, if any,
+// moves back to the end and tri takes its place.
+func (m *Mesh) insertTriangleAt(idx int, tri types.Triangle) {
+	m.ownTriangles()
+	if idx < len(m.triangles) {
+		moved := m.triangles[idx]
+		for _, v := range moved {
+			m.relabelIncidence(v, idx, len(m.triangles))
+		}
+		m.triangles = append(m.triangles, moved)
+		m.triangles[idx] = tri
+	} else {
+		m.triangles = append(m.triangles, tri)
+	}
+	m.indexTriangle(idx, tri)
 }
 
 func (m *Mesh) validationConfig() validation.Config {
 	return validation.Config{
-		Epsilon:                  m.cfg.epsilon,
+		Epsilon:                  m.cfg.tolerance.Abs,
+		Tolerance:                m.cfg.tolerance,
+		Exact:                    m.cfg.exactPredicates,
 		ErrorOnDuplicateTriangle: m.cfg.errorOnDuplicateTriangle,
 		ErrorOnOpposingDuplicate: m.cfg.errorOnOpposingDuplicate,
 		ValidateVertexInside:     m.cfg.validateVertexInside,
@@ -72,7 +178,22 @@ func (m *Mesh) validationConfig() validation.Config {
 	}
 }
 
-func (m *Mesh) translateValidationError(err error) error {
+func (m *Mesh) translateValidationError(tri types.Triangle, err error) error {
+	var inside validation.VertexInsideError
+	if errors.As(err, &inside) {
+		return VertexInsideTriangleError{Triangle: tri, Vertex: inside.Vertex, Point: inside.Point}
+	}
+	var cross validation.EdgeIntersectionError
+	if errors.As(err, &cross) {
+		return EdgeIntersectionError{
+			Triangle: tri,
+			Edge:     cross.Edge,
+			Existing: cross.Existing,
+			Point:    cross.Point,
+			Kind:     cross.Kind,
+		}
+	}
+
 	errs := validation.Errors()
 	switch {
 	case errors.Is(err, errs.Degenerate):
@@ -101,41 +222,34 @@ func (m *Mesh) translateValidationError(err error) error {
 func (m *Mesh) validateEdgesDoNotCrossPerimeters(tri types.Triangle) error {
 	triEdges := tri.Edges()
 
-	// Check against all perimeter edges
-	for _, perim := range m.perimeters {
-		for i := 0; i < len(perim); i++ {
-			next := (i + 1) % len(perim)
-			boundaryEdge := types.NewEdge(perim[i], perim[next])
+	// Check against all perimeter and hole edges
+	for kind, loops := range [][]types.PolygonLoop{m.perimeters, m.holes} {
+		for loopIdx, loop := range loops {
+			for i := 0; i < len(loop); i++ {
+				next := (i + 1) % len(loop)
+				boundaryEdge := types.NewEdge(loop[i], loop[next])
 
-			for _, triEdge := range triEdges {
-				// If the edges are the same (edge lands exactly on boundary), allow it
-				if triEdge == boundaryEdge {
-					continue
-				}
-
-				// Check if triangle edge crosses boundary edge
-				if m.edgesCross(triEdge, boundaryEdge) {
-					return ErrEdgeCrossesPerimeter
-				}
-			}
-		}
-	}
-
-	// Check against all hole edges
-	for _, hole := range m.holes {
-		for i := 0; i < len(hole); i++ {
-			next := (i + 1) % len(hole)
-			boundaryEdge := types.NewEdge(hole[i], hole[next])
-
-			for _, triEdge := range triEdges {
-				// If the edges are the same (edge lands exactly on boundary), allow it
-				if triEdge == boundaryEdge {
-					continue
-				}
+				for _, triEdge := range triEdges {
+					// If the edges are the same (edge lands exactly on boundary), allow it
+					if triEdge == boundaryEdge {
+						continue
+					}
 
-				// Check if triangle edge crosses boundary edge
-				if m.edgesCross(triEdge, boundaryEdge) {
-					return ErrEdgeCrossesPerimeter
+					// Check if triangle edge crosses boundary edge
+					if m.edgesCross(triEdge, boundaryEdge) {
+						point, _ := m.cfg.mode().SegmentIntersectionPoint(
+							m.vertices[triEdge.V1()], m.vertices[triEdge.V2()],
+							m.vertices[boundaryEdge.V1()], m.vertices[boundaryEdge.V2()],
+						)
+						return EdgeCrossesPerimeterError{
+							Triangle: tri,
+							Edge:     triEdge,
+							Boundary: boundaryEdge,
+							Hole:     kind == 1,
+							Loop:     loopIdx,
+							Point:    point,
+						}
+					}
 				}
 			}
 		}
@@ -144,7 +258,10 @@ func (m *Mesh) validateEdgesDoNotCrossPerimeters(tri types.Triangle) error {
 	// Check if any triangle edge goes outside the perimeter (via midpoint check)
 	for _, edge := range triEdges {
 		if m.edgeGoesOutsidePerimeter(edge.V1(), edge.V2()) {
-			return ErrEdgeCrossesPerimeter
+			a := m.vertices[edge.V1()]
+			b := m.vertices[edge.V2()]
+			midpoint := types.Point{X: (a.X + b.X) / 2.0, Y: (a.Y + b.Y) / 2.0}
+			return m.outsideDomainError(tri, edge, midpoint)
 		}
 	}
 
@@ -153,12 +270,42 @@ func (m *Mesh) validateEdgesDoNotCrossPerimeters(tri types.Triangle) error {
 	b := m.vertices[tri.V2()]
 	c := m.vertices[tri.V3()]
 	if m.triangleGoesOutsidePerimeter(a, b, c) {
-		return ErrEdgeCrossesPerimeter
+		return m.triangleOutsideError(tri, a, b, c)
 	}
 
 	return nil
 }
 
+// triangleOutsideError reports a triangle whose centroid lies outside the
+// meshable domain.
+func (m *Mesh) triangleOutsideError(tri types.Triangle, a, b, c types.Point) error {
+	centroid := types.Point{
+		X: (a.X + b.X + c.X) / 3.0,
+		Y: (a.Y + b.Y + c.Y) / 3.0,
+	}
+	return m.outsideDomainError(tri, types.Edge{types.NilVertex, types.NilVertex}, centroid)
+}
+
+// outsideDomainError builds the error for a sample point of tri that lies
+// outside every perimeter or inside a hole.
+func (m *Mesh) outsideDomainError(tri types.Triangle, edge types.Edge, p types.Point) error {
+	err := EdgeCrossesPerimeterError{
+		Triangle: tri,
+		Edge:     edge,
+		Boundary: types.Edge{types.NilVertex, types.NilVertex},
+		Loop:     -1,
+		Point:    p,
+	}
+	for i, hole := range m.holes {
+		if m.cfg.mode().PointInPolygon(p, m.getPolygonPoints(hole)) {
+			err.Hole = true
+			err.Loop = i
+			break
+		}
+	}
+	return err
+}
+
 // edgesCross checks if two edges cross each other (proper intersection).
 //
 // Returns true only for proper intersections where the edges cross each other.
@@ -170,7 +317,7 @@ func (m *Mesh) edgesCross(e1, e2 types.Edge) bool {
 	b2 := m.vertices[e2.V2()]
 
 	// Use the predicates package to check for proper intersection
-	intersects, proper := predicates.SegmentsIntersect(a1, a2, b1, b2, m.cfg.epsilon)
+	intersects, proper := m.cfg.mode().SegmentsIntersect(a1, a2, b1, b2)
 	return intersects && proper
 }
 
@@ -191,20 +338,21 @@ func (m *Mesh) validateTriangleDoesNotOverlap(tri types.Triangle, a, b, c types.
 		a2 := m.vertices[existingTri.V1()]
 		b2 := m.vertices[existingTri.V2()]
 		c2 := m.vertices[existingTri.V3()]
+		eps := m.cfg.tolerance.TolForPoints(a, b, c, a2, b2, c2)
 
 		// Calculate intersection area in BOTH directions and take the maximum.
 		// The Sutherland-Hodgman clipping algorithm is not symmetric, so we need
 		// to check both orders to catch all overlaps.
-		area1 := predicates.TriangleIntersectionArea(a, b, c, a2, b2, c2, m.cfg.epsilon)
-		area2 := predicates.TriangleIntersectionArea(a2, b2, c2, a, b, c, m.cfg.epsilon)
+		area1 := predicates.TriangleIntersectionArea(a, b, c, a2, b2, c2, eps)
+		area2 := predicates.TriangleIntersectionArea(a2, b2, c2, a, b, c, eps)
 
 		intersectionArea := area1
 		if area2 > intersectionArea {
 			intersectionArea = area2
 		}
 
-		// If there's meaningful overlap (area > epsilon), reject
-		if intersectionArea > m.cfg.epsilon {
+		// Reject overlaps thicker than a sliver along the boundary
+		if intersectionArea > m.overlapTolerance(tri, existingTri) {
 			return ErrTriangleOverlap{
 				TriangleIndex:    i,
 				IntersectionArea: intersectionArea,
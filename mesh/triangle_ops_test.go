@@ -1,6 +1,7 @@
 package mesh
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/iceisfun/gomesh/types"
@@ -87,9 +88,14 @@ func TestVertexInsideValidation(t *testing.T) {
 		t.Fatalf("failed to add outer vertex: %v", err)
 	}
 
-	if err := m.AddTriangle(a, b, d); err != ErrVertexInsideTriangle {
+	err = m.AddTriangle(a, b, d)
+	if !errors.Is(err, ErrVertexInsideTriangle) {
 		t.Fatalf("expected vertex-inside error, got %v", err)
 	}
+	var inside VertexInsideTriangleError
+	if !errors.As(err, &inside) || inside.Vertex != 3 || inside.Point != (types.Point{1, 0.5}) {
+		t.Fatalf("expected vertex 3 at (1, 0.5) to be reported, got %+v", err)
+	}
 }
 
 func TestEdgeIntersectionValidation(t *testing.T) {
@@ -105,9 +111,18 @@ func TestEdgeIntersectionValidation(t *testing.T) {
 		t.Fatalf("unexpected error adding first triangle: %v", err)
 	}
 
-	if err := m.AddTriangle(v3, v1, v4); err != ErrEdgeIntersection {
+	err := m.AddTriangle(v3, v1, v4)
+	if !errors.Is(err, ErrEdgeIntersection) {
 		t.Fatalf("expected edge intersection error, got %v", err)
 	}
+	var cross EdgeIntersectionError
+	if !errors.As(err, &cross) {
+		t.Fatalf("expected an EdgeIntersectionError, got %T", err)
+	}
+	if cross.Edge != types.NewEdge(v4, v3) || cross.Existing != types.NewEdge(v0, v1) ||
+		cross.Point != (types.Point{1, 0}) || cross.Kind != types.IntersectProper {
+		t.Fatalf("unexpected intersection details %+v", cross)
+	}
 }
 
 func TestEdgeCannotCrossPerimeter(t *testing.T) {
@@ -137,9 +152,16 @@ func TestEdgeCannotCrossPerimeter(t *testing.T) {
 	// Triangle with edge crossing perimeter (should fail)
 	// Edge from v1 to v2 stays inside, but trying to connect to outside
 	v4, _ := m.AddVertex(types.Point{15, 5})
-	if err := m.AddTriangle(v1, v2, v4); err != ErrEdgeCrossesPerimeter {
+	err = m.AddTriangle(v1, v2, v4)
+	if !errors.Is(err, ErrEdgeCrossesPerimeter) {
 		t.Fatalf("expected perimeter crossing error, got %v", err)
 	}
+	// AddPerimeter created its own vertices 4-7, so the right edge is 5-6.
+	var crossing EdgeCrossesPerimeterError
+	if !errors.As(err, &crossing) || crossing.Hole || crossing.Loop != 0 ||
+		crossing.Boundary != types.NewEdge(5, 6) || crossing.Point.X != 10 {
+		t.Fatalf("expected the right perimeter edge to be reported, got %+v", err)
+	}
 
 	// Triangle entirely inside perimeter (should succeed)
 	if err := m.AddTriangle(v1, v2, v3); err != nil {
@@ -183,9 +205,14 @@ func TestEdgeCannotCrossHole(t *testing.T) {
 	// Triangle with edge crossing hole boundary (should fail)
 	// Try to connect vertex inside hole region to vertex outside
 	v3, _ := m.AddVertex(types.Point{10, 10}) // Inside hole
-	if err := m.AddTriangle(v1, v2, v3); err != ErrEdgeCrossesPerimeter {
+	err = m.AddTriangle(v1, v2, v3)
+	if !errors.Is(err, ErrEdgeCrossesPerimeter) {
 		t.Fatalf("expected hole crossing error, got %v", err)
 	}
+	var crossing EdgeCrossesPerimeterError
+	if !errors.As(err, &crossing) || !crossing.Hole || crossing.Loop != 0 {
+		t.Fatalf("expected hole 0 to be reported, got %+v", err)
+	}
 }
 
 func TestOverlapTriangleDefault(t *testing.T) {
@@ -321,7 +348,7 @@ func TestTriangleSpanningConcavePerimeter(t *testing.T) {
 	err = m.AddTriangle(2, 4, 8)
 	if err == nil {
 		t.Error("Expected error when adding triangle that spans outside concave perimeter, got nil")
-	} else if err != ErrEdgeCrossesPerimeter {
+	} else if !errors.Is(err, ErrEdgeCrossesPerimeter) {
 		t.Logf("Got error (expected ErrEdgeCrossesPerimeter): %v", err)
 	}
 
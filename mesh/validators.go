@@ -0,0 +1,114 @@
+package mesh
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// ErrTriangleRejected indicates a user-defined triangle validator rejected a triangle.
+var ErrTriangleRejected = errors.New("gomesh: triangle rejected by validator")
+
+// TriangleValidator is a user-defined check run by AddTriangle and by the
+// local operators (FlipEdge, SplitEdge, CollapseEdge and SplitTriangle) for
+// each triangle they create.
+//
+// It receives the candidate triangle, the coordinates of its vertices and a
+// read-only view of the mesh without that triangle; for a local operator
+// this is the mesh after the operation. A non-nil error rejects the
+// triangle and is returned wrapped in a ValidatorError.
+type TriangleValidator func(tri types.Triangle, a, b, c types.Point, view MeshView) error
+
+// MeshView is the read-only access to a mesh given to triangle validators.
+//
+// Slices returned by the view are copies; modifying them does not affect
+// the mesh.
+type MeshView interface {
+	NumVertices() int
+	NumTriangles() int
+	GetVertex(id types.VertexID) types.Point
+	GetTriangle(idx int) types.Triangle
+	GetTriangleCoords(idx int) (types.Point, types.Point, types.Point)
+	VertexTriangles(id types.VertexID) []int
+	GetPerimeters() []types.PolygonLoop
+	GetHoles() []types.PolygonLoop
+	Epsilon() float64
+	Tolerance() types.Epsilon
+	Predicates() predicates.Mode
+}
+
+// meshView hides the mutating methods of a Mesh from validators.
+type meshView struct {
+	m *Mesh
+}
+
+func (v meshView) NumVertices() int                        { return v.m.NumVertices() }
+func (v meshView) NumTriangles() int                       { return v.m.NumTriangles() }
+func (v meshView) GetVertex(id types.VertexID) types.Point { return v.m.GetVertex(id) }
+func (v meshView) GetTriangle(idx int) types.Triangle      { return v.m.GetTriangle(idx) }
+func (v meshView) GetTriangleCoords(idx int) (types.Point, types.Point, types.Point) {
+	return v.m.GetTriangleCoords(idx)
+}
+func (v meshView) VertexTriangles(id types.VertexID) []int { return v.m.VertexTriangles(id) }
+func (v meshView) GetPerimeters() []types.PolygonLoop      { return cloneLoops(v.m.perimeters) }
+func (v meshView) GetHoles() []types.PolygonLoop           { return cloneLoops(v.m.holes) }
+func (v meshView) Epsilon() float64                        { return v.m.Epsilon() }
+func (v meshView) Tolerance() types.Epsilon                { return v.m.Tolerance() }
+func (v meshView) Predicates() predicates.Mode             { return v.m.Predicates() }
+
+// cloneLoops deep-copies a list of loops.
+func cloneLoops(loops []types.PolygonLoop) []types.PolygonLoop {
+	out := make([]types.PolygonLoop, len(loops))
+	for i, loop := range loops {
+		out[i] = slices.Clone(loop)
+	}
+	return out
+}
+
+// namedValidator is a registered TriangleValidator.
+type namedValidator struct {
+	name string
+	fn   TriangleValidator
+}
+
+// ValidatorError reports a triangle rejected by a user-defined validator.
+//
+// It matches ErrTriangleRejected with errors.Is, and errors.As reaches the
+// validator's own error through Unwrap.
+type ValidatorError struct {
+	Validator string
+	Triangle  types.Triangle
+	Err       error
+}
+
+func (e ValidatorError) Error() string {
+	return fmt.Sprintf("gomesh: triangle %v rejected by validator %q: %v", e.Triangle, e.Validator, e.Err)
+}
+
+// Unwrap returns the validator's error.
+func (e ValidatorError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTriangleRejected.
+func (e ValidatorError) Is(target error) bool {
+	return target == ErrTriangleRejected
+}
+
+// runTriangleValidators applies the registered validators in order and
+// returns the first rejection.
+func (m *Mesh) runTriangleValidators(tri types.Triangle, a, b, c types.Point) error {
+	if len(m.cfg.triangleValidators) == 0 {
+		return nil
+	}
+	view := meshView{m: m}
+	for _, v := range m.cfg.triangleValidators {
+		if err := v.fn(tri, a, b, c, view); err != nil {
+			return ValidatorError{Validator: v.name, Triangle: tri, Err: err}
+		}
+	}
+	return nil
+}
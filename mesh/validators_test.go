@@ -0,0 +1,154 @@
+package mesh
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// zoneAreaError is a typed error returned by a test validator.
+type zoneAreaError struct {
+	Zone string
+	Area float64
+}
+
+func (e zoneAreaError) Error() string {
+	return fmt.Sprintf("area %g too large for zone %s", e.Area, e.Zone)
+}
+
+func TestTriangleValidators(t *testing.T) {
+	// Triangles left of x=5 are in the "fine" zone with a maximum area of 1.
+	maxArea := func(tri types.Triangle, a, b, c types.Point, view MeshView) error {
+		area := math.Abs(predicates.Area2(a, b, c)) / 2
+		if (a.X+b.X+c.X)/3 < 5 && area > 1 {
+			return zoneAreaError{Zone: "fine", Area: area}
+		}
+		return nil
+	}
+	// No triangle may use vertex 0 once the mesh has two triangles.
+	var calls int
+	forbidden := func(tri types.Triangle, a, b, c types.Point, view MeshView) error {
+		calls++
+		if view.NumTriangles() >= 2 && (tri.V1() == 0 || tri.V2() == 0 || tri.V3() == 0) {
+			return errors.New("vertex 0 is full")
+		}
+		return nil
+	}
+
+	m := NewMesh(WithTriangleValidator("max-area", maxArea), WithTriangleValidator("forbidden", forbidden))
+	var ids []types.VertexID
+	for _, p := range []types.Point{{0, 0}, {1, 0}, {0, 1}, {2, 3}, {10, 0}, {10, 10}, {-1, 0.5}} {
+		id, _ := m.AddVertex(p)
+		ids = append(ids, id)
+	}
+
+	err := m.AddTriangle(ids[0], ids[1], ids[3])
+	if !errors.Is(err, ErrTriangleRejected) {
+		t.Fatalf("expected a rejected triangle, got %v", err)
+	}
+	var zone zoneAreaError
+	var verr ValidatorError
+	if !errors.As(err, &zone) || zone.Zone != "fine" || !errors.As(err, &verr) || verr.Validator != "max-area" {
+		t.Fatalf("expected the zone error from max-area, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatal("expected validators to stop at the first rejection")
+	}
+
+	for _, tri := range [][3]int{{0, 1, 2}, {4, 5, 3}} {
+		if err := m.AddTriangle(ids[tri[0]], ids[tri[1]], ids[tri[2]]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.AddTriangle(ids[0], ids[2], ids[6]); !errors.As(err, &verr) || verr.Validator != "forbidden" {
+		t.Fatalf("expected the forbidden validator to reject a third triangle at vertex 0, got %v", err)
+	}
+	if m.NumTriangles() != 2 {
+		t.Fatalf("expected rejected triangles to be left out, got %d", m.NumTriangles())
+	}
+
+	// Built-in checks run first.
+	if err := m.AddTriangle(ids[1], ids[1], ids[2]); !errors.Is(err, ErrDegenerateTriangle) {
+		t.Fatalf("expected the built-in degenerate check, got %v", err)
+	}
+}
+
+func TestTriangleValidatorView(t *testing.T) {
+	var view MeshView
+	m := NewMesh(WithTriangleValidator("capture", func(tri types.Triangle, a, b, c types.Point, v MeshView) error {
+		view = v
+		return nil
+	}))
+	for _, p := range []types.Point{{0, 0}, {1, 0}, {0, 1}} {
+		m.AddVertex(p)
+	}
+	if err := m.AddTriangle(0, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := view.(*Mesh); ok {
+		t.Fatal("validators must not receive the mutable mesh")
+	}
+	if view.NumTriangles() != 1 || view.GetVertex(1) != (types.Point{1, 0}) {
+		t.Fatal("expected the view to track the mesh")
+	}
+}
+
+func TestTriangleValidatorViewCopiesLoops(t *testing.T) {
+	m := NewMesh(WithTriangleValidator("scribble", func(tri types.Triangle, a, b, c types.Point, v MeshView) error {
+		for _, loops := range [][]types.PolygonLoop{v.GetPerimeters(), v.GetHoles()} {
+			for _, loop := range loops {
+				loop[0] = types.NilVertex
+			}
+		}
+		return nil
+	}))
+	if _, err := m.AddPerimeter([]types.Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AddHole([]types.Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddTriangle(0, 1, 4); err != nil {
+		t.Fatal(err)
+	}
+	if m.Perimeters()[0][0] != 0 || m.Holes()[0][0] != 4 {
+		t.Fatalf("validator modified the mesh loops: %v %v", m.Perimeters(), m.Holes())
+	}
+}
+
+// TestTriangleValidatorConcurrentView runs validators from the parallel
+// candidate search; run with -race to check that the view is read-only.
+func TestTriangleValidatorConcurrentView(t *testing.T) {
+	m := NewMesh(WithTriangleValidator("max-degree", func(tri types.Triangle, a, b, c types.Point, v MeshView) error {
+		for _, id := range tri {
+			if len(v.VertexTriangles(id)) >= 2 {
+				return errors.New("vertex already has two triangles")
+			}
+		}
+		return nil
+	}))
+	for _, p := range []types.Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {2, 2}, {8, 2}} {
+		m.AddVertex(p)
+	}
+	for _, tri := range [][3]types.VertexID{{0, 1, 4}, {1, 2, 4}} {
+		if err := m.AddTriangle(tri[0], tri[1], tri[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	candidates := m.VertexFindTriangleCandidates(5)
+	if len(candidates) == 0 {
+		t.Fatal("expected some candidates")
+	}
+	for _, c := range candidates {
+		for _, id := range []types.VertexID{c.V1, c.V2, c.V3} {
+			if id == 1 || id == 4 {
+				t.Fatalf("candidate %v uses a vertex with two triangles", c)
+			}
+		}
+	}
+}
@@ -8,11 +8,21 @@ import (
 
 // AddVertex adds a vertex to the mesh or returns an existing nearby vertex.
 func (m *Mesh) AddVertex(p types.Point) (types.VertexID, error) {
+	m.beginCommand(OpAddVertex, nil, []types.Point{p})
+	id, err := m.addVertex(p)
+	m.endCommand(err)
+	return id, err
+}
+
+func (m *Mesh) addVertex(p types.Point) (types.VertexID, error) {
+	p = m.cfg.snapGrid.Snap(p)
 	if m.cfg.mergeVertices {
 		if m.vertexIndex == nil {
 			m.vertexIndex = spatial.NewHashGrid(m.cfg.effectiveMergeDistance())
 			for id, existing := range m.vertices {
-				m.vertexIndex.AddVertex(types.VertexID(id), existing)
+				if m.IsValidVertexID(types.VertexID(id)) {
+					m.vertexIndex.AddVertex(types.VertexID(id), existing)
+				}
 			}
 		}
 
@@ -30,6 +40,7 @@ func (m *Mesh) AddVertex(p types.Point) (types.VertexID, error) {
 
 	id := types.VertexID(len(m.vertices))
 	m.vertices = append(m.vertices, p)
+	m.recordEffect(JournalEffect{Kind: EffectAddVertex, Vertex: id, Point: &p})
 
 	if m.vertexIndex != nil {
 		m.vertexIndex.AddVertex(id, p)
@@ -44,6 +55,7 @@ func (m *Mesh) AddVertex(p types.Point) (types.VertexID, error) {
 
 // FindVertexNear searches for a vertex within merge distance of p.
 func (m *Mesh) FindVertexNear(p types.Point) (types.VertexID, bool) {
+	p = m.cfg.snapGrid.Snap(p)
 	if m.vertexIndex == nil {
 		m.buildVertexIndex()
 	}
@@ -71,7 +83,113 @@ func (m *Mesh) buildVertexIndex() {
 
 	m.vertexIndex = spatial.NewHashGrid(radius)
 	for id, p := range m.vertices {
-		m.vertexIndex.AddVertex(types.VertexID(id), p)
+		if m.IsValidVertexID(types.VertexID(id)) {
+			m.vertexIndex.AddVertex(types.VertexID(id), p)
+		}
 	}
 	m.vertexIndex.Build()
 }
+
+// MoveVertex moves a vertex to p.
+//
+// The move is rejected with ErrInvertedTriangle if any triangle using the
+// vertex would change orientation or become degenerate. Perimeter and hole
+// loops reference vertices by ID and follow the move.
+func (m *Mesh) MoveVertex(id types.VertexID, p types.Point) error {
+	m.beginCommand(OpMoveVertex, []types.VertexID{id}, []types.Point{p})
+	err := m.moveVertex(id, p)
+	m.endCommand(err)
+	if m.cfg.debugMoveVertex != nil {
+		m.cfg.debugMoveVertex(id, p, err)
+	}
+	return err
+}
+
+func (m *Mesh) moveVertex(id types.VertexID, p types.Point) error {
+	if !m.IsValidVertexID(id) {
+		return ErrInvalidVertexID
+	}
+	p = m.cfg.snapGrid.Snap(p)
+
+	for _, idx := range m.incidentTriangles(id) {
+		tri := m.triangles[idx]
+		want := m.triangleOrient(tri, types.NilVertex, types.Point{})
+		if want == 0 || m.triangleOrient(tri, id, p) != want {
+			return ErrInvertedTriangle
+		}
+	}
+
+	prev := m.vertices[id]
+	if prev == p {
+		return nil
+	}
+	m.commitEffect(JournalEffect{Kind: EffectMoveVertex, Vertex: id, Point: &p, PreviousPoint: &prev})
+	return nil
+}
+
+// VertexTriangles returns the indices of the triangles that use a vertex.
+func (m *Mesh) VertexTriangles(id types.VertexID) []int {
+	if !m.IsValidVertexID(id) {
+		return nil
+	}
+	return append([]int(nil), m.incidentTriangles(id)...)
+}
+
+// incidentTriangles returns the cached vertex-to-triangle incidence list.
+//
+// The lists are built lazily on first use and then kept up to date by
+// indexTriangle and unindexTriangle.
+func (m *Mesh) incidentTriangles(id types.VertexID) []int {
+	if m.incidence == nil {
+		m.incidence = make([][]int, len(m.vertices))
+		for i, tri := range m.triangles {
+			for _, v := range tri {
+				m.incidence[v] = append(m.incidence[v], i)
+			}
+		}
+	}
+	if int(id) >= len(m.incidence) {
+		return nil
+	}
+	return m.incidence[id]
+}
+
+// addIncidence records that the triangle at idx uses v. The lists are only
+// maintained once they have been built.
+func (m *Mesh) addIncidence(v types.VertexID, idx int) {
+	if m.incidence == nil {
+		return
+	}
+	for int(v) >= len(m.incidence) {
+		m.incidence = append(m.incidence, nil)
+	}
+	m.incidence[v] = append(m.incidence[v], idx)
+}
+
+// removeIncidence drops the triangle at idx from the list of v.
+func (m *Mesh) removeIncidence(v types.VertexID, idx int) {
+	if int(v) >= len(m.incidence) {
+		return
+	}
+	list := m.incidence[v]
+	for k, j := range list {
+		if j == idx {
+			list[k] = list[len(list)-1]
+			m.incidence[v] = list[:len(list)-1]
+			return
+		}
+	}
+}
+
+// relabelIncidence records that a triangle of v moved from index from to to.
+func (m *Mesh) relabelIncidence(v types.VertexID, from, to int) {
+	if int(v) >= len(m.incidence) {
+		return
+	}
+	for k, j := range m.incidence[v] {
+		if j == from {
+			m.incidence[v][k] = to
+			return
+		}
+	}
+}
@@ -39,3 +39,47 @@ func TestFindVertexNear(t *testing.T) {
 		t.Fatalf("expected to locate nearby vertex")
 	}
 }
+
+func TestMoveVertex(t *testing.T) {
+	m, c := buildFan(t, WithJournal(true))
+
+	if err := m.MoveVertex(c, types.Point{X: 6, Y: 4}); err != nil {
+		t.Fatalf("MoveVertex failed: %v", err)
+	}
+	if m.GetVertex(c) != (types.Point{X: 6, Y: 4}) {
+		t.Fatalf("vertex not moved: %v", m.GetVertex(c))
+	}
+	if err := m.MoveVertex(c, types.Point{X: 12, Y: 5}); err != ErrInvertedTriangle {
+		t.Fatalf("expected ErrInvertedTriangle, got %v", err)
+	}
+	if len(m.VertexTriangles(c)) != 4 {
+		t.Fatalf("expected 4 incident triangles, got %v", m.VertexTriangles(c))
+	}
+
+	if err := m.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if m.GetVertex(c) != (types.Point{X: 5, Y: 5}) {
+		t.Fatalf("expected undo to restore position, got %v", m.GetVertex(c))
+	}
+}
+
+func TestAddVertexWithSnapGrid(t *testing.T) {
+	m := NewMesh(WithSnapGrid(0.5))
+	id1, _ := m.AddVertex(types.Point{X: 0.1 + 0.2, Y: 1.1})
+	id2, _ := m.AddVertex(types.Point{X: 0.5 - 1e-12, Y: 1 + 1e-12})
+	if id1 != id2 {
+		t.Fatalf("expected drifted points to snap onto one vertex")
+	}
+	if got := m.GetVertex(id1); got != (types.Point{X: 0.5, Y: 1}) {
+		t.Fatalf("vertex not snapped to the grid: %v", got)
+	}
+	if m.SnapGrid().Cell != 0.5 {
+		t.Fatalf("unexpected snap grid %v", m.SnapGrid())
+	}
+
+	found, ok := m.FindVertexNear(types.Point{X: 0.6, Y: 0.9})
+	if !ok || found != id1 {
+		t.Fatalf("expected FindVertexNear to search at the snapped point")
+	}
+}
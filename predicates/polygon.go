@@ -1,9 +1,16 @@
 package predicates
 
-import "github.com/iceisfun/gomesh/types"
+import (
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
 
 // PointInPolygonRayCast tests if a point is inside a polygon using ray casting.
 func PointInPolygonRayCast(p types.Point, poly []types.Point, eps float64) bool {
+	return pointInPolygon(p, poly, absTol(eps))
+}
+
+func pointInPolygon(p types.Point, poly []types.Point, tol tolerance) bool {
 	n := len(poly)
 	if n == 0 {
 		return false
@@ -12,7 +19,7 @@ func PointInPolygonRayCast(p types.Point, poly []types.Point, eps float64) bool
 	// Boundary check first.
 	for i := 0; i < n; i++ {
 		j := (i + 1) % n
-		if PointOnSegment(p, poly[i], poly[j], eps) {
+		if pointOnSegment(p, poly[i], poly[j], tol) {
 			return true
 		}
 	}
@@ -22,12 +29,25 @@ func PointInPolygonRayCast(p types.Point, poly []types.Point, eps float64) bool
 		iP := poly[i]
 		jP := poly[j]
 		diff := (iP.Y > p.Y) != (jP.Y > p.Y)
-		if diff {
-			t := (p.Y - iP.Y) / (jP.Y - iP.Y)
-			x := iP.X + t*(jP.X-iP.X)
-			if x > p.X {
+		if !diff {
+			continue
+		}
+		if tol.exact() {
+			// The edge crosses the ray right of p iff p is left of the
+			// edge directed upwards.
+			lo, hi := iP, jP
+			if lo.Y > hi.Y {
+				lo, hi = hi, lo
+			}
+			if robust.Orient2D(lo, hi, p) > 0 {
 				inside = !inside
 			}
+			continue
+		}
+		t := (p.Y - iP.Y) / (jP.Y - iP.Y)
+		x := iP.X + t*(jP.X-iP.X)
+		if x > p.X {
+			inside = !inside
 		}
 	}
 
@@ -39,6 +59,10 @@ func PointInPolygonRayCast(p types.Point, poly []types.Point, eps float64) bool
 // Returns true if any non-adjacent edges intersect.
 // Adjacent edges (sharing a vertex) are allowed to touch.
 func PolygonSelfIntersects(poly []types.Point, eps float64) bool {
+	return polygonSelfIntersects(poly, absTol(eps))
+}
+
+func polygonSelfIntersects(poly []types.Point, tol tolerance) bool {
 	n := len(poly)
 	if n < 3 {
 		return false
@@ -61,7 +85,7 @@ func PolygonSelfIntersects(poly []types.Point, eps float64) bool {
 			b2 := poly[nextJ]
 
 			// Check for proper intersection (not just touching at shared vertex)
-			intersects, proper := SegmentsIntersect(a1, a2, b1, b2, eps)
+			intersects, proper := segmentsIntersect(a1, a2, b1, b2, tol)
 			if intersects && proper {
 				return true
 			}
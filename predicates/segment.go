@@ -3,6 +3,7 @@ package predicates
 import (
 	"math"
 
+	"github.com/iceisfun/gomesh/algorithm/robust"
 	"github.com/iceisfun/gomesh/types"
 )
 
@@ -15,10 +16,14 @@ func Dist2(a, b types.Point) float64 {
 
 // SegmentsIntersect tests if two line segments intersect.
 func SegmentsIntersect(a1, a2, b1, b2 types.Point, eps float64) (bool, bool) {
-	o1 := Orient(a1, a2, b1, eps)
-	o2 := Orient(a1, a2, b2, eps)
-	o3 := Orient(b1, b2, a1, eps)
-	o4 := Orient(b1, b2, a2, eps)
+	return segmentsIntersect(a1, a2, b1, b2, absTol(eps))
+}
+
+func segmentsIntersect(a1, a2, b1, b2 types.Point, tol tolerance) (bool, bool) {
+	o1 := orient(a1, a2, b1, tol)
+	o2 := orient(a1, a2, b2, tol)
+	o3 := orient(b1, b2, a1, tol)
+	o4 := orient(b1, b2, a2, tol)
 
 	// Proper intersection if segments straddle each other.
 	if o1*o2 < 0 && o3*o4 < 0 {
@@ -26,16 +31,16 @@ func SegmentsIntersect(a1, a2, b1, b2 types.Point, eps float64) (bool, bool) {
 	}
 
 	// Check special cases: endpoints and collinear overlaps.
-	if o1 == 0 && PointOnSegment(b1, a1, a2, eps) {
+	if o1 == 0 && pointOnSegment(b1, a1, a2, tol) {
 		return true, false
 	}
-	if o2 == 0 && PointOnSegment(b2, a1, a2, eps) {
+	if o2 == 0 && pointOnSegment(b2, a1, a2, tol) {
 		return true, false
 	}
-	if o3 == 0 && PointOnSegment(a1, b1, b2, eps) {
+	if o3 == 0 && pointOnSegment(a1, b1, b2, tol) {
 		return true, false
 	}
-	if o4 == 0 && PointOnSegment(a2, b1, b2, eps) {
+	if o4 == 0 && pointOnSegment(a2, b1, b2, tol) {
 		return true, false
 	}
 
@@ -44,7 +49,11 @@ func SegmentsIntersect(a1, a2, b1, b2 types.Point, eps float64) (bool, bool) {
 
 // SegmentIntersectionPoint computes the intersection point of two segments.
 func SegmentIntersectionPoint(a1, a2, b1, b2 types.Point, eps float64) (types.Point, types.IntersectionType) {
-	intersects, proper := SegmentsIntersect(a1, a2, b1, b2, eps)
+	return segmentIntersectionPoint(a1, a2, b1, b2, absTol(eps))
+}
+
+func segmentIntersectionPoint(a1, a2, b1, b2 types.Point, tol tolerance) (types.Point, types.IntersectionType) {
+	intersects, proper := segmentsIntersect(a1, a2, b1, b2, tol)
 	if !intersects {
 		return types.Point{}, types.IntersectNone
 	}
@@ -55,7 +64,8 @@ func SegmentIntersectionPoint(a1, a2, b1, b2 types.Point, eps float64) (types.Po
 	}
 
 	// Handle collinear overlaps or touching endpoints.
-	if isCollinear(a1, a2, b1, eps) && isCollinear(a1, a2, b2, eps) {
+	if isCollinear(a1, a2, b1, tol) && isCollinear(a1, a2, b2, tol) {
+		eps := tol.dist(a1, a2, b1, b2)
 		length, point := collinearOverlapPoint(a1, a2, b1, b2, eps)
 		if length > eps {
 			return point, types.IntersectCollinearOverlap
@@ -64,16 +74,16 @@ func SegmentIntersectionPoint(a1, a2, b1, b2 types.Point, eps float64) (types.Po
 	}
 
 	// Otherwise one of the endpoints lies on the other segment.
-	if PointOnSegment(a1, b1, b2, eps) {
+	if pointOnSegment(a1, b1, b2, tol) {
 		return a1, types.IntersectTouching
 	}
-	if PointOnSegment(a2, b1, b2, eps) {
+	if pointOnSegment(a2, b1, b2, tol) {
 		return a2, types.IntersectTouching
 	}
-	if PointOnSegment(b1, a1, a2, eps) {
+	if pointOnSegment(b1, a1, a2, tol) {
 		return b1, types.IntersectTouching
 	}
-	if PointOnSegment(b2, a1, a2, eps) {
+	if pointOnSegment(b2, a1, a2, tol) {
 		return b2, types.IntersectTouching
 	}
 
@@ -82,6 +92,14 @@ func SegmentIntersectionPoint(a1, a2, b1, b2 types.Point, eps float64) (types.Po
 
 // PointOnSegment tests if a point lies on a line segment within tolerance.
 func PointOnSegment(p, a, b types.Point, eps float64) bool {
+	return pointOnSegment(p, a, b, absTol(eps))
+}
+
+func pointOnSegment(p, a, b types.Point, tol tolerance) bool {
+	if tol.exact() {
+		return robust.PointOnSegment(p, a, b)
+	}
+	eps := tol.dist(p, a, b)
 	area := math.Abs(Area2(a, b, p))
 	segmentLen := math.Sqrt(Dist2(a, b))
 	if segmentLen == 0 {
@@ -99,8 +117,8 @@ func PointOnSegment(p, a, b types.Point, eps float64) bool {
 	return p.X >= minX && p.X <= maxX && p.Y >= minY && p.Y <= maxY
 }
 
-func isCollinear(a1, a2, p types.Point, eps float64) bool {
-	return math.Abs(Area2(a1, a2, p)) <= eps
+func isCollinear(a1, a2, p types.Point, tol tolerance) bool {
+	return orient(a1, a2, p, tol) == 0
 }
 
 func lineIntersectionPoint(a1, a2, b1, b2 types.Point) types.Point {
@@ -0,0 +1,133 @@
+package predicates
+
+import (
+	"math"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+// tolerance supplies the thresholds used by the predicates.
+//
+// The float64 predicates compare both distances and twice-areas against the
+// same absolute eps. Mode derives them from a types.Epsilon so that they
+// scale with the coordinates involved. The exact tolerance has no
+// thresholds and decides orientation with algorithm/robust instead.
+type tolerance interface {
+	// dist is the distance within which points are considered coincident.
+	dist(pts ...types.Point) float64
+	// area bounds |Area2(a, b, c)| for a, b and c to count as collinear.
+	area(a, b, c types.Point) float64
+	// exact reports whether decisions use exact arithmetic.
+	exact() bool
+}
+
+// absTol is the fixed tolerance of the float64 predicates.
+type absTol float64
+
+func (t absTol) dist(...types.Point) float64      { return float64(t) }
+func (t absTol) area(a, b, c types.Point) float64 { return float64(t) }
+func (t absTol) exact() bool                      { return false }
+
+// scaledTol is the scale-relative tolerance of Mode.
+type scaledTol types.Epsilon
+
+func (t scaledTol) dist(pts ...types.Point) float64 {
+	return types.Epsilon(t).TolForPoints(pts...)
+}
+
+func (t scaledTol) area(a, b, c types.Point) float64 {
+	return AreaTolerance(types.Epsilon(t), a, b, c)
+}
+
+func (t scaledTol) exact() bool { return false }
+
+// exactTol routes decisions through the exact predicates of
+// algorithm/robust.
+type exactTol struct{}
+
+func (exactTol) dist(...types.Point) float64      { return 0 }
+func (exactTol) area(a, b, c types.Point) float64 { return 0 }
+func (exactTol) exact() bool                      { return true }
+
+// AreaTolerance returns the bound on |Area2(a, b, c)| below which a, b and c
+// count as collinear under eps.
+//
+// Twice the area is the longest edge times the smallest height, so the bound
+// is reached when that height equals the distance tolerance of the points.
+// Unlike a fixed area threshold this holds at any coordinate scale.
+func AreaTolerance(eps types.Epsilon, a, b, c types.Point) float64 {
+	longest := math.Sqrt(math.Max(Dist2(a, b), math.Max(Dist2(b, c), Dist2(c, a))))
+	return eps.TolForPoints(a, b, c) * longest
+}
+
+// Mode selects how predicates decide orientation and containment, so that
+// callers can thread a single setting through every check.
+//
+// The zero Mode compares against a zero tolerance in float64 arithmetic.
+// With Exact set, Epsilon is ignored: orientation, collinearity and
+// point-on-segment are decided exactly by algorithm/robust, matching the
+// decisions made by cdt.
+type Mode struct {
+	Epsilon types.Epsilon
+	Exact   bool
+}
+
+func (m Mode) tol() tolerance {
+	if m.Exact {
+		return exactTol{}
+	}
+	return scaledTol(m.Epsilon)
+}
+
+// AreaTolerance returns the collinearity bound on |Area2(a, b, c)|, which
+// is zero in exact mode.
+func (m Mode) AreaTolerance(a, b, c types.Point) float64 {
+	return m.tol().area(a, b, c)
+}
+
+// Distance returns the distance within which the points are considered
+// coincident, which is zero in exact mode.
+func (m Mode) Distance(pts ...types.Point) float64 {
+	return m.tol().dist(pts...)
+}
+
+// Orient determines the orientation of a, b and c.
+func (m Mode) Orient(a, b, c types.Point) int {
+	return orient(a, b, c, m.tol())
+}
+
+// PointOnSegment tests if p lies on segment a-b.
+func (m Mode) PointOnSegment(p, a, b types.Point) bool {
+	return pointOnSegment(p, a, b, m.tol())
+}
+
+// SegmentsIntersect tests if two segments intersect, and if so whether
+// properly.
+func (m Mode) SegmentsIntersect(a1, a2, b1, b2 types.Point) (bool, bool) {
+	return segmentsIntersect(a1, a2, b1, b2, m.tol())
+}
+
+// SegmentIntersectionPoint computes the intersection point of two segments.
+func (m Mode) SegmentIntersectionPoint(a1, a2, b1, b2 types.Point) (types.Point, types.IntersectionType) {
+	return segmentIntersectionPoint(a1, a2, b1, b2, m.tol())
+}
+
+// PointInTriangle tests if p is inside or on triangle a, b, c.
+func (m Mode) PointInTriangle(p, a, b, c types.Point) bool {
+	return pointInTriangle(p, a, b, c, m.tol(), false)
+}
+
+// PointStrictlyInTriangle tests if p lies strictly inside triangle a, b, c.
+func (m Mode) PointStrictlyInTriangle(p, a, b, c types.Point) bool {
+	return pointInTriangle(p, a, b, c, m.tol(), true)
+}
+
+// PointInPolygon tests if p is inside or on poly.
+func (m Mode) PointInPolygon(p types.Point, poly []types.Point) bool {
+	return pointInPolygon(p, poly, m.tol())
+}
+
+// PolygonSelfIntersects checks if any non-adjacent segments of poly cross.
+func (m Mode) PolygonSelfIntersects(poly []types.Point) bool {
+	return polygonSelfIntersects(poly, m.tol())
+}
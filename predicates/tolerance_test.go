@@ -0,0 +1,109 @@
+package predicates
+
+import (
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestModeOrientScalesWithCoordinates(t *testing.T) {
+	eps := types.DefaultEpsilon()
+	mode := Mode{Epsilon: eps}
+
+	// Kilometre-scale coordinates: c sits 1e-7 off the line through a and
+	// b, well below the rounding noise of coordinates this large.
+	a := types.Point{X: 500000, Y: 4000000}
+	b := types.Point{X: 500100, Y: 4000100}
+	c := types.Point{X: 500050, Y: 4000050 + 1e-7}
+	if Orient(a, b, c, eps.Abs) == 0 {
+		t.Fatalf("expected the absolute tolerance to treat the sliver as non-degenerate")
+	}
+	if mode.Orient(a, b, c) != 0 {
+		t.Fatalf("expected the scaled tolerance to treat the sliver as collinear")
+	}
+
+	// A small but well shaped triangle has twice-area below the absolute
+	// epsilon even though its heights are far above it.
+	p := types.Point{X: 0, Y: 0}
+	q := types.Point{X: 3e-5, Y: 0}
+	r := types.Point{X: 0, Y: 3e-5}
+	if Orient(p, q, r, eps.Abs) != 0 {
+		t.Fatalf("expected the absolute tolerance to reject the small triangle")
+	}
+	if mode.Orient(p, q, r) != 1 {
+		t.Fatalf("expected the scaled tolerance to keep the small triangle counter-clockwise")
+	}
+}
+
+func TestModeSegmentsIntersectTouchingFarFromOrigin(t *testing.T) {
+	eps := types.DefaultEpsilon()
+	mode := Mode{Epsilon: eps}
+	off := types.Point{X: 7e6, Y: -3e6}
+	shift := func(x, y float64) types.Point { return types.Point{X: off.X + x, Y: off.Y + y} }
+
+	// b1 ends on segment a within rounding error of the large offset.
+	a1, a2 := shift(0, 0), shift(10, 0)
+	b1, b2 := shift(5, 1e-7), shift(5, 10)
+	intersects, proper := mode.SegmentsIntersect(a1, a2, b1, b2)
+	if !intersects || proper {
+		t.Fatalf("expected a touching intersection, got intersects=%v proper=%v", intersects, proper)
+	}
+	if _, kind := mode.SegmentIntersectionPoint(a1, a2, b1, b2); kind != types.IntersectTouching {
+		t.Fatalf("expected IntersectTouching, got %v", kind)
+	}
+	if !mode.PointOnSegment(b1, a1, a2) {
+		t.Fatalf("expected b1 to lie on segment a")
+	}
+}
+
+func TestAreaTolerance(t *testing.T) {
+	eps := types.NewEpsilon(0.5, 0)
+	a := types.Point{X: 0, Y: 0}
+	b := types.Point{X: 4, Y: 0}
+	c := types.Point{X: 0, Y: 3}
+	// Longest edge 5, so the bound is 0.5 * 5.
+	if got := AreaTolerance(eps, a, b, c); got != 2.5 {
+		t.Fatalf("expected 2.5, got %v", got)
+	}
+}
+
+func TestModeExact(t *testing.T) {
+	exact := Mode{Exact: true}
+	tolerant := Mode{Epsilon: types.DefaultEpsilon()}
+
+	a := types.Point{X: 0, Y: 0}
+	b := types.Point{X: 1, Y: 1}
+	c := types.Point{X: 0.5, Y: 0.5 + 1e-12}
+	if tolerant.Orient(a, b, c) != 0 {
+		t.Fatalf("expected the tolerance to treat c as collinear")
+	}
+	if exact.Orient(a, b, c) != 1 {
+		t.Fatalf("expected exact orientation to see c left of a-b")
+	}
+	if exact.PointOnSegment(c, a, b) || !exact.PointOnSegment(types.Point{X: 0.5, Y: 0.5}, a, b) {
+		t.Fatalf("expected exact point-on-segment to accept only points on the diagonal")
+	}
+	if got := exact.AreaTolerance(a, b, c); got != 0 {
+		t.Fatalf("expected no area tolerance in exact mode, got %v", got)
+	}
+
+	square := []types.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+	below := types.Point{X: 0.5, Y: -1e-300}
+	if !tolerant.PointInPolygon(below, square) {
+		t.Fatalf("expected the tolerance to snap the point onto the boundary")
+	}
+	if exact.PointInPolygon(below, square) {
+		t.Fatalf("expected the exact test to place the point outside")
+	}
+	if !exact.PointInPolygon(types.Point{X: 0.5, Y: 0}, square) || !exact.PointInPolygon(types.Point{X: 0.5, Y: 0.5}, square) {
+		t.Fatalf("expected boundary and interior points to be inside")
+	}
+
+	// Segments meeting 1e-12 apart touch under the tolerance only.
+	if ok, _ := tolerant.SegmentsIntersect(a, b, c, types.Point{X: 0, Y: 1}); !ok {
+		t.Fatalf("expected the tolerance to report touching segments")
+	}
+	if ok, _ := exact.SegmentsIntersect(a, b, c, types.Point{X: 0, Y: 1}); ok {
+		t.Fatalf("expected exact segments to miss")
+	}
+}
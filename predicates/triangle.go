@@ -1,8 +1,7 @@
 package predicates
 
 import (
-	"math"
-
+	"github.com/iceisfun/gomesh/algorithm/robust"
 	"github.com/iceisfun/gomesh/types"
 )
 
@@ -13,11 +12,19 @@ func Area2(a, b, c types.Point) float64 {
 
 // Orient determines the orientation of three points with tolerance.
 func Orient(a, b, c types.Point, eps float64) int {
+	return orient(a, b, c, absTol(eps))
+}
+
+func orient(a, b, c types.Point, tol tolerance) int {
+	if tol.exact() {
+		return robust.Orient2D(a, b, c)
+	}
 	area := Area2(a, b, c)
-	if area > eps {
+	limit := tol.area(a, b, c)
+	if area > limit {
 		return 1
 	}
-	if area < -eps {
+	if area < -limit {
 		return -1
 	}
 	return 0
@@ -25,38 +32,28 @@ func Orient(a, b, c types.Point, eps float64) int {
 
 // PointInTriangle tests if a point is inside or on a triangle.
 func PointInTriangle(p, a, b, c types.Point, eps float64) bool {
-	area := Area2(a, b, c)
-	if math.Abs(area) <= eps {
-		return false
-	}
-
-	o1 := Orient(a, b, p, eps)
-	o2 := Orient(b, c, p, eps)
-	o3 := Orient(c, a, p, eps)
-
-	if (o1 >= 0 && o2 >= 0 && o3 >= 0) || (o1 <= 0 && o2 <= 0 && o3 <= 0) {
-		return true
-	}
-	return false
+	return pointInTriangle(p, a, b, c, absTol(eps), false)
 }
 
 // PointStrictlyInTriangle tests if a point lies strictly inside a triangle.
 func PointStrictlyInTriangle(p, a, b, c types.Point, eps float64) bool {
-	area := Area2(a, b, c)
-	if math.Abs(area) <= eps {
-		return false
-	}
-
-	o1 := Orient(a, b, p, eps)
-	o2 := Orient(b, c, p, eps)
-	o3 := Orient(c, a, p, eps)
+	return pointInTriangle(p, a, b, c, absTol(eps), true)
+}
 
-	if o1 == 0 || o2 == 0 || o3 == 0 {
+func pointInTriangle(p, a, b, c types.Point, tol tolerance, strict bool) bool {
+	if orient(a, b, c, tol) == 0 {
 		return false
 	}
 
-	if (o1 > 0 && o2 > 0 && o3 > 0) || (o1 < 0 && o2 < 0 && o3 < 0) {
-		return true
+	o1 := orient(a, b, p, tol)
+	o2 := orient(b, c, p, tol)
+	o3 := orient(c, a, p, tol)
+
+	if strict {
+		if o1 == 0 || o2 == 0 || o3 == 0 {
+			return false
+		}
+		return (o1 > 0 && o2 > 0 && o3 > 0) || (o1 < 0 && o2 < 0 && o3 < 0)
 	}
-	return false
+	return (o1 >= 0 && o2 >= 0 && o3 >= 0) || (o1 <= 0 && o2 <= 0 && o3 <= 0)
 }
@@ -0,0 +1,101 @@
+// Package quality measures the shape of triangles and summarizes how good a
+// mesh is.
+//
+// Triangle computes per-triangle metrics: angles, area, edge lengths and the
+// aspect, radius-edge and edge-length ratios used to compare meshers.
+// Analyze aggregates them over a mesh.Mesh into min/mean/max summaries and
+// histograms, and Stats.Check gates the result against Limits, for example
+// to fail a CI job when cdt.Build output regresses.
+//
+// Angles are in degrees. Ratios are normalized so that an equilateral
+// triangle scores its best value: 1 for AspectRatio and EdgeRatio, and
+// 1/sqrt(3) for RadiusEdgeRatio.
+package quality
+
+import (
+	"math"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Metrics are the shape measures of one triangle.
+//
+// The ratios of a degenerate triangle are +Inf, and its angles are those of
+// the flattened triangle (0, 0 and 180 degrees for distinct collinear
+// points).
+type Metrics struct {
+	// SignedArea is positive for counter-clockwise triangles and negative
+	// for clockwise ones.
+	SignedArea float64 `json:"signed_area"`
+
+	// Degenerate reports collinear vertices, decided exactly.
+	Degenerate bool `json:"degenerate"`
+
+	MinAngle float64 `json:"min_angle"`
+	MaxAngle float64 `json:"max_angle"`
+
+	MinEdge float64 `json:"min_edge"`
+	MaxEdge float64 `json:"max_edge"`
+
+	// AspectRatio is the circumradius over twice the inradius.
+	AspectRatio float64 `json:"aspect_ratio"`
+
+	// RadiusEdgeRatio is the circumradius over the shortest edge, the
+	// measure bounded by Delaunay refinement.
+	RadiusEdgeRatio float64 `json:"radius_edge_ratio"`
+
+	// EdgeRatio is the longest edge over the shortest edge.
+	EdgeRatio float64 `json:"edge_ratio"`
+}
+
+// Area returns the unsigned area.
+func (m Metrics) Area() float64 {
+	return math.Abs(m.SignedArea)
+}
+
+// Triangle computes the metrics of the triangle a, b, c.
+func Triangle(a, b, c types.Point) Metrics {
+	pts := [3]types.Point{a, b, c}
+
+	var edges, angles [3]float64
+	for i := range pts {
+		p, q, r := pts[i], pts[(i+1)%3], pts[(i+2)%3]
+		edges[i] = math.Hypot(q.X-p.X, q.Y-p.Y)
+
+		// Angle at p between the edges towards q and r.
+		ux, uy := q.X-p.X, q.Y-p.Y
+		vx, vy := r.X-p.X, r.Y-p.Y
+		angles[i] = math.Abs(math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)) * 180 / math.Pi
+	}
+
+	m := Metrics{
+		SignedArea: ((b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)) / 2,
+		Degenerate: robust.Orient2D(a, b, c) == 0,
+		MinAngle:   math.Min(angles[0], math.Min(angles[1], angles[2])),
+		MaxAngle:   math.Max(angles[0], math.Max(angles[1], angles[2])),
+		MinEdge:    math.Min(edges[0], math.Min(edges[1], edges[2])),
+		MaxEdge:    math.Max(edges[0], math.Max(edges[1], edges[2])),
+	}
+
+	area := m.Area()
+	if m.Degenerate || area == 0 || m.MinEdge == 0 {
+		m.AspectRatio = math.Inf(1)
+		m.RadiusEdgeRatio = math.Inf(1)
+		m.EdgeRatio = math.Inf(1)
+		if m.MinEdge > 0 {
+			m.EdgeRatio = m.MaxEdge / m.MinEdge
+		}
+		return m
+	}
+
+	// R = abc / 4A and r = A / s, so R / 2r = abc s / 8A^2.
+	product := edges[0] * edges[1] * edges[2]
+	s := (edges[0] + edges[1] + edges[2]) / 2
+	circumradius := product / (4 * area)
+
+	m.AspectRatio = product * s / (8 * area * area)
+	m.RadiusEdgeRatio = circumradius / m.MinEdge
+	m.EdgeRatio = m.MaxEdge / m.MinEdge
+	return m
+}
@@ -0,0 +1,81 @@
+package quality
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTriangleMetrics(t *testing.T) {
+	eq := Triangle(types.Point{X: 0, Y: 0}, types.Point{X: 2, Y: 0}, types.Point{X: 1, Y: math.Sqrt(3)})
+	if !near(eq.MinAngle, 60) || !near(eq.MaxAngle, 60) || !near(eq.AspectRatio, 1) ||
+		!near(eq.EdgeRatio, 1) || !near(eq.RadiusEdgeRatio, 1/math.Sqrt(3)) || !near(eq.Area(), math.Sqrt(3)) {
+		t.Fatalf("unexpected equilateral metrics %+v", eq)
+	}
+
+	// Clockwise right isosceles triangle.
+	right := Triangle(types.Point{X: 0, Y: 0}, types.Point{X: 0, Y: 1}, types.Point{X: 1, Y: 0})
+	if right.SignedArea != -0.5 || !near(right.MinAngle, 45) || !near(right.MaxAngle, 90) ||
+		!near(right.EdgeRatio, math.Sqrt2) || !near(right.RadiusEdgeRatio, math.Sqrt2/2) ||
+		!near(right.AspectRatio, (1+math.Sqrt2)/2) {
+		t.Fatalf("unexpected right triangle metrics %+v", right)
+	}
+
+	flat := Triangle(types.Point{X: 0, Y: 0}, types.Point{X: 1, Y: 0}, types.Point{X: 3, Y: 0})
+	if !flat.Degenerate || !math.IsInf(flat.AspectRatio, 1) || flat.MaxAngle != 180 || flat.EdgeRatio != 3 {
+		t.Fatalf("unexpected degenerate metrics %+v", flat)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	m := mesh.NewMesh()
+	var ids []types.VertexID
+	for _, p := range []types.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 10, Y: 0.5}} {
+		id, _ := m.AddVertex(p)
+		ids = append(ids, id)
+	}
+	for _, tri := range [][3]int{{0, 1, 2}, {0, 2, 3}, {1, 4, 2}} {
+		if err := m.AddTriangle(ids[tri[0]], ids[tri[1]], ids[tri[2]]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := Analyze(m)
+	if s.Triangles != 3 || s.CCW != 3 || s.Inverted != 0 || s.Degenerate != 0 || !near(s.TotalArea, 5.5) {
+		t.Fatalf("unexpected counts %+v", s)
+	}
+	// The sliver's sharpest angle is at (10, 0.5).
+	if !near(s.MinAngle.Max, 45) || !near(s.MinAngle.Min, 2*math.Atan(0.5/9)*180/math.Pi) || !near(s.EdgeLength.Min, 1) {
+		t.Fatalf("unexpected summaries %+v", s)
+	}
+	if !reflect.DeepEqual(s.MinAngleHistogram.Counts, []int{0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0}) {
+		t.Fatalf("unexpected min angle histogram %v", s.MinAngleHistogram.Counts)
+	}
+
+	if err := s.Check(Limits{MinAngle: 20}); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected the sliver to fail a 20 degree minimum, got %v", err)
+	}
+	if err := s.Check(Limits{MinAngle: 6, MaxAngle: 90}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := json.Marshal(s); err != nil {
+		t.Fatalf("stats must be JSON encodable: %v", err)
+	}
+}
+
+func TestNewHistogram(t *testing.T) {
+	h := NewHistogram([]float64{-1, 0, 0.5, 1, 2, 9}, []float64{0, 1, 2})
+	if !reflect.DeepEqual(h.Counts, []int{3, 3}) {
+		t.Fatalf("expected values to clamp into the end bins, got %v", h.Counts)
+	}
+}
@@ -0,0 +1,200 @@
+package quality
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/iceisfun/gomesh/mesh"
+)
+
+// ErrLimitExceeded is returned by Stats.Check when a mesh fails its limits.
+var ErrLimitExceeded = errors.New("quality: mesh exceeds quality limits")
+
+// Default histogram bin bounds.
+var (
+	MinAngleBins    = []float64{0, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55, 60}
+	MaxAngleBins    = []float64{60, 70, 80, 90, 100, 110, 120, 130, 140, 150, 160, 170, 180}
+	AspectRatioBins = []float64{1, 1.25, 1.5, 2, 3, 5, 10}
+)
+
+// Summary is the range and mean of one metric.
+type Summary struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	Max  float64 `json:"max"`
+}
+
+// Histogram counts values into bins. Counts[i] holds the values in
+// [Bounds[i], Bounds[i+1]); values below the first bound land in the first
+// bin and the last bin is open-ended.
+type Histogram struct {
+	Bounds []float64 `json:"bounds"`
+	Counts []int     `json:"counts"`
+}
+
+// NewHistogram bins values using the ascending bounds, which must hold at
+// least two entries.
+func NewHistogram(values []float64, bounds []float64) Histogram {
+	h := Histogram{
+		Bounds: append([]float64(nil), bounds...),
+		Counts: make([]int, len(bounds)-1),
+	}
+	for _, v := range values {
+		// The bin ends at the first bound above v.
+		bin := sort.Search(len(h.Bounds), func(i int) bool { return h.Bounds[i] > v }) - 1
+		if bin < 0 {
+			bin = 0
+		}
+		if bin >= len(h.Counts) {
+			bin = len(h.Counts) - 1
+		}
+		h.Counts[bin]++
+	}
+	return h
+}
+
+// Stats aggregates triangle metrics over a mesh.
+//
+// Degenerate triangles are counted but left out of the summaries and
+// histograms, so every reported value is finite. Inverted counts the
+// triangles whose winding disagrees with the majority of the mesh.
+type Stats struct {
+	Triangles  int `json:"triangles"`
+	Degenerate int `json:"degenerate"`
+	CCW        int `json:"ccw"`
+	CW         int `json:"cw"`
+	Inverted   int `json:"inverted"`
+
+	TotalArea float64 `json:"total_area"`
+
+	Area            Summary `json:"area"`
+	MinAngle        Summary `json:"min_angle"`
+	MaxAngle        Summary `json:"max_angle"`
+	AspectRatio     Summary `json:"aspect_ratio"`
+	RadiusEdgeRatio Summary `json:"radius_edge_ratio"`
+	EdgeRatio       Summary `json:"edge_ratio"`
+	EdgeLength      Summary `json:"edge_length"`
+
+	MinAngleHistogram    Histogram `json:"min_angle_histogram"`
+	MaxAngleHistogram    Histogram `json:"max_angle_histogram"`
+	AspectRatioHistogram Histogram `json:"aspect_ratio_histogram"`
+}
+
+// MeshMetrics returns the metrics of every triangle of m, in triangle order.
+func MeshMetrics(m *mesh.Mesh) []Metrics {
+	out := make([]Metrics, m.NumTriangles())
+	for i := range out {
+		out[i] = Triangle(m.GetTriangleCoords(i))
+	}
+	return out
+}
+
+// Analyze computes the statistics of m.
+func Analyze(m *mesh.Mesh) Stats {
+	return Summarize(MeshMetrics(m))
+}
+
+// Summarize aggregates precomputed triangle metrics.
+func Summarize(metrics []Metrics) Stats {
+	s := Stats{Triangles: len(metrics)}
+
+	var area, minAngle, maxAngle, aspect, radiusEdge, edgeRatio, edges []float64
+	for _, m := range metrics {
+		if m.Degenerate {
+			s.Degenerate++
+			continue
+		}
+		if m.SignedArea > 0 {
+			s.CCW++
+		} else {
+			s.CW++
+		}
+		s.TotalArea += m.Area()
+
+		area = append(area, m.Area())
+		minAngle = append(minAngle, m.MinAngle)
+		maxAngle = append(maxAngle, m.MaxAngle)
+		aspect = append(aspect, m.AspectRatio)
+		radiusEdge = append(radiusEdge, m.RadiusEdgeRatio)
+		edgeRatio = append(edgeRatio, m.EdgeRatio)
+		edges = append(edges, m.MinEdge, m.MaxEdge)
+	}
+	s.Inverted = s.CW
+	if s.CW > s.CCW {
+		s.Inverted = s.CCW
+	}
+
+	s.Area = summarize(area)
+	s.MinAngle = summarize(minAngle)
+	s.MaxAngle = summarize(maxAngle)
+	s.AspectRatio = summarize(aspect)
+	s.RadiusEdgeRatio = summarize(radiusEdge)
+	s.EdgeRatio = summarize(edgeRatio)
+	s.EdgeLength = summarize(edges)
+
+	s.MinAngleHistogram = NewHistogram(minAngle, MinAngleBins)
+	s.MaxAngleHistogram = NewHistogram(maxAngle, MaxAngleBins)
+	s.AspectRatioHistogram = NewHistogram(aspect, AspectRatioBins)
+	return s
+}
+
+func summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	s := Summary{Min: math.Inf(1), Max: math.Inf(-1)}
+	total := 0.0
+	for _, v := range values {
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+		total += v
+	}
+	s.Mean = total / float64(len(values))
+	return s
+}
+
+// Limits are quality thresholds for Stats.Check. Zero values disable a
+// limit.
+type Limits struct {
+	MinAngle           float64 // Smallest allowed angle in degrees
+	MaxAngle           float64 // Largest allowed angle in degrees
+	MaxAspectRatio     float64
+	MaxRadiusEdgeRatio float64
+
+	AllowDegenerate bool
+	AllowInverted   bool
+}
+
+// Check reports every limit the statistics violate, wrapped in
+// ErrLimitExceeded, or nil when the mesh passes.
+func (s Stats) Check(l Limits) error {
+	var failed []string
+	if !l.AllowDegenerate && s.Degenerate > 0 {
+		failed = append(failed, fmt.Sprintf("%d degenerate triangles", s.Degenerate))
+	}
+	if !l.AllowInverted && s.Inverted > 0 {
+		failed = append(failed, fmt.Sprintf("%d inverted triangles", s.Inverted))
+	}
+
+	measured := s.Triangles > s.Degenerate
+	if measured && l.MinAngle > 0 && s.MinAngle.Min < l.MinAngle {
+		failed = append(failed, fmt.Sprintf("min angle %.4g < %.4g", s.MinAngle.Min, l.MinAngle))
+	}
+	if measured && l.MaxAngle > 0 && s.MaxAngle.Max > l.MaxAngle {
+		failed = append(failed, fmt.Sprintf("max angle %.4g > %.4g", s.MaxAngle.Max, l.MaxAngle))
+	}
+	if measured && l.MaxAspectRatio > 0 && s.AspectRatio.Max > l.MaxAspectRatio {
+		failed = append(failed, fmt.Sprintf("aspect ratio %.4g > %.4g", s.AspectRatio.Max, l.MaxAspectRatio))
+	}
+	if measured && l.MaxRadiusEdgeRatio > 0 && s.RadiusEdgeRatio.Max > l.MaxRadiusEdgeRatio {
+		failed = append(failed, fmt.Sprintf("radius-edge ratio %.4g > %.4g", s.RadiusEdgeRatio.Max, l.MaxRadiusEdgeRatio))
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrLimitExceeded, strings.Join(failed, "; "))
+}
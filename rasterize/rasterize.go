@@ -235,6 +235,9 @@ func renderVertices(img *image.RGBA, m *mesh.Mesh, transform Transform, col colo
 		return
 	}
 	for i := 0; i < m.NumVertices(); i++ {
+		if !m.IsValidVertexID(types.VertexID(i)) {
+			continue
+		}
 		p := m.GetVertex(types.VertexID(i))
 		x, y := transform.Apply(p)
 		DrawPointAlpha(img, x, y, col)
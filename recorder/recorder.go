@@ -0,0 +1,220 @@
+// Package recorder turns the mesh debug hooks into a reproducible artifact.
+//
+// A Recorder installs the mesh debug hooks and captures every mutation,
+// including rejected AddTriangle calls and local edits such as FlipEdge or
+// MoveVertex, successful or not, with their errors. The recording can be
+// saved to a JSON file and replayed step by step against a fresh mesh to
+// reproduce field bugs. Undo and Redo call no hooks and are not recorded, so
+// sessions using them do not replay.
+//
+// Example:
+//
+//	rec := recorder.New()
+//	m := mesh.NewMesh(append(opts, rec.Options()...)...)
+//	// ... run the failing workload ...
+//	rec.Save("bug.json", m)
+package recorder
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// EventKind identifies the hook that produced an event.
+type EventKind string
+
+const (
+	// EventVertex is a vertex returned by AddVertex, new or merged.
+	EventVertex EventKind = "vertex"
+	// EventEdge is a new edge recorded by AddTriangle.
+	EventEdge EventKind = "edge"
+	// EventTriangle is a triangle accepted by AddTriangle.
+	EventTriangle EventKind = "triangle"
+	// EventRejectTriangle is a triangle rejected by AddTriangle.
+	EventRejectTriangle EventKind = "reject_triangle"
+	// EventPerimeter is a perimeter loop accepted by AddPerimeter.
+	EventPerimeter EventKind = "perimeter"
+	// EventHole is a hole loop accepted by AddHole.
+	EventHole EventKind = "hole"
+	// EventFlipEdge is a FlipEdge call.
+	EventFlipEdge EventKind = "flip_edge"
+	// EventSplitEdge is a SplitEdge call; Vertex is the new vertex.
+	EventSplitEdge EventKind = "split_edge"
+	// EventCollapseEdge is a CollapseEdge call; Vertex is the survivor.
+	EventCollapseEdge EventKind = "collapse_edge"
+	// EventSplitTriangle is a SplitTriangle call on the triangle at Index;
+	// Vertex is the new vertex.
+	EventSplitTriangle EventKind = "split_triangle"
+	// EventMoveVertex is a MoveVertex call.
+	EventMoveVertex EventKind = "move_vertex"
+)
+
+// Event is a single recorded hook invocation.
+//
+// Only the fields relevant to Kind are set.
+type Event struct {
+	Kind     EventKind         `json:"kind"`
+	Vertex   types.VertexID    `json:"vertex,omitempty"`
+	Point    *types.Point      `json:"point,omitempty"`
+	Edge     *types.Edge       `json:"edge,omitempty"`
+	Triangle *types.Triangle   `json:"triangle,omitempty"`
+	Loop     types.PolygonLoop `json:"loop,omitempty"`
+	Points   []types.Point     `json:"points,omitempty"`
+	Index    int               `json:"index,omitempty"`
+	Error    string            `json:"error,omitempty"`
+
+	// InLoop marks vertex events produced by the AddPerimeter or AddHole
+	// call recorded by a later perimeter or hole event.
+	InLoop bool `json:"in_loop,omitempty"`
+}
+
+// Recording is the serializable result of a Recorder.
+type Recording struct {
+	Config mesh.SavedConfig `json:"config"`
+	Events []Event          `json:"events"`
+}
+
+// Recorder captures mesh hook invocations.
+//
+// A Recorder is safe for concurrent use, although a mesh itself is not.
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// New creates an empty recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Options returns the mesh options that install the recorder's hooks.
+//
+// The options replace any debug hooks set earlier in the option list.
+func (r *Recorder) Options() []mesh.Option {
+	return []mesh.Option{
+		mesh.WithDebugAddVertex(func(id types.VertexID, p types.Point) {
+			r.add(Event{Kind: EventVertex, Vertex: id, Point: &p})
+		}),
+		mesh.WithDebugAddEdge(func(e types.Edge) {
+			r.add(Event{Kind: EventEdge, Edge: &e})
+		}),
+		mesh.WithDebugAddTriangle(func(t types.Triangle) {
+			r.add(Event{Kind: EventTriangle, Triangle: &t})
+		}),
+		mesh.WithDebugRejectTriangle(func(t types.Triangle, err error) {
+			r.add(Event{Kind: EventRejectTriangle, Triangle: &t, Error: err.Error()})
+		}),
+		mesh.WithDebugAddPerimeter(func(loop types.PolygonLoop) {
+			r.addLoop(EventPerimeter, loop)
+		}),
+		mesh.WithDebugAddHole(func(loop types.PolygonLoop) {
+			r.addLoop(EventHole, loop)
+		}),
+		mesh.WithDebugFlipEdge(func(e types.Edge, err error) {
+			r.add(Event{Kind: EventFlipEdge, Edge: &e, Error: errorString(err)})
+		}),
+		mesh.WithDebugSplitEdge(func(e types.Edge, p types.Point, id types.VertexID, err error) {
+			r.add(Event{Kind: EventSplitEdge, Edge: &e, Point: &p, Vertex: id, Error: errorString(err)})
+		}),
+		mesh.WithDebugCollapseEdge(func(e types.Edge, id types.VertexID, err error) {
+			r.add(Event{Kind: EventCollapseEdge, Edge: &e, Vertex: id, Error: errorString(err)})
+		}),
+		mesh.WithDebugSplitTriangle(func(idx int, p types.Point, id types.VertexID, err error) {
+			r.add(Event{Kind: EventSplitTriangle, Index: idx, Point: &p, Vertex: id, Error: errorString(err)})
+		}),
+		mesh.WithDebugMoveVertex(func(id types.VertexID, p types.Point, err error) {
+			r.add(Event{Kind: EventMoveVertex, Vertex: id, Point: &p, Error: errorString(err)})
+		}),
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (r *Recorder) add(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+// addLoop records a perimeter or hole. AddPerimeter and AddHole add one
+// vertex per input point immediately before the loop hook fires, so the
+// trailing vertex events belong to this loop and replay through it.
+func (r *Recorder) addLoop(kind EventKind, loop types.PolygonLoop) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	points := make([]types.Point, len(loop))
+	start := len(r.events) - len(loop)
+	for i := range loop {
+		e := &r.events[start+i]
+		e.InLoop = true
+		points[i] = *e.Point
+	}
+
+	r.events = append(r.events, Event{
+		Kind:   kind,
+		Loop:   append(types.PolygonLoop(nil), loop...),
+		Points: points,
+	})
+}
+
+// Len returns the number of recorded events.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// Recording returns a copy of the recorded events together with the
+// configuration of m, which must be the mesh the hooks were installed on.
+func (r *Recorder) Recording(m *mesh.Mesh) *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Recording{
+		Config: m.Config(),
+		Events: append([]Event(nil), r.events...),
+	}
+}
+
+// Save writes the recording for m to a JSON file.
+func (r *Recorder) Save(filename string, m *mesh.Mesh) error {
+	return r.Recording(m).Save(filename)
+}
+
+// Save writes the recording to a JSON file.
+func (rec *Recording) Save(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rec)
+}
+
+// Load reads a recording written by Save.
+func Load(filename string) (*Recording, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rec Recording
+	if err := json.NewDecoder(file).Decode(&rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
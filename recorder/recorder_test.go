@@ -0,0 +1,150 @@
+package recorder
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func recordSession(t *testing.T) (*Recorder, *mesh.Mesh) {
+	t.Helper()
+	rec := New()
+	m := mesh.NewMesh(append([]mesh.Option{mesh.WithMergeVertices(true)}, rec.Options()...)...)
+
+	if _, err := m.AddPerimeter([]types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}); err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	c, _ := m.AddVertex(types.Point{X: 5, Y: 5})
+	if err := m.AddTriangle(0, 1, c); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	x, _ := m.AddVertex(types.Point{X: 20, Y: 0})
+	if err := m.AddTriangle(0, 1, x); err == nil {
+		t.Fatalf("expected degenerate triangle to be rejected")
+	}
+	return rec, m
+}
+
+func TestRecorderCapturesEvents(t *testing.T) {
+	rec, m := recordSession(t)
+	r := rec.Recording(m)
+
+	counts := make(map[EventKind]int)
+	inLoop := 0
+	for _, e := range r.Events {
+		counts[e.Kind]++
+		if e.InLoop {
+			inLoop++
+		}
+	}
+	if counts[EventVertex] != 6 || inLoop != 4 {
+		t.Fatalf("expected 6 vertex events (4 in loop), got %d (%d)", counts[EventVertex], inLoop)
+	}
+	if counts[EventPerimeter] != 1 || counts[EventTriangle] != 1 || counts[EventEdge] != 3 {
+		t.Fatalf("unexpected event counts: %v", counts)
+	}
+	if counts[EventRejectTriangle] != 1 {
+		t.Fatalf("expected rejected triangle to be recorded, got %v", counts)
+	}
+	if !r.Config.MergeVertices {
+		t.Fatalf("expected recorded config to include merging")
+	}
+}
+
+func TestRecordingSaveLoadReplay(t *testing.T) {
+	rec, m := recordSession(t)
+	path := filepath.Join(t.TempDir(), "recording.json")
+	if err := rec.Save(path, m); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	steps := 0
+	replayed, err := loaded.Replay(func(int, Event, *mesh.Mesh) error {
+		steps++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if steps != 5 {
+		t.Fatalf("expected 5 replay steps, got %d", steps)
+	}
+	if !mesh.Diff(m.Snapshot(), replayed.Snapshot()).IsEmpty() {
+		t.Fatalf("replayed mesh differs from recorded mesh")
+	}
+	if len(replayed.Perimeters()) != 1 {
+		t.Fatalf("expected replayed perimeter")
+	}
+}
+
+func TestReplayDetectsDivergence(t *testing.T) {
+	rec, m := recordSession(t)
+	r := rec.Recording(m)
+	for i := range r.Events {
+		if r.Events[i].Kind == EventRejectTriangle {
+			r.Events[i].Error = "something else"
+		}
+	}
+
+	if _, err := r.Replay(nil); !errors.Is(err, ErrDiverged) {
+		t.Fatalf("expected ErrDiverged, got %v", err)
+	}
+}
+
+func TestRecordingReplaysEdits(t *testing.T) {
+	rec := New()
+	m := mesh.NewMesh(rec.Options()...)
+	a, _ := m.AddVertex(types.Point{X: 0, Y: 0})
+	b, _ := m.AddVertex(types.Point{X: 4, Y: 0})
+	c, _ := m.AddVertex(types.Point{X: 4, Y: 4})
+	d, _ := m.AddVertex(types.Point{X: 0, Y: 4})
+	if err := m.AddTriangle(a, b, c); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+	if err := m.AddTriangle(a, c, d); err != nil {
+		t.Fatalf("AddTriangle failed: %v", err)
+	}
+
+	if err := m.FlipEdge(types.NewEdge(a, c)); err != nil {
+		t.Fatalf("FlipEdge failed: %v", err)
+	}
+	mid, err := m.SplitEdge(types.NewEdge(b, d), types.Point{X: 2, Y: 2})
+	if err != nil {
+		t.Fatalf("SplitEdge failed: %v", err)
+	}
+	if err := m.MoveVertex(mid, types.Point{X: 2.5, Y: 2}); err != nil {
+		t.Fatalf("MoveVertex failed: %v", err)
+	}
+	if _, err := m.SplitTriangle(0, types.Point{X: 10, Y: 10}); err == nil {
+		t.Fatalf("expected SplitTriangle outside the triangle to fail")
+	}
+	if _, err := m.CollapseEdge(types.NewEdge(mid, c)); err != nil {
+		t.Fatalf("CollapseEdge failed: %v", err)
+	}
+
+	r := rec.Recording(m)
+	replayed, err := r.Replay(nil)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !mesh.Diff(m.Snapshot(), replayed.Snapshot()).IsEmpty() {
+		t.Fatalf("replayed mesh differs from recorded mesh")
+	}
+
+	for i := range r.Events {
+		if r.Events[i].Kind == EventMoveVertex {
+			r.Events[i].Point = &types.Point{X: 9, Y: 9}
+		}
+	}
+	if _, err := r.Replay(nil); !errors.Is(err, ErrDiverged) {
+		t.Fatalf("expected ErrDiverged for a changed move, got %v", err)
+	}
+}
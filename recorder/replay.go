@@ -0,0 +1,153 @@
+package recorder
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// ErrDiverged indicates a replayed event produced a different result than
+// the one recorded.
+var ErrDiverged = errors.New("gomesh: replay diverged from recording")
+
+// StepFunc is called after each replayed event with the event's index in
+// Recording.Events. Returning an error stops the replay.
+type StepFunc func(index int, e Event, m *mesh.Mesh) error
+
+// Replay rebuilds the recorded mesh by re-issuing each recorded call.
+//
+// The mesh is created from the recorded configuration followed by opts.
+// Edge events are derived from triangle events and are not replayed; vertex
+// events that belong to a perimeter or hole are replayed through that loop.
+// step may be nil.
+//
+// If an event behaves differently than recorded, Replay stops with an error
+// wrapping ErrDiverged and returns the mesh in its state at that point.
+func (rec *Recording) Replay(step StepFunc, opts ...mesh.Option) (*mesh.Mesh, error) {
+	m := mesh.NewMesh(append(rec.Config.Options(), opts...)...)
+
+	for i, e := range rec.Events {
+		if e.Kind == EventEdge || (e.Kind == EventVertex && e.InLoop) {
+			continue
+		}
+
+		if err := replayEvent(m, e); err != nil {
+			return m, fmt.Errorf("event %d (%s): %w", i, e.Kind, err)
+		}
+
+		if step != nil {
+			if err := step(i, e, m); err != nil {
+				return m, err
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func replayEvent(m *mesh.Mesh, e Event) error {
+	switch e.Kind {
+	case EventVertex:
+		if e.Point == nil {
+			return fmt.Errorf("gomesh: malformed vertex event")
+		}
+		id, err := m.AddVertex(*e.Point)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrDiverged, err)
+		}
+		if id != e.Vertex {
+			return fmt.Errorf("%w: got vertex %d, recorded %d", ErrDiverged, id, e.Vertex)
+		}
+
+	case EventTriangle, EventRejectTriangle:
+		if e.Triangle == nil {
+			return fmt.Errorf("gomesh: malformed triangle event")
+		}
+		t := *e.Triangle
+		err := m.AddTriangle(t.V1(), t.V2(), t.V3())
+		switch {
+		case e.Kind == EventTriangle && err != nil:
+			return fmt.Errorf("%w: triangle %v rejected: %v", ErrDiverged, t, err)
+		case e.Kind == EventRejectTriangle && err == nil:
+			return fmt.Errorf("%w: triangle %v accepted, recorded %q", ErrDiverged, t, e.Error)
+		case e.Kind == EventRejectTriangle && err.Error() != e.Error:
+			return fmt.Errorf("%w: triangle %v rejected with %q, recorded %q", ErrDiverged, t, err, e.Error)
+		}
+
+	case EventPerimeter, EventHole:
+		add := m.AddPerimeter
+		if e.Kind == EventHole {
+			add = m.AddHole
+		}
+		loop, err := add(e.Points)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrDiverged, err)
+		}
+		if !slices.Equal(loop, e.Loop) {
+			return fmt.Errorf("%w: got loop %v, recorded %v", ErrDiverged, loop, e.Loop)
+		}
+
+	case EventFlipEdge:
+		if e.Edge == nil {
+			return fmt.Errorf("gomesh: malformed flip_edge event")
+		}
+		return checkEdit(m.FlipEdge(*e.Edge), e)
+
+	case EventSplitEdge:
+		if e.Edge == nil || e.Point == nil {
+			return fmt.Errorf("gomesh: malformed split_edge event")
+		}
+		id, err := m.SplitEdge(*e.Edge, *e.Point)
+		return checkEditVertex(id, err, e)
+
+	case EventCollapseEdge:
+		if e.Edge == nil {
+			return fmt.Errorf("gomesh: malformed collapse_edge event")
+		}
+		id, err := m.CollapseEdge(*e.Edge)
+		return checkEditVertex(id, err, e)
+
+	case EventSplitTriangle:
+		if e.Point == nil {
+			return fmt.Errorf("gomesh: malformed split_triangle event")
+		}
+		id, err := m.SplitTriangle(e.Index, *e.Point)
+		return checkEditVertex(id, err, e)
+
+	case EventMoveVertex:
+		if e.Point == nil {
+			return fmt.Errorf("gomesh: malformed move_vertex event")
+		}
+		return checkEdit(m.MoveVertex(e.Vertex, *e.Point), e)
+
+	default:
+		return fmt.Errorf("gomesh: unknown event kind %q", e.Kind)
+	}
+
+	return nil
+}
+
+// checkEdit compares the error of a replayed edit with the recorded one.
+func checkEdit(err error, e Event) error {
+	switch {
+	case err == nil && e.Error != "":
+		return fmt.Errorf("%w: succeeded, recorded %q", ErrDiverged, e.Error)
+	case err != nil && err.Error() != e.Error:
+		return fmt.Errorf("%w: failed with %q, recorded %q", ErrDiverged, err, e.Error)
+	}
+	return nil
+}
+
+// checkEditVertex is checkEdit for edits that return a vertex.
+func checkEditVertex(id types.VertexID, err error, e Event) error {
+	if err := checkEdit(err, e); err != nil {
+		return err
+	}
+	if err == nil && id != e.Vertex {
+		return fmt.Errorf("%w: got vertex %d, recorded %d", ErrDiverged, id, e.Vertex)
+	}
+	return nil
+}
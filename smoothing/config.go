@@ -0,0 +1,66 @@
+package smoothing
+
+// Method selects how a vertex's target position is computed.
+type Method int
+
+const (
+	// MethodLaplacian moves each vertex to the average of its neighbours.
+	MethodLaplacian Method = iota
+
+	// MethodAngleBased moves each vertex so that it bisects the angles
+	// formed at its neighbours (Zhou and Shimada).
+	MethodAngleBased
+
+	// MethodODT moves each vertex to the area-weighted average of the
+	// circumcenters of its incident triangles (optimal Delaunay triangulation).
+	MethodODT
+
+	// MethodCVT moves each vertex to the centroid of the polygon formed by
+	// the circumcenters of its incident triangles (centroidal Voronoi).
+	MethodCVT
+)
+
+// String returns the method name.
+func (m Method) String() string {
+	switch m {
+	case MethodLaplacian:
+		return "laplacian"
+	case MethodAngleBased:
+		return "angle"
+	case MethodODT:
+		return "odt"
+	case MethodCVT:
+		return "cvt"
+	default:
+		return "unknown"
+	}
+}
+
+// Config holds options for smoothing a mesh.
+type Config struct {
+	Method Method
+
+	// Iterations is the maximum number of passes over the vertices.
+	Iterations int
+
+	// Relaxation scales each move; 1 moves vertices all the way to their
+	// target, smaller values move them part of the way.
+	Relaxation float64
+
+	// SlideBoundary lets boundary vertices move along straight runs of the
+	// boundary instead of keeping them fixed.
+	SlideBoundary bool
+
+	// Tolerance stops smoothing early once no vertex moved further than
+	// this in a pass.
+	Tolerance float64
+}
+
+// DefaultConfig returns the default smoothing settings.
+func DefaultConfig() Config {
+	return Config{
+		Method:     MethodLaplacian,
+		Iterations: 10,
+		Relaxation: 1,
+	}
+}
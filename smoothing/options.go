@@ -0,0 +1,48 @@
+package smoothing
+
+// Option configures smoothing.
+type Option func(*Config)
+
+// WithMethod selects the smoothing method.
+func WithMethod(method Method) Option {
+	return func(c *Config) {
+		c.Method = method
+	}
+}
+
+// WithIterations sets the maximum number of smoothing passes.
+func WithIterations(n int) Option {
+	return func(c *Config) {
+		if n >= 0 {
+			c.Iterations = n
+		}
+	}
+}
+
+// WithRelaxation sets the fraction of the way each vertex moves toward its
+// target. Values outside (0, 1] are ignored.
+func WithRelaxation(factor float64) Option {
+	return func(c *Config) {
+		if factor > 0 && factor <= 1 {
+			c.Relaxation = factor
+		}
+	}
+}
+
+// WithBoundarySliding lets boundary vertices slide along straight runs of
+// the boundary.
+func WithBoundarySliding(enable bool) Option {
+	return func(c *Config) {
+		c.SlideBoundary = enable
+	}
+}
+
+// WithTolerance stops smoothing once the largest move in a pass is at most
+// tolerance.
+func WithTolerance(tolerance float64) Option {
+	return func(c *Config) {
+		if tolerance >= 0 {
+			c.Tolerance = tolerance
+		}
+	}
+}
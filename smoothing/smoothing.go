@@ -0,0 +1,365 @@
+// Package smoothing relocates mesh vertices to improve triangle quality
+// without changing the mesh topology.
+//
+// Only interior vertices are moved. Vertices on a perimeter, a hole or an
+// open mesh boundary stay fixed unless boundary sliding is enabled, in which
+// case a boundary vertex whose two boundary neighbours are collinear with it
+// may move along the segment between them. Every move goes through
+// mesh.MoveVertex, so moves that would invert or degenerate a triangle are
+// rejected and, when the mesh has a journal, each move can be undone.
+package smoothing
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// maxBacktracks is how many times a rejected move is halved before the
+// vertex is left in place for the current pass.
+const maxBacktracks = 4
+
+// Result summarizes a smoothing run.
+type Result struct {
+	// Iterations is the number of passes performed.
+	Iterations int
+
+	// Moved counts accepted vertex moves across all passes.
+	Moved int
+
+	// Rejected counts moves abandoned because every backtracking step
+	// would have inverted a triangle.
+	Rejected int
+
+	// MaxDisplacement is the largest distance a vertex moved in the final pass.
+	MaxDisplacement float64
+}
+
+// vertex describes a movable vertex and its fixed neighbourhood.
+type vertex struct {
+	id         types.VertexID
+	triangles  []int
+	neighbours []types.VertexID
+
+	// sliding marks a boundary vertex that may move between the two
+	// boundary neighbours in slide.
+	sliding bool
+	slide   [2]types.VertexID
+}
+
+// Smooth relocates the vertices of m using the configured method.
+//
+// Vertices are updated in place one at a time (Gauss-Seidel order). A move
+// that mesh.MoveVertex rejects with mesh.ErrInvertedTriangle is retried at
+// half the distance up to a few times before it is counted as rejected. Any
+// other error stops smoothing and is returned with the partial result.
+func Smooth(m *mesh.Mesh, opts ...Option) (Result, error) {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var res Result
+	vertices := movableVertices(m, cfg.SlideBoundary)
+	eps := m.Epsilon()
+
+	for pass := 0; pass < cfg.Iterations; pass++ {
+		res.Iterations++
+		res.MaxDisplacement = 0
+
+		for _, v := range vertices {
+			p := m.GetVertex(v.id)
+			target, ok := v.target(m, cfg.Method)
+			if !ok {
+				continue
+			}
+
+			dx := (target.X - p.X) * cfg.Relaxation
+			dy := (target.Y - p.Y) * cfg.Relaxation
+			if math.Hypot(dx, dy) <= eps {
+				continue
+			}
+
+			moved := false
+			for try := 0; try <= maxBacktracks; try++ {
+				err := m.MoveVertex(v.id, types.Point{X: p.X + dx, Y: p.Y + dy})
+				if err == nil {
+					moved = true
+					break
+				}
+				if !errors.Is(err, mesh.ErrInvertedTriangle) {
+					return res, err
+				}
+				dx /= 2
+				dy /= 2
+			}
+
+			if !moved {
+				res.Rejected++
+				continue
+			}
+			res.Moved++
+			res.MaxDisplacement = math.Max(res.MaxDisplacement, math.Hypot(dx, dy))
+		}
+
+		if res.MaxDisplacement <= cfg.Tolerance {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// movableVertices collects the vertices smoothing may move, in ID order.
+//
+// Boundary edges are the perimeter and hole edges plus any edge used by a
+// single triangle, so meshes without loops keep their outline as well.
+func movableVertices(m *mesh.Mesh, slideBoundary bool) []vertex {
+	boundary := make(map[types.VertexID]map[types.VertexID]struct{})
+	addBoundaryEdge := func(e types.Edge) {
+		for _, pair := range [2][2]types.VertexID{{e.V1(), e.V2()}, {e.V2(), e.V1()}} {
+			if boundary[pair[0]] == nil {
+				boundary[pair[0]] = make(map[types.VertexID]struct{})
+			}
+			boundary[pair[0]][pair[1]] = struct{}{}
+		}
+	}
+
+	for edge, count := range m.EdgeUsageCounts() {
+		if count == 1 {
+			addBoundaryEdge(edge)
+		}
+	}
+	loopUses := make(map[types.VertexID]int)
+	for _, loops := range [][]types.PolygonLoop{m.Perimeters(), m.Holes()} {
+		for _, loop := range loops {
+			for _, id := range loop {
+				loopUses[id]++
+			}
+			for _, edge := range loop.Edges() {
+				addBoundaryEdge(edge)
+			}
+		}
+	}
+
+	var out []vertex
+	for i := 0; i < m.NumVertices(); i++ {
+		id := types.VertexID(i)
+		triangles := m.VertexTriangles(id)
+		if len(triangles) == 0 {
+			continue
+		}
+
+		v := vertex{id: id, triangles: triangles, neighbours: neighbours(m, id, triangles)}
+		if _, onBoundary := boundary[id]; onBoundary || loopUses[id] > 0 {
+			if !slideBoundary || loopUses[id] > 1 || len(boundary[id]) != 2 {
+				continue
+			}
+			var ends []types.VertexID
+			for n := range boundary[id] {
+				ends = append(ends, n)
+			}
+			a, b, p := m.GetVertex(ends[0]), m.GetVertex(ends[1]), m.GetVertex(id)
+			if m.Predicates().Orient(a, p, b) != 0 || dot(sub(a, p), sub(b, p)) >= 0 {
+				continue
+			}
+			v.sliding = true
+			v.slide = [2]types.VertexID{ends[0], ends[1]}
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// neighbours returns the distinct vertices sharing a triangle with id.
+func neighbours(m *mesh.Mesh, id types.VertexID, triangles []int) []types.VertexID {
+	seen := make(map[types.VertexID]struct{})
+	var out []types.VertexID
+	for _, idx := range triangles {
+		for _, n := range m.GetTriangle(idx) {
+			if _, ok := seen[n]; ok || n == id {
+				continue
+			}
+			seen[n] = struct{}{}
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// target computes where v should move. Sliding boundary vertices always
+// move toward the midpoint of their boundary neighbours, which keeps them on
+// the boundary line.
+func (v vertex) target(m *mesh.Mesh, method Method) (types.Point, bool) {
+	if v.sliding {
+		a, b := m.GetVertex(v.slide[0]), m.GetVertex(v.slide[1])
+		return types.Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}, true
+	}
+
+	switch method {
+	case MethodAngleBased:
+		if p, ok := v.angleTarget(m); ok {
+			return p, true
+		}
+	case MethodODT:
+		if p, ok := v.odtTarget(m); ok {
+			return p, true
+		}
+	case MethodCVT:
+		if p, ok := v.cvtTarget(m); ok {
+			return p, true
+		}
+	}
+	return v.laplacianTarget(m)
+}
+
+func (v vertex) laplacianTarget(m *mesh.Mesh) (types.Point, bool) {
+	if len(v.neighbours) == 0 {
+		return types.Point{}, false
+	}
+	var sum types.Point
+	for _, n := range v.neighbours {
+		p := m.GetVertex(n)
+		sum.X += p.X
+		sum.Y += p.Y
+	}
+	k := float64(len(v.neighbours))
+	return types.Point{X: sum.X / k, Y: sum.Y / k}, true
+}
+
+// angleTarget rotates v about each neighbour so that the edge to v bisects
+// the angle between the neighbour's two fan edges, then averages the results.
+func (v vertex) angleTarget(m *mesh.Mesh) (types.Point, bool) {
+	p := m.GetVertex(v.id)
+	var sum types.Point
+	count := 0
+	for _, n := range v.neighbours {
+		q := m.GetVertex(n)
+		rel := sub(p, q)
+
+		var angles []float64
+		for _, idx := range v.triangles {
+			tri := m.GetTriangle(idx)
+			if !hasVertex(tri, n) {
+				continue
+			}
+			other := sub(m.GetVertex(thirdVertex(tri, v.id, n)), q)
+			angles = append(angles, math.Atan2(cross(rel, other), dot(rel, other)))
+		}
+		if len(angles) != 2 {
+			continue
+		}
+
+		phi := (angles[0] + angles[1]) / 2
+		sin, cos := math.Sincos(phi)
+		sum.X += q.X + rel.X*cos - rel.Y*sin
+		sum.Y += q.Y + rel.X*sin + rel.Y*cos
+		count++
+	}
+	if count == 0 {
+		return types.Point{}, false
+	}
+	return types.Point{X: sum.X / float64(count), Y: sum.Y / float64(count)}, true
+}
+
+// odtTarget averages the circumcenters of the incident triangles weighted by
+// triangle area.
+func (v vertex) odtTarget(m *mesh.Mesh) (types.Point, bool) {
+	var sum types.Point
+	total := 0.0
+	for _, idx := range v.triangles {
+		a, b, c := m.GetTriangleCoords(idx)
+		cc, ok := circumcenter(a, b, c)
+		if !ok {
+			continue
+		}
+		area := math.Abs(predicates.Area2(a, b, c)) / 2
+		sum.X += cc.X * area
+		sum.Y += cc.Y * area
+		total += area
+	}
+	if total == 0 {
+		return types.Point{}, false
+	}
+	return types.Point{X: sum.X / total, Y: sum.Y / total}, true
+}
+
+// cvtTarget returns the centroid of the polygon formed by the circumcenters
+// of the incident triangles taken in angular order around v.
+func (v vertex) cvtTarget(m *mesh.Mesh) (types.Point, bool) {
+	p := m.GetVertex(v.id)
+
+	type corner struct {
+		angle  float64
+		center types.Point
+	}
+	corners := make([]corner, 0, len(v.triangles))
+	for _, idx := range v.triangles {
+		a, b, c := m.GetTriangleCoords(idx)
+		cc, ok := circumcenter(a, b, c)
+		if !ok {
+			return types.Point{}, false
+		}
+		centroid := types.Point{X: (a.X + b.X + c.X) / 3, Y: (a.Y + b.Y + c.Y) / 3}
+		corners = append(corners, corner{angle: math.Atan2(centroid.Y-p.Y, centroid.X-p.X), center: cc})
+	}
+	sort.Slice(corners, func(i, j int) bool { return corners[i].angle < corners[j].angle })
+
+	var area2, cx, cy float64
+	for i := range corners {
+		a, b := corners[i].center, corners[(i+1)%len(corners)].center
+		w := a.X*b.Y - b.X*a.Y
+		area2 += w
+		cx += (a.X + b.X) * w
+		cy += (a.Y + b.Y) * w
+	}
+	if math.Abs(area2) <= m.Epsilon() {
+		return types.Point{}, false
+	}
+	return types.Point{X: cx / (3 * area2), Y: cy / (3 * area2)}, true
+}
+
+// circumcenter returns the center of the circle through a, b and c.
+func circumcenter(a, b, c types.Point) (types.Point, bool) {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		return types.Point{}, false
+	}
+	b2 := bx*bx + by*by
+	c2 := cx*cx + cy*cy
+	return types.Point{
+		X: a.X + (cy*b2-by*c2)/d,
+		Y: a.Y + (bx*c2-cx*b2)/d,
+	}, true
+}
+
+func hasVertex(tri types.Triangle, id types.VertexID) bool {
+	return tri.V1() == id || tri.V2() == id || tri.V3() == id
+}
+
+func thirdVertex(tri types.Triangle, a, b types.VertexID) types.VertexID {
+	for _, v := range tri {
+		if v != a && v != b {
+			return v
+		}
+	}
+	return types.NilVertex
+}
+
+func sub(a, b types.Point) types.Point {
+	return types.Point{X: a.X - b.X, Y: a.Y - b.Y}
+}
+
+func dot(a, b types.Point) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+func cross(a, b types.Point) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
@@ -0,0 +1,159 @@
+package smoothing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// buildGrid creates an n x n grid of unit quads split into triangles, with
+// the interior vertices displaced by a fixed pattern.
+func buildGrid(t *testing.T, n int, opts ...mesh.Option) *mesh.Mesh {
+	t.Helper()
+	m := mesh.NewMesh(opts...)
+	id := func(i, j int) types.VertexID { return types.VertexID(j*(n+1) + i) }
+	for j := 0; j <= n; j++ {
+		for i := 0; i <= n; i++ {
+			p := types.Point{X: float64(i), Y: float64(j)}
+			if i > 0 && j > 0 && i < n && j < n {
+				p.X += 0.3 * float64((i+j)%3-1)
+				p.Y += 0.25 * float64((i*j)%3-1)
+			}
+			if _, err := m.AddVertex(p); err != nil {
+				t.Fatalf("AddVertex failed: %v", err)
+			}
+		}
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if err := m.AddTriangle(id(i, j), id(i+1, j), id(i+1, j+1)); err != nil {
+				t.Fatalf("AddTriangle failed: %v", err)
+			}
+			if err := m.AddTriangle(id(i, j), id(i+1, j+1), id(i, j+1)); err != nil {
+				t.Fatalf("AddTriangle failed: %v", err)
+			}
+		}
+	}
+	return m
+}
+
+func assertPositive(t *testing.T, m *mesh.Mesh) {
+	t.Helper()
+	for i := 0; i < m.NumTriangles(); i++ {
+		a, b, c := m.GetTriangleCoords(i)
+		if predicates.Orient(a, b, c, 0) <= 0 {
+			t.Fatalf("triangle %d inverted: %v %v %v", i, a, b, c)
+		}
+	}
+}
+
+func TestSmoothMethodsRestoreGrid(t *testing.T) {
+	for _, method := range []Method{MethodLaplacian, MethodAngleBased, MethodODT, MethodCVT} {
+		t.Run(method.String(), func(t *testing.T) {
+			m := buildGrid(t, 4)
+			res, err := Smooth(m, WithMethod(method), WithIterations(200), WithTolerance(1e-9))
+			if err != nil {
+				t.Fatalf("Smooth failed: %v", err)
+			}
+			if res.Moved == 0 {
+				t.Fatalf("expected vertices to move")
+			}
+			assertPositive(t, m)
+
+			// Corners and edges of the grid stay fixed; the displaced interior
+			// relaxes back toward the regular lattice.
+			if m.GetVertex(0) != (types.Point{X: 0, Y: 0}) || m.GetVertex(2) != (types.Point{X: 2, Y: 0}) {
+				t.Fatalf("boundary vertex moved")
+			}
+			center := m.GetVertex(types.VertexID(2*5 + 2))
+			if math.Hypot(center.X-2, center.Y-2) > 0.05 {
+				t.Fatalf("expected center vertex near (2, 2), got %v", center)
+			}
+		})
+	}
+}
+
+func TestSmoothBoundarySliding(t *testing.T) {
+	build := func() (*mesh.Mesh, types.VertexID) {
+		m := mesh.NewMesh()
+		loop, err := m.AddPerimeter([]types.Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}})
+		if err != nil {
+			t.Fatalf("AddPerimeter failed: %v", err)
+		}
+		c, _ := m.AddVertex(types.Point{X: 5, Y: 5})
+		for i := range loop {
+			if err := m.AddTriangle(loop[i], loop[(i+1)%len(loop)], c); err != nil {
+				t.Fatalf("AddTriangle failed: %v", err)
+			}
+		}
+		return m, loop[1]
+	}
+
+	m, v := build()
+	if _, err := Smooth(m); err != nil {
+		t.Fatalf("Smooth failed: %v", err)
+	}
+	if m.GetVertex(v) != (types.Point{X: 3, Y: 0}) {
+		t.Fatalf("perimeter vertex moved without sliding: %v", m.GetVertex(v))
+	}
+
+	m, v = build()
+	if _, err := Smooth(m, WithBoundarySliding(true), WithIterations(50)); err != nil {
+		t.Fatalf("Smooth failed: %v", err)
+	}
+	p := m.GetVertex(v)
+	if p.Y != 0 || math.Abs(p.X-5) > 1e-6 {
+		t.Fatalf("expected vertex to slide to (5, 0), got %v", p)
+	}
+	if m.GetVertex(0) != (types.Point{X: 0, Y: 0}) {
+		t.Fatalf("corner vertex moved")
+	}
+	assertPositive(t, m)
+}
+
+func TestSmoothBacktracksInvertingMoves(t *testing.T) {
+	// The neighbour average of the vertex fanned inside this L-shape lies in
+	// the notch, so a full Laplacian step would invert triangles.
+	m := mesh.NewMesh()
+	loop, err := m.AddPerimeter([]types.Point{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 10}, {X: 0, Y: 10},
+	})
+	if err != nil {
+		t.Fatalf("AddPerimeter failed: %v", err)
+	}
+	c, _ := m.AddVertex(types.Point{X: 2, Y: 2})
+	for i := range loop {
+		if err := m.AddTriangle(loop[i], loop[(i+1)%len(loop)], c); err != nil {
+			t.Fatalf("AddTriangle failed: %v", err)
+		}
+	}
+
+	if _, err := Smooth(m, WithIterations(1)); err != nil {
+		t.Fatalf("Smooth failed: %v", err)
+	}
+	p := m.GetVertex(c)
+	if p == (types.Point{X: 2, Y: 2}) || p.X >= 4 || p.Y >= 4 {
+		t.Fatalf("expected a shortened move inside the kernel, got %v", p)
+	}
+	assertPositive(t, m)
+}
+
+func TestSmoothIsUndoable(t *testing.T) {
+	m := buildGrid(t, 3, mesh.WithJournal(true))
+	before := m.Snapshot()
+	if err := m.Checkpoint("before"); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if _, err := Smooth(m, WithMethod(MethodODT)); err != nil {
+		t.Fatalf("Smooth failed: %v", err)
+	}
+	if err := m.RestoreCheckpoint("before"); err != nil {
+		t.Fatalf("RestoreCheckpoint failed: %v", err)
+	}
+	if !mesh.Diff(before, m.Snapshot()).IsEmpty() {
+		t.Fatalf("expected smoothing to be undone")
+	}
+}
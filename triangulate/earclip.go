@@ -0,0 +1,260 @@
+package triangulate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// EarClip triangulates by ear clipping. Holes are first joined to the
+// perimeter by bridge edges, turning the input into a single weakly simple
+// ring, which is then clipped one ear at a time.
+type EarClip struct {
+	Options Options
+}
+
+// NewEarClip returns an ear-clipping triangulator using DefaultOptions.
+func NewEarClip() EarClip {
+	return EarClip{Options: DefaultOptions()}
+}
+
+// Name implements Triangulator.
+func (EarClip) Name() string { return "earclip" }
+
+// Triangulate implements Triangulator.
+func (t EarClip) Triangulate(outer []types.Point, holes [][]types.Point) (*mesh.Mesh, error) {
+	pslg, err := prepare(outer, holes, t.Options.Epsilon)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ring{pts: pslg.Vertices}
+	start := r.link(pslg.Outer)
+	if start, err = r.bridgeHoles(start, pslg.Holes); err != nil {
+		return nil, err
+	}
+
+	tris, err := r.clip(start)
+	if err != nil {
+		return nil, err
+	}
+	return export(pslg.Vertices, tris, t.Options.MeshOptions...)
+}
+
+// ring is a circular doubly linked list of polygon vertices. A vertex may
+// appear in several nodes once holes are bridged in.
+type ring struct {
+	pts  []types.Point
+	v    []int
+	prev []int
+	next []int
+}
+
+// link appends a closed loop of vertex indices and returns its first node.
+func (r *ring) link(loop []int) int {
+	first := len(r.v)
+	for i, v := range loop {
+		r.v = append(r.v, v)
+		r.prev = append(r.prev, first+(i+len(loop)-1)%len(loop))
+		r.next = append(r.next, first+(i+1)%len(loop))
+	}
+	return first
+}
+
+func (r *ring) pt(n int) types.Point { return r.pts[r.v[n]] }
+
+// bridgeHoles splices each hole into the outer ring, rightmost hole first,
+// so that every bridge is visible from the ring built so far.
+func (r *ring) bridgeHoles(start int, holes [][]int) (int, error) {
+	type hole struct {
+		index     int
+		rightmost int
+	}
+
+	order := make([]hole, 0, len(holes))
+	for i, loop := range holes {
+		first := r.link(loop)
+		best := first
+		for n := first; n < first+len(loop); n++ {
+			p, q := r.pt(n), r.pt(best)
+			if p.X > q.X || (p.X == q.X && p.Y < q.Y) {
+				best = n
+			}
+		}
+		order = append(order, hole{index: i, rightmost: best})
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return r.pt(order[i].rightmost).X > r.pt(order[j].rightmost).X
+	})
+
+	for _, h := range order {
+		bridge := r.findBridge(start, h.rightmost)
+		if bridge < 0 {
+			return 0, fmt.Errorf("hole %d: %w", h.index, ErrNoBridge)
+		}
+		r.split(bridge, h.rightmost)
+	}
+	return start, nil
+}
+
+// findBridge returns a ring node visible from hole node m using Eberly's
+// method: cast a ray from m in +x, take the nearest edge hit, and if its
+// endpoint is hidden behind other ring vertices choose the one among them
+// closest in angle to the ray.
+func (r *ring) findBridge(start, m int) int {
+	mp := r.pt(m)
+	qx := math.Inf(1)
+	candidate := -1
+
+	n := start
+	for {
+		a, b := r.pt(n), r.pt(r.next[n])
+		if (a.Y-mp.Y)*(b.Y-mp.Y) <= 0 && a.Y != b.Y {
+			x := a.X + (mp.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if x >= mp.X && x < qx {
+				qx = x
+				switch {
+				case a.Y == mp.Y:
+					candidate = n
+				case b.Y == mp.Y:
+					candidate = r.next[n]
+				case a.X > b.X:
+					candidate = n
+				default:
+					candidate = r.next[n]
+				}
+			}
+		}
+		if n = r.next[n]; n == start {
+			break
+		}
+	}
+	if candidate < 0 {
+		return -1
+	}
+
+	cp := r.pt(candidate)
+	if cp.X == qx && cp.Y == mp.Y {
+		return r.visibleCopy(candidate, mp)
+	}
+
+	// Vertices inside the triangle (m, hit, candidate) may block the view
+	// of the candidate; the one with the smallest angle to the ray, nearest
+	// first on ties, is always visible.
+	hit := types.Point{X: qx, Y: mp.Y}
+	ta, tb := hit, cp
+	if cp.Y < mp.Y {
+		ta, tb = cp, hit
+	}
+	best := candidate
+	bestTan := math.Inf(1)
+	n = start
+	for {
+		p := r.pt(n)
+		if n != candidate && p.X >= mp.X && pointInTriangle(p, mp, ta, tb) && r.locallyInside(n, mp) {
+			tan := math.Abs(mp.Y-p.Y) / (p.X - mp.X)
+			if tan < bestTan || (tan == bestTan && p.X < r.pt(best).X) {
+				best = n
+				bestTan = tan
+			}
+		}
+		if n = r.next[n]; n == start {
+			break
+		}
+	}
+	return r.visibleCopy(best, mp)
+}
+
+// visibleCopy picks, among the nodes sharing n's vertex, one whose interior
+// angle contains the direction towards p.
+func (r *ring) visibleCopy(n int, p types.Point) int {
+	for k := range r.v {
+		if r.v[k] == r.v[n] && r.locallyInside(k, p) {
+			return k
+		}
+	}
+	return n
+}
+
+// locallyInside reports whether the direction from node n towards p lies
+// within the interior angle at n.
+func (r *ring) locallyInside(n int, p types.Point) bool {
+	a, b, c := r.pt(r.prev[n]), r.pt(n), r.pt(r.next[n])
+	if robust.Orient2D(a, b, c) >= 0 {
+		return robust.Orient2D(b, c, p) >= 0 && robust.Orient2D(a, b, p) >= 0
+	}
+	return robust.Orient2D(b, c, p) >= 0 || robust.Orient2D(a, b, p) >= 0
+}
+
+// split connects ring node a to hole node b with a pair of bridge edges.
+func (r *ring) split(a, b int) {
+	a2 := r.link([]int{r.v[a]})
+	b2 := r.link([]int{r.v[b]})
+	an, bp := r.next[a], r.prev[b]
+
+	r.next[a], r.prev[b] = b, a
+	r.next[a2], r.prev[an] = an, a2
+	r.next[b2], r.prev[a2] = a2, b2
+	r.next[bp], r.prev[b2] = b2, bp
+}
+
+// clip removes ears from the ring starting at start until a single
+// triangle remains.
+func (r *ring) clip(start int) ([][3]int, error) {
+	count := 1
+	for n := r.next[start]; n != start; n = r.next[n] {
+		count++
+	}
+
+	tris := make([][3]int, 0, count-2)
+	ear, stop := start, start
+	for count > 3 {
+		prev, next := r.prev[ear], r.next[ear]
+		if r.isEar(ear) {
+			tris = append(tris, [3]int{r.v[prev], r.v[ear], r.v[next]})
+			r.next[prev], r.prev[next] = next, prev
+			count--
+			ear, stop = next, next
+			continue
+		}
+		if ear = next; ear == stop {
+			return tris, fmt.Errorf("%w after %d triangles", ErrNoEar, len(tris))
+		}
+	}
+
+	if robust.Orient2D(r.pt(r.prev[ear]), r.pt(ear), r.pt(r.next[ear])) > 0 {
+		tris = append(tris, [3]int{r.v[r.prev[ear]], r.v[ear], r.v[r.next[ear]]})
+	}
+	return tris, nil
+}
+
+// isEar reports whether the triangle at node n is strictly convex and no
+// other ring vertex lies inside it or on its boundary.
+func (r *ring) isEar(n int) bool {
+	pa, pb, pc := r.prev[n], n, r.next[n]
+	a, b, c := r.pt(pa), r.pt(pb), r.pt(pc)
+	if robust.Orient2D(a, b, c) <= 0 {
+		return false
+	}
+
+	for k := r.next[pc]; k != pa; k = r.next[k] {
+		p := r.pt(k)
+		if p == a || p == b || p == c {
+			continue
+		}
+		if pointInTriangle(p, a, b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// pointInTriangle reports whether p lies inside or on the boundary of the
+// counter-clockwise triangle abc.
+func pointInTriangle(p, a, b, c types.Point) bool {
+	return robust.Orient2D(a, b, p) >= 0 && robust.Orient2D(b, c, p) >= 0 && robust.Orient2D(c, a, p) >= 0
+}
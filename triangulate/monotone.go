@@ -0,0 +1,347 @@
+package triangulate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iceisfun/gomesh/algorithm/robust"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Monotone triangulates by splitting the polygon into y-monotone pieces
+// with a top-to-bottom sweep and triangulating each piece in linear time.
+// Holes are handled by the sweep directly, without bridging.
+//
+// Triangles whose corners are exactly collinear are dropped, so a vertex
+// lying on the straight segment between its neighbours may end up on the
+// edge of a triangle rather than at one of its corners.
+type Monotone struct {
+	Options Options
+}
+
+// NewMonotone returns a monotone-partition triangulator using DefaultOptions.
+func NewMonotone() Monotone {
+	return Monotone{Options: DefaultOptions()}
+}
+
+// Name implements Triangulator.
+func (Monotone) Name() string { return "monotone" }
+
+// Triangulate implements Triangulator.
+func (t Monotone) Triangulate(outer []types.Point, holes [][]types.Point) (*mesh.Mesh, error) {
+	pslg, err := prepare(outer, holes, t.Options.Epsilon)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newSweep(pslg.Vertices, append([][]int{pslg.Outer}, pslg.Holes...))
+	diagonals, err := s.partition()
+	if err != nil {
+		return nil, err
+	}
+
+	var tris [][3]int
+	for _, piece := range s.pieces(diagonals) {
+		tris = append(tris, s.triangulatePiece(piece)...)
+	}
+	return export(pslg.Vertices, tris, t.Options.MeshOptions...)
+}
+
+// vertexKind classifies a vertex for the monotone partition sweep.
+type vertexKind int
+
+const (
+	kindRegular vertexKind = iota
+	kindStart
+	kindEnd
+	kindSplit
+	kindMerge
+)
+
+// sweep holds the loops being partitioned. Loops run with the interior on
+// their left, so edge v is the directed edge from v to next[v].
+type sweep struct {
+	pts  []types.Point
+	prev map[int]int
+	next map[int]int
+}
+
+func newSweep(pts []types.Point, loops [][]int) *sweep {
+	s := &sweep{pts: pts, prev: make(map[int]int), next: make(map[int]int)}
+	for _, loop := range loops {
+		for i, v := range loop {
+			s.prev[v] = loop[(i+len(loop)-1)%len(loop)]
+			s.next[v] = loop[(i+1)%len(loop)]
+		}
+	}
+	return s
+}
+
+// above orders vertices for the sweep: higher y first, then lower x.
+func (s *sweep) above(a, b int) bool {
+	pa, pb := s.pts[a], s.pts[b]
+	return pa.Y > pb.Y || (pa.Y == pb.Y && pa.X < pb.X)
+}
+
+func (s *sweep) kind(v int) vertexKind {
+	p, n := s.prev[v], s.next[v]
+	pAbove, nAbove := s.above(p, v), s.above(n, v)
+	convex := robust.Orient2D(s.pts[p], s.pts[v], s.pts[n]) > 0
+
+	switch {
+	case !pAbove && !nAbove && convex:
+		return kindStart
+	case !pAbove && !nAbove:
+		return kindSplit
+	case pAbove && nAbove && convex:
+		return kindEnd
+	case pAbove && nAbove:
+		return kindMerge
+	default:
+		return kindRegular
+	}
+}
+
+// xAt returns the x coordinate of edge e at height y.
+func (s *sweep) xAt(e int, y float64) float64 {
+	a, b := s.pts[e], s.pts[s.next[e]]
+	if a.Y == b.Y {
+		return math.Max(a.X, b.X)
+	}
+	return a.X + (y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+}
+
+// partition runs the sweep and returns the diagonals that split the
+// polygon into y-monotone pieces.
+func (s *sweep) partition() ([][2]int, error) {
+	events := make([]int, 0, len(s.next))
+	for v := range s.next {
+		events = append(events, v)
+	}
+	sort.Slice(events, func(i, j int) bool { return s.above(events[i], events[j]) })
+
+	status := make(map[int]bool)
+	helper := make(map[int]int)
+	var diagonals [][2]int
+
+	connectMerge := func(v, e int) {
+		if h := helper[e]; s.kind(h) == kindMerge {
+			diagonals = append(diagonals, [2]int{v, h})
+		}
+	}
+
+	// leftOf returns the status edge directly left of v.
+	leftOf := func(v int) (int, error) {
+		p := s.pts[v]
+		best, bestX := -1, math.Inf(-1)
+		for e := range status {
+			if e == v || s.next[e] == v {
+				continue
+			}
+			if x := s.xAt(e, p.Y); x <= p.X && x > bestX {
+				best, bestX = e, x
+			}
+		}
+		if best < 0 {
+			return 0, fmt.Errorf("no edge left of vertex %d", v)
+		}
+		return best, nil
+	}
+
+	for _, v := range events {
+		p := s.prev[v]
+		switch s.kind(v) {
+		case kindStart:
+			status[v], helper[v] = true, v
+
+		case kindEnd:
+			connectMerge(v, p)
+			delete(status, p)
+
+		case kindSplit:
+			e, err := leftOf(v)
+			if err != nil {
+				return nil, err
+			}
+			diagonals = append(diagonals, [2]int{v, helper[e]})
+			helper[e] = v
+			status[v], helper[v] = true, v
+
+		case kindMerge:
+			connectMerge(v, p)
+			delete(status, p)
+			e, err := leftOf(v)
+			if err != nil {
+				return nil, err
+			}
+			connectMerge(v, e)
+			helper[e] = v
+
+		default:
+			if s.above(p, v) {
+				// The boundary runs downwards, so the interior is to the right.
+				connectMerge(v, p)
+				delete(status, p)
+				status[v], helper[v] = true, v
+			} else {
+				e, err := leftOf(v)
+				if err != nil {
+					return nil, err
+				}
+				connectMerge(v, e)
+				helper[e] = v
+			}
+		}
+	}
+	return diagonals, nil
+}
+
+// pieces walks the faces formed by the loops and diagonals and returns each
+// one as a counter-clockwise vertex cycle.
+func (s *sweep) pieces(diagonals [][2]int) [][]int {
+	neighbours := make(map[int][]int)
+	for v, n := range s.next {
+		neighbours[v] = append(neighbours[v], n, s.prev[v])
+	}
+
+	type halfEdge struct{ from, to int }
+	pending := make(map[halfEdge]bool)
+	for v, n := range s.next {
+		pending[halfEdge{v, n}] = true
+	}
+	for _, d := range diagonals {
+		neighbours[d[0]] = append(neighbours[d[0]], d[1])
+		neighbours[d[1]] = append(neighbours[d[1]], d[0])
+		pending[halfEdge{d[0], d[1]}] = true
+		pending[halfEdge{d[1], d[0]}] = true
+	}
+
+	// nextEdge turns at w onto the first edge clockwise from the edge back
+	// to u, which keeps the face on the left.
+	nextEdge := func(u, w int) int {
+		pu, pw := s.pts[u], s.pts[w]
+
+		// side groups directions by clockwise angle from w->u: right of it,
+		// directly opposite, then left of it.
+		side := func(x int) int {
+			return 1 + robust.Orient2D(pw, pu, s.pts[x])
+		}
+
+		best := -1
+		for _, x := range neighbours[w] {
+			if x == u {
+				continue
+			}
+			if best < 0 {
+				best = x
+				continue
+			}
+			sx, sb := side(x), side(best)
+			if sx < sb || (sx == sb && robust.Orient2D(pw, s.pts[best], s.pts[x]) > 0) {
+				best = x
+			}
+		}
+		return best
+	}
+
+	// Walk the half-edges in a fixed order so the output is deterministic.
+	starts := make([]halfEdge, 0, len(pending))
+	for h := range pending {
+		starts = append(starts, h)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		if starts[i].from != starts[j].from {
+			return starts[i].from < starts[j].from
+		}
+		return starts[i].to < starts[j].to
+	})
+
+	var out [][]int
+	for _, h := range starts {
+		if !pending[h] {
+			continue
+		}
+		var face []int
+		for e := h; pending[e]; {
+			delete(pending, e)
+			face = append(face, e.from)
+			e = halfEdge{e.to, nextEdge(e.from, e.to)}
+		}
+		out = append(out, face)
+	}
+	return out
+}
+
+// triangulatePiece triangulates a y-monotone counter-clockwise piece with
+// the standard stack-based sweep.
+func (s *sweep) triangulatePiece(piece []int) [][3]int {
+	n := len(piece)
+	if n < 3 {
+		return nil
+	}
+
+	top, bottom := 0, 0
+	for i := range piece {
+		if s.above(piece[i], piece[top]) {
+			top = i
+		}
+		if s.above(piece[bottom], piece[i]) {
+			bottom = i
+		}
+	}
+
+	// Walking counter-clockwise from the top descends the left chain.
+	left := make(map[int]bool, n)
+	for i := top; i != bottom; i = (i + 1) % n {
+		left[piece[i]] = true
+	}
+
+	order := append([]int(nil), piece...)
+	sort.Slice(order, func(i, j int) bool { return s.above(order[i], order[j]) })
+
+	var tris [][3]int
+	emit := func(a, b, c int) {
+		if robust.Orient2D(s.pts[a], s.pts[b], s.pts[c]) != 0 {
+			tris = append(tris, [3]int{a, b, c})
+		}
+	}
+
+	stack := []int{order[0], order[1]}
+	for j := 2; j < n-1; j++ {
+		u := order[j]
+		if left[u] != left[stack[len(stack)-1]] {
+			for k := len(stack) - 1; k > 0; k-- {
+				emit(u, stack[k], stack[k-1])
+			}
+			stack = []int{order[j-1], u}
+			continue
+		}
+
+		last := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			var convex bool
+			if left[u] {
+				convex = robust.Orient2D(s.pts[top], s.pts[last], s.pts[u]) > 0
+			} else {
+				convex = robust.Orient2D(s.pts[u], s.pts[last], s.pts[top]) > 0
+			}
+			if !convex {
+				break
+			}
+			emit(u, last, top)
+			last = top
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, last, u)
+	}
+
+	u := order[n-1]
+	for k := len(stack) - 1; k > 0; k-- {
+		emit(u, stack[k], stack[k-1])
+	}
+	return tris
+}
@@ -0,0 +1,218 @@
+// Package triangulate provides interchangeable triangulators for polygons
+// with holes.
+//
+// Every triangulator accepts the same outer/holes input as cdt.Build and
+// produces a mesh.Mesh whose vertices are the merged input points. CDT wraps
+// cdt.Build, EarClip clips ears after bridging holes into the perimeter, and
+// Monotone splits the polygon into y-monotone pieces with a sweep before
+// triangulating each piece. EarClip and Monotone add no Steiner points and
+// make no attempt at Delaunay quality, but they do not depend on constraint
+// recovery, so they make dependable fallbacks when cdt.Build fails.
+package triangulate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/iceisfun/gomesh/cdt"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+var (
+	// ErrNoEar is returned by EarClip when no clippable ear remains.
+	ErrNoEar = errors.New("no ear found")
+
+	// ErrNoBridge is returned by EarClip when a hole cannot be connected to
+	// the perimeter.
+	ErrNoBridge = errors.New("no bridge found for hole")
+
+	// ErrNoTriangulator is returned by Fallback when it holds no triangulators.
+	ErrNoTriangulator = errors.New("no triangulator configured")
+)
+
+// Triangulator turns a perimeter and its holes into a triangle mesh.
+type Triangulator interface {
+	// Name identifies the triangulator in errors and comparisons.
+	Name() string
+
+	// Triangulate builds a mesh covering the region inside outer and
+	// outside every hole. Winding of the input loops does not matter.
+	Triangulate(outer []types.Point, holes [][]types.Point) (*mesh.Mesh, error)
+}
+
+// Options configures the EarClip and Monotone triangulators.
+type Options struct {
+	// Epsilon tolerance used when merging and validating input points
+	Epsilon types.Epsilon
+
+	// MeshOptions are passed to the final mesh constructor
+	MeshOptions []mesh.Option
+}
+
+// DefaultOptions returns the settings used by NewEarClip and NewMonotone.
+func DefaultOptions() Options {
+	return Options{
+		Epsilon:     types.DefaultEpsilon(),
+		MeshOptions: nil,
+	}
+}
+
+// CDT adapts cdt.Build to the Triangulator interface.
+type CDT struct {
+	Options cdt.BuildOptions
+}
+
+// NewCDT returns a CDT triangulator using cdt.DefaultBuildOptions.
+func NewCDT() CDT {
+	return CDT{Options: cdt.DefaultBuildOptions()}
+}
+
+// Name implements Triangulator.
+func (CDT) Name() string { return "cdt" }
+
+// Triangulate implements Triangulator.
+func (t CDT) Triangulate(outer []types.Point, holes [][]types.Point) (*mesh.Mesh, error) {
+	return cdt.Build(outer, holes, nil, t.Options)
+}
+
+// Fallback tries each triangulator in order and returns the first mesh
+// that is built successfully.
+type Fallback []Triangulator
+
+// DefaultFallback tries CDT first, then ear clipping, then monotone
+// partitioning.
+func DefaultFallback() Fallback {
+	return Fallback{NewCDT(), NewEarClip(), NewMonotone()}
+}
+
+// Name implements Triangulator.
+func (f Fallback) Name() string {
+	names := make([]string, len(f))
+	for i, t := range f {
+		names[i] = t.Name()
+	}
+	return "fallback(" + strings.Join(names, ",") + ")"
+}
+
+// Triangulate implements Triangulator.
+func (f Fallback) Triangulate(outer []types.Point, holes [][]types.Point) (*mesh.Mesh, error) {
+	m, _, err := First(outer, holes, f...)
+	return m, err
+}
+
+// First runs the triangulators in order and returns the first successful
+// mesh together with the triangulator that produced it. When all of them
+// fail the returned error joins every failure.
+func First(outer []types.Point, holes [][]types.Point, triangulators ...Triangulator) (*mesh.Mesh, Triangulator, error) {
+	if len(triangulators) == 0 {
+		return nil, nil, ErrNoTriangulator
+	}
+
+	var errs []error
+	for _, t := range triangulators {
+		m, err := t.Triangulate(outer, holes)
+		if err == nil {
+			return m, t, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", t.Name(), err))
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+// Comparison summarizes the output of one triangulator.
+type Comparison struct {
+	Name      string
+	Mesh      *mesh.Mesh
+	Err       error
+	Vertices  int
+	Triangles int
+
+	// Area is the total unsigned triangle area.
+	Area float64
+
+	// MinAngle is the smallest triangle angle in degrees.
+	MinAngle float64
+}
+
+// Compare runs every triangulator on the same input and reports the results
+// in the same order. Failures are recorded in Comparison.Err rather than
+// stopping the comparison.
+func Compare(outer []types.Point, holes [][]types.Point, triangulators ...Triangulator) []Comparison {
+	out := make([]Comparison, len(triangulators))
+	for i, t := range triangulators {
+		c := Comparison{Name: t.Name()}
+		c.Mesh, c.Err = t.Triangulate(outer, holes)
+		if c.Err == nil {
+			c.Vertices = c.Mesh.NumVertices()
+			c.Triangles = c.Mesh.NumTriangles()
+			c.MinAngle = 180
+			for j := 0; j < c.Triangles; j++ {
+				a, b, p := c.Mesh.GetTriangleCoords(j)
+				c.Area += math.Abs(predicates.Area2(a, b, p)) / 2
+				c.MinAngle = math.Min(c.MinAngle, minAngle(a, b, p))
+			}
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// prepare merges and validates the input exactly as cdt.Build does, so every
+// triangulator sees the same vertices, with the outer loop CCW and holes CW.
+func prepare(outer []types.Point, holes [][]types.Point, eps types.Epsilon) (*cdt.PSLG, error) {
+	pslg, err := cdt.NormalizePSLG(outer, holes, nil, eps)
+	if err != nil {
+		return nil, fmt.Errorf("PSLG normalization failed: %w", err)
+	}
+	return pslg, nil
+}
+
+// export builds a mesh from triangles given as indices into vertices. Only
+// referenced vertices are added, in index order, and every triangle is
+// added counter-clockwise.
+func export(vertices []types.Point, tris [][3]int, opts ...mesh.Option) (*mesh.Mesh, error) {
+	used := make([]bool, len(vertices))
+	for _, tri := range tris {
+		for _, v := range tri {
+			used[v] = true
+		}
+	}
+
+	m := mesh.NewMesh(opts...)
+	ids := make([]types.VertexID, len(vertices))
+	for i, p := range vertices {
+		if !used[i] {
+			continue
+		}
+		vid, err := m.AddVertex(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add vertex %d: %w", i, err)
+		}
+		ids[i] = vid
+	}
+
+	for i, tri := range tris {
+		a, b, c := tri[0], tri[1], tri[2]
+		if predicates.Area2(vertices[a], vertices[b], vertices[c]) < 0 {
+			b, c = c, b
+		}
+		if err := m.AddTriangle(ids[a], ids[b], ids[c]); err != nil {
+			return nil, fmt.Errorf("failed to add triangle %d: %w", i, err)
+		}
+	}
+	return m, nil
+}
+
+// minAngle returns the smallest interior angle of triangle abc in degrees.
+func minAngle(a, b, c types.Point) float64 {
+	angle := func(p, q, r types.Point) float64 {
+		ux, uy := q.X-p.X, q.Y-p.Y
+		vx, vy := r.X-p.X, r.Y-p.Y
+		return math.Abs(math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)) * 180 / math.Pi
+	}
+	return math.Min(angle(a, b, c), math.Min(angle(b, c, a), angle(c, a, b)))
+}
@@ -0,0 +1,184 @@
+package triangulate
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/iceisfun/gomesh/algorithm/polygon"
+	"github.com/iceisfun/gomesh/mesh"
+	"github.com/iceisfun/gomesh/types"
+)
+
+func square(x0, y0, size float64) []types.Point {
+	return []types.Point{{X: x0, Y: y0}, {X: x0 + size, Y: y0}, {X: x0 + size, Y: y0 + size}, {X: x0, Y: y0 + size}}
+}
+
+// comb is a perimeter with many teeth pointing up and down, giving several
+// split and merge vertices.
+func comb(teeth int) []types.Point {
+	var loop []types.Point
+	for i := 0; i < teeth; i++ {
+		x := float64(i * 2)
+		loop = append(loop, types.Point{X: x, Y: 0}, types.Point{X: x + 1, Y: -3})
+	}
+	loop = append(loop, types.Point{X: float64(teeth * 2), Y: 0}, types.Point{X: float64(teeth * 2), Y: 5})
+	for i := teeth; i > 0; i-- {
+		x := float64(i * 2)
+		loop = append(loop, types.Point{X: x - 1, Y: 8}, types.Point{X: x - 2, Y: 5})
+	}
+	return loop
+}
+
+func regionArea(outer []types.Point, holes [][]types.Point) float64 {
+	area := math.Abs(polygon.SignedArea(outer))
+	for _, h := range holes {
+		area -= math.Abs(polygon.SignedArea(h))
+	}
+	return area
+}
+
+func reversed(loop []types.Point) []types.Point {
+	out := make([]types.Point, len(loop))
+	for i, p := range loop {
+		out[len(loop)-1-i] = p
+	}
+	return out
+}
+
+// checkMesh verifies that m covers exactly the input region with
+// counter-clockwise triangles and that interior edges are shared by two
+// triangles.
+func checkMesh(t *testing.T, name string, m *mesh.Mesh, outer []types.Point, holes [][]types.Point) {
+	t.Helper()
+
+	area := 0.0
+	for i := 0; i < m.NumTriangles(); i++ {
+		a, b, c := m.GetTriangleCoords(i)
+		signed := (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+		if signed <= 0 {
+			t.Fatalf("%s: triangle %d is not counter-clockwise", name, i)
+		}
+		area += signed / 2
+	}
+	if want := regionArea(outer, holes); math.Abs(area-want) > 1e-9*want {
+		t.Fatalf("%s: expected area %v, got %v", name, want, area)
+	}
+
+	for edge, uses := range m.EdgeUsageCounts() {
+		if uses > 2 {
+			t.Fatalf("%s: edge %v used by %d triangles", name, edge, uses)
+		}
+	}
+}
+
+func TestTriangulatorsCoverRegion(t *testing.T) {
+	cases := []struct {
+		name  string
+		outer []types.Point
+		holes [][]types.Point
+	}{
+		{"square", square(0, 0, 10), nil},
+		{"clockwise", reversed(square(0, 0, 10)), nil},
+		{"comb", comb(6), nil},
+		{"one hole", square(0, 0, 10), [][]types.Point{square(3, 3, 4)}},
+		{"two holes", square(0, 0, 20), [][]types.Point{square(2, 2, 5), reversed(square(10, 10, 6))}},
+		{"holes sharing a row", square(0, 0, 30), [][]types.Point{square(2, 5, 4), square(10, 5, 4), square(18, 5, 4)}},
+		{"comb with hole", comb(5), [][]types.Point{square(1, 1, 2)}},
+	}
+
+	for _, tc := range cases {
+		for _, tr := range []Triangulator{NewEarClip(), NewMonotone()} {
+			m, err := tr.Triangulate(tc.outer, tc.holes)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", tc.name, tr.Name(), err)
+			}
+			checkMesh(t, tc.name+"/"+tr.Name(), m, tc.outer, tc.holes)
+
+			vertices := len(tc.outer)
+			for _, h := range tc.holes {
+				vertices += len(h)
+			}
+			if got, want := m.NumTriangles(), vertices+2*len(tc.holes)-2; got != want {
+				t.Fatalf("%s/%s: expected %d triangles, got %d", tc.name, tr.Name(), want, got)
+			}
+		}
+	}
+}
+
+func TestEarClipKeepsCollinearVertices(t *testing.T) {
+	outer := []types.Point{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	m, err := NewEarClip().Triangulate(outer, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMesh(t, "earclip", m, outer, nil)
+	if m.NumVertices() != 5 || m.NumTriangles() != 3 {
+		t.Fatalf("expected 5 vertices and 3 triangles, got %d and %d", m.NumVertices(), m.NumTriangles())
+	}
+}
+
+type failing struct{}
+
+func (failing) Name() string { return "failing" }
+
+func (failing) Triangulate([]types.Point, [][]types.Point) (*mesh.Mesh, error) {
+	return nil, errors.New("boom")
+}
+
+func TestFallback(t *testing.T) {
+	outer := square(0, 0, 10)
+	m, used, err := First(outer, nil, failing{}, NewEarClip())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used.Name() != "earclip" || m.NumTriangles() != 2 {
+		t.Fatalf("expected earclip fallback with 2 triangles, got %s with %d", used.Name(), m.NumTriangles())
+	}
+
+	if _, err := (Fallback{failing{}, failing{}}).Triangulate(outer, nil); err == nil {
+		t.Fatal("expected error when every triangulator fails")
+	}
+	if _, err := (Fallback{}).Triangulate(outer, nil); !errors.Is(err, ErrNoTriangulator) {
+		t.Fatalf("expected ErrNoTriangulator, got %v", err)
+	}
+	holes := [][]types.Point{square(4, 4, 2)}
+	m, err = DefaultFallback().Triangulate(outer, holes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMesh(t, "fallback", m, outer, holes)
+
+	if name := DefaultFallback().Name(); name != "fallback(cdt,earclip,monotone)" {
+		t.Fatalf("unexpected name %q", name)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	outer := square(0, 0, 10)
+	holes := [][]types.Point{{{X: 3, Y: 3}, {X: 3, Y: 7}, {X: 7, Y: 7}, {X: 7, Y: 3}}}
+	results := Compare(outer, holes, NewCDT(), NewEarClip(), NewMonotone(), failing{})
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	for _, r := range results[:3] {
+		if r.Err != nil {
+			t.Fatalf("%s: %v", r.Name, r.Err)
+		}
+		if math.Abs(r.Area-84) > 1e-9 || r.Vertices != 8 || r.MinAngle <= 0 {
+			t.Fatalf("%s: unexpected comparison %+v", r.Name, r)
+		}
+	}
+	if results[3].Err == nil {
+		t.Fatal("expected failing triangulator to report an error")
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	bowtie := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}}
+	for _, tr := range []Triangulator{NewEarClip(), NewMonotone()} {
+		if _, err := tr.Triangulate(bowtie, nil); err == nil {
+			t.Fatalf("%s: expected error for self-intersecting input", tr.Name())
+		}
+	}
+}
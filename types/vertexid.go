@@ -4,7 +4,8 @@ package types
 //
 // VertexID values are assigned sequentially starting from 0 when
 // vertices are added to a mesh. They remain stable for the lifetime
-// of the mesh (vertices are never removed or reordered).
+// of the mesh: vertices are never reordered, and a vertex removed by an
+// edge collapse keeps its ID, which is not reused.
 //
 // The special value NilVertex (-1) represents an invalid or absent
 // vertex reference.
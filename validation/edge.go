@@ -1,7 +1,8 @@
 package validation
 
 import (
-	"github.com/iceisfun/gomesh/predicates"
+	"sort"
+
 	"github.com/iceisfun/gomesh/types"
 )
 
@@ -9,20 +10,56 @@ import (
 //
 // Also checks that each edge is used by at most 2 triangles (prevents overlapping
 // triangles that share an edge).
+//
+// Failures are reported as an EdgeIntersectionError naming both edges.
 func ValidateEdgeIntersections(tri types.Triangle, a, b, c types.Point, cfg Config, mesh MeshProvider) error {
+	if found := edgeIntersections(tri, a, b, c, cfg, mesh, false); len(found) > 0 {
+		return found[0]
+	}
+	return nil
+}
+
+// edgeIntersections returns the conflicts between the edges of tri and the
+// mesh edges, stopping at the first unless all is set. With all set the
+// existing edges are visited in sorted order so the result is stable.
+func edgeIntersections(tri types.Triangle, a, b, c types.Point, cfg Config, mesh MeshProvider, all bool) []EdgeIntersectionError {
 	newEdges := tri.Edges()
 	segments := [][2]types.Point{{a, b}, {b, c}, {c, a}}
+	mode := cfg.mode()
 
 	// Get edge usage counts to check for edge reuse
 	edgeUsage := mesh.EdgeUsageCounts()
 
+	existingEdges := make([]types.Edge, 0, len(mesh.EdgeSet()))
+	for existing := range mesh.EdgeSet() {
+		existingEdges = append(existingEdges, existing)
+	}
+	if all {
+		sort.Slice(existingEdges, func(i, j int) bool {
+			if existingEdges[i][0] != existingEdges[j][0] {
+				return existingEdges[i][0] < existingEdges[j][0]
+			}
+			return existingEdges[i][1] < existingEdges[j][1]
+		})
+	}
+
+	var found []EdgeIntersectionError
 	for i, edge := range newEdges {
 		// Check if this edge already has 2 triangles (maximum allowed)
 		if count, exists := edgeUsage[edge]; exists && count >= 2 {
-			return errTriangleEdgeIntersection // Edge already has 2 triangles, cannot add third
+			// Edge already has 2 triangles, cannot add third
+			found = append(found, EdgeIntersectionError{
+				Edge:     edge,
+				Existing: edge,
+				Point:    midpoint(segments[i][0], segments[i][1]),
+				Kind:     types.IntersectCollinearOverlap,
+			})
+			if !all {
+				return found
+			}
 		}
 
-		for existing := range mesh.EdgeSet() {
+		for _, existing := range existingEdges {
 			if sharesVertex(edge, existing) {
 				continue
 			}
@@ -30,10 +67,9 @@ func ValidateEdgeIntersections(tri types.Triangle, a, b, c types.Point, cfg Conf
 			p1 := mesh.GetVertex(existing.V1())
 			p2 := mesh.GetVertex(existing.V2())
 
-			intersects, proper := predicates.SegmentsIntersect(
+			intersects, proper := mode.SegmentsIntersect(
 				segments[i][0], segments[i][1],
 				p1, p2,
-				cfg.Epsilon,
 			)
 
 			if !intersects {
@@ -41,21 +77,29 @@ func ValidateEdgeIntersections(tri types.Triangle, a, b, c types.Point, cfg Conf
 			}
 
 			if proper {
-				return errTriangleEdgeIntersection
+				point, _ := mode.SegmentIntersectionPoint(segments[i][0], segments[i][1], p1, p2)
+				found = append(found, EdgeIntersectionError{Edge: edge, Existing: existing, Point: point, Kind: types.IntersectProper})
+			} else if mode.PointOnSegment(p1, segments[i][0], segments[i][1]) &&
+				mode.PointOnSegment(p2, segments[i][0], segments[i][1]) {
+				// Detect collinear overlap beyond shared endpoints.
+				found = append(found, EdgeIntersectionError{Edge: edge, Existing: existing, Point: midpoint(p1, p2), Kind: types.IntersectCollinearOverlap})
+			} else {
+				continue
 			}
-
-			// Detect collinear overlap beyond shared endpoints.
-			if predicates.PointOnSegment(p1, segments[i][0], segments[i][1], cfg.Epsilon) &&
-				predicates.PointOnSegment(p2, segments[i][0], segments[i][1], cfg.Epsilon) {
-				return errTriangleEdgeIntersection
+			if !all {
+				return found
 			}
 		}
 	}
 
-	return nil
+	return found
 }
 
 func sharesVertex(e1, e2 types.Edge) bool {
 	return e1.V1() == e2.V1() || e1.V1() == e2.V2() ||
 		e1.V2() == e2.V1() || e1.V2() == e2.V2()
 }
+
+func midpoint(a, b types.Point) types.Point {
+	return types.Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
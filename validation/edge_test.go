@@ -17,6 +17,10 @@ func TestValidateEdgeIntersections(t *testing.T) {
 	if !errors.Is(err, Errors().EdgeIntersection) {
 		t.Fatalf("expected edge intersection error, got %v", err)
 	}
+	var cross EdgeIntersectionError
+	if !errors.As(err, &cross) || cross.Existing != types.NewEdge(0, 1) || cross.Point != (types.Point{1, 0}) {
+		t.Fatalf("expected edge 0-1 crossed at (1, 0), got %+v", err)
+	}
 }
 
 func TestValidateEdgeIntersectionsSharedVertex(t *testing.T) {
@@ -0,0 +1,244 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// FeatureError reports a polygon measurement below its configured minimum.
+//
+// Segments holds the polygon segments involved, where segment i runs from
+// point i to point i+1: the short segment for an edge length, the two
+// segments meeting at the sharp corner for an interior angle, the two
+// closest segments facing each other across the interior for a feature
+// size, and one segment of each polygon for a clearance.
+type FeatureError struct {
+	Check    Check
+	Value    float64
+	Limit    float64
+	Segments []int
+}
+
+func (e FeatureError) Error() string {
+	names := map[Check]string{
+		CheckMinEdgeLength:  "edge length",
+		CheckMinAngle:       "interior angle",
+		CheckMinFeatureSize: "feature size",
+		CheckMinClearance:   "clearance",
+	}
+	name, ok := names[e.Check]
+	if !ok {
+		name = string(e.Check)
+	}
+	return fmt.Sprintf("polygon %s %.6g is less than minimum %.6g (segments %v)", name, e.Value, e.Limit, e.Segments)
+}
+
+// MinEdgeLength returns the length of the shortest segment of poly and its
+// index.
+func MinEdgeLength(poly []types.Point) (float64, int) {
+	best, at := math.Inf(1), -1
+	for i := range poly {
+		if d := math.Sqrt(predicates.Dist2(poly[i], poly[(i+1)%len(poly)])); d < best {
+			best, at = d, i
+		}
+	}
+	return best, at
+}
+
+// MinInteriorAngle returns the smallest interior angle of poly in degrees
+// and the index of its vertex. The interior side follows the winding of
+// poly, so reflex corners measure more than 180 degrees.
+func MinInteriorAngle(poly []types.Point) (float64, int) {
+	n := len(poly)
+	sign := 1.0
+	if predicates.PolygonArea(poly) < 0 {
+		sign = -1
+	}
+
+	best, at := math.Inf(1), -1
+	for i := range poly {
+		cur := poly[i]
+		next := poly[(i+1)%n]
+		prev := poly[(i+n-1)%n]
+		ux, uy := next.X-cur.X, next.Y-cur.Y
+		vx, vy := prev.X-cur.X, prev.Y-cur.Y
+
+		// Turning counter-clockwise from the next edge to the previous one
+		// sweeps the interior of a counter-clockwise polygon.
+		angle := math.Atan2(sign*(ux*vy-uy*vx), ux*vx+uy*vy)
+		if angle < 0 {
+			angle += 2 * math.Pi
+		}
+		if deg := angle * 180 / math.Pi; deg < best {
+			best, at = deg, i
+		}
+	}
+	return best, at
+}
+
+// minFacingCos bounds the cosine of the angle between the segment joining
+// two closest points and each of their segments. MinFeatureSize only counts
+// pairs crossed at 60 degrees or more: neighbours along a finely sampled
+// curve, or the two sides of a corner wider than 60 degrees, meet at
+// shallower angles and lie on the same stretch of boundary rather than on
+// opposite sides of the interior.
+const minFacingCos = 0.5
+
+// MinFeatureSize returns the smallest distance between two segments of poly
+// that face each other across the interior, such as the two long sides of a
+// sliver or the walls of a narrow neck, and the indices of those segments.
+//
+// Two segments face each other when each of their closest points lies on
+// the interior side of the other segment and the line between them crosses
+// both segments steeply (see minFacingCos). Segments that are close only
+// because they follow the same stretch of boundary are skipped, so finely
+// sampling an outline does not lower its feature size. Polygons with no
+// facing segments, including all triangles, measure +Inf.
+func MinFeatureSize(poly []types.Point, eps types.Epsilon) (float64, [2]int) {
+	return minFeatureSize(poly, predicates.Mode{Epsilon: eps})
+}
+
+func minFeatureSize(poly []types.Point, mode predicates.Mode) (float64, [2]int) {
+	n := len(poly)
+	sign := 1
+	if predicates.PolygonArea(poly) < 0 {
+		sign = -1
+	}
+
+	best, at := math.Inf(1), [2]int{-1, -1}
+	for i := 0; i < n; i++ {
+		a1, a2 := poly[i], poly[(i+1)%n]
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			b1, b2 := poly[j], poly[(j+1)%n]
+			d, s, t := closestPoints(a1, a2, b1, b2, mode)
+			if d >= best {
+				continue
+			}
+			if d > 0 {
+				// Non-adjacent segments that touch always count.
+				p, q := lerp(a1, a2, s), lerp(b1, b2, t)
+				if sign*mode.Orient(a1, a2, q) <= 0 || sign*mode.Orient(b1, b2, p) <= 0 ||
+					crossingCos(p, q, a1, a2) > minFacingCos || crossingCos(p, q, b1, b2) > minFacingCos {
+					continue
+				}
+			}
+			best, at = d, [2]int{i, j}
+		}
+	}
+	return best, at
+}
+
+// crossingCos returns the absolute cosine of the angle between p-q and a-b.
+func crossingCos(p, q, a, b types.Point) float64 {
+	ux, uy := q.X-p.X, q.Y-p.Y
+	vx, vy := b.X-a.X, b.Y-a.Y
+	norm := math.Sqrt((ux*ux + uy*uy) * (vx*vx + vy*vy))
+	if norm == 0 {
+		return 1
+	}
+	return math.Abs(ux*vx+uy*vy) / norm
+}
+
+// Clearance returns the smallest distance between the boundaries of a and
+// b, and the segment of each polygon where it occurs.
+func Clearance(a, b []types.Point, eps types.Epsilon) (float64, [2]int) {
+	best, at := math.Inf(1), [2]int{-1, -1}
+	for i := range a {
+		for j := range b {
+			d := segmentDistance(a[i], a[(i+1)%len(a)], b[j], b[(j+1)%len(b)], predicates.Mode{Epsilon: eps})
+			if d < best {
+				best, at = d, [2]int{i, j}
+			}
+		}
+	}
+	return best, at
+}
+
+// ValidateClearance checks that the boundaries of a and b, such as a hole
+// and its perimeter, stay at least minClearance apart. Failures are
+// reported as a FeatureError with Check CheckMinClearance.
+func ValidateClearance(a, b []types.Point, minClearance float64, eps types.Epsilon) error {
+	if minClearance <= 0 || len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	if d, at := Clearance(a, b, eps); d < minClearance {
+		return FeatureError{Check: CheckMinClearance, Value: d, Limit: minClearance, Segments: at[:]}
+	}
+	return nil
+}
+
+// validateFeatures applies the edge length, interior angle and feature size
+// limits of cfg, returning every violation.
+func validateFeatures(poly []types.Point, cfg PolygonConfig) []FeatureError {
+	var found []FeatureError
+	n := len(poly)
+
+	if cfg.MinEdgeLength > 0 {
+		if d, i := MinEdgeLength(poly); d < cfg.MinEdgeLength {
+			found = append(found, FeatureError{Check: CheckMinEdgeLength, Value: d, Limit: cfg.MinEdgeLength, Segments: []int{i}})
+		}
+	}
+	if cfg.MinAngle > 0 {
+		if angle, i := MinInteriorAngle(poly); angle < cfg.MinAngle {
+			found = append(found, FeatureError{Check: CheckMinAngle, Value: angle, Limit: cfg.MinAngle, Segments: []int{(i + n - 1) % n, i}})
+		}
+	}
+	if cfg.MinFeatureSize > 0 {
+		if d, at := minFeatureSize(poly, cfg.mode()); d < cfg.MinFeatureSize {
+			found = append(found, FeatureError{Check: CheckMinFeatureSize, Value: d, Limit: cfg.MinFeatureSize, Segments: at[:]})
+		}
+	}
+	return found
+}
+
+// segmentDistance returns the distance between segments a1-a2 and b1-b2,
+// zero when they intersect.
+func segmentDistance(a1, a2, b1, b2 types.Point, mode predicates.Mode) float64 {
+	d, _, _ := closestPoints(a1, a2, b1, b2, mode)
+	return d
+}
+
+// closestPoints returns the distance between segments a1-a2 and b1-b2 and
+// the parameters along each segment of a pair of closest points. When the
+// segments intersect the distance is zero and the parameters are those of
+// the closest endpoint pair.
+func closestPoints(a1, a2, b1, b2 types.Point, mode predicates.Mode) (d, s, t float64) {
+	d, s, t = math.Inf(1), 0, 0
+	try := func(dist, ps, pt float64) {
+		if dist < d {
+			d, s, t = dist, ps, pt
+		}
+	}
+	for k, p := range [2]types.Point{a1, a2} {
+		u := project(p, b1, b2)
+		try(math.Sqrt(predicates.Dist2(p, lerp(b1, b2, u))), float64(k), u)
+	}
+	for k, p := range [2]types.Point{b1, b2} {
+		u := project(p, a1, a2)
+		try(math.Sqrt(predicates.Dist2(p, lerp(a1, a2, u))), u, float64(k))
+	}
+	if intersects, _ := mode.SegmentsIntersect(a1, a2, b1, b2); intersects {
+		d = 0
+	}
+	return d, s, t
+}
+
+// project returns the parameter of the point of segment a-b closest to p.
+func project(p, a, b types.Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length2 := dx*dx + dy*dy
+	if length2 == 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/length2))
+}
+
+func lerp(a, b types.Point, t float64) types.Point {
+	return types.Point{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)}
+}
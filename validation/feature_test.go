@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestMinFeatureSizeSliver(t *testing.T) {
+	// A diagonal strip sqrt(2) wide whose bounding box is 31 wide.
+	sliver := []types.Point{{X: 0, Y: 0}, {X: 30, Y: 30}, {X: 29, Y: 31}, {X: -1, Y: 1}}
+
+	if err := ValidatePolygon(sliver, WithPolygonMinWidth(10)); err != nil {
+		t.Fatalf("bounding box width should pass, got %v", err)
+	}
+
+	err := ValidatePolygon(sliver, WithPolygonMinFeatureSize(10))
+	var feature FeatureError
+	if !errors.As(err, &feature) {
+		t.Fatalf("expected a FeatureError, got %v", err)
+	}
+	if feature.Check != CheckMinFeatureSize || math.Abs(feature.Value-math.Sqrt2) > 1e-12 ||
+		!reflect.DeepEqual(feature.Segments, []int{0, 2}) {
+		t.Fatalf("unexpected feature error %+v", feature)
+	}
+
+	if d, _ := MinFeatureSize([]types.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}, types.DefaultEpsilon()); !math.IsInf(d, 1) {
+		t.Fatalf("expected a triangle to have no feature size, got %v", d)
+	}
+}
+
+func TestMinFeatureSizeFineSampling(t *testing.T) {
+	// A 100x100 square with a vertex every 5 units: neighbouring segments
+	// are 5 apart, but the square is 100 thick.
+	var square []types.Point
+	for _, side := range [][2]types.Point{
+		{{X: 0, Y: 0}, {X: 5, Y: 0}}, {{X: 100, Y: 0}, {X: 0, Y: 5}},
+		{{X: 100, Y: 100}, {X: -5, Y: 0}}, {{X: 0, Y: 100}, {X: 0, Y: -5}},
+	} {
+		for k := 0; k < 20; k++ {
+			square = append(square, types.Point{X: side[0].X + float64(k)*side[1].X, Y: side[0].Y + float64(k)*side[1].Y})
+		}
+	}
+	if err := ValidatePolygon(square, WithPolygonMinFeatureSize(10)); err != nil {
+		t.Fatalf("finely sampled square should pass, got %v", err)
+	}
+	if d, _ := MinFeatureSize(square, types.DefaultEpsilon()); d != 100 {
+		t.Fatalf("expected feature size 100, got %v", d)
+	}
+
+	// A circle of radius 50 sampled every 3.6 degrees.
+	circle := make([]types.Point, 100)
+	for k := range circle {
+		a := 2 * math.Pi * float64(k) / float64(len(circle))
+		circle[k] = types.Point{X: 50 * math.Cos(a), Y: 50 * math.Sin(a)}
+	}
+	if err := ValidatePolygon(circle, WithPolygonMinFeatureSize(10)); err != nil {
+		t.Fatalf("finely sampled circle should pass, got %v", err)
+	}
+}
+
+func TestMinFeatureSizeNeck(t *testing.T) {
+	// Two 10x10 squares joined by a neck 1 unit wide.
+	dumbbell := []types.Point{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 4.5}, {X: 20, Y: 4.5}, {X: 20, Y: 0}, {X: 30, Y: 0},
+		{X: 30, Y: 10}, {X: 20, Y: 10}, {X: 20, Y: 5.5}, {X: 10, Y: 5.5}, {X: 10, Y: 10}, {X: 0, Y: 10},
+	}
+	d, at := MinFeatureSize(dumbbell, types.DefaultEpsilon())
+	if math.Abs(d-1) > 1e-12 || at != [2]int{2, 8} {
+		t.Fatalf("expected the neck to measure 1 at segments [2 8], got %v at %v", d, at)
+	}
+}
+
+func TestMinInteriorAngle(t *testing.T) {
+	spike := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 1}}
+	want := math.Atan(0.1) * 180 / math.Pi
+	for _, poly := range [][]types.Point{spike, {spike[2], spike[1], spike[0]}} {
+		angle, at := MinInteriorAngle(poly)
+		if math.Abs(angle-want) > 1e-9 || poly[at] != (types.Point{X: 10, Y: 0}) {
+			t.Fatalf("expected %v degrees at (10, 0), got %v at %v", want, angle, poly[at])
+		}
+	}
+
+	// The reflex corner of an L measures 270 degrees.
+	l := []types.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}}
+	if angle, _ := MinInteriorAngle(l); math.Abs(angle-90) > 1e-9 {
+		t.Fatalf("expected 90 degrees, got %v", angle)
+	}
+	if err := ValidatePolygon(l, WithPolygonMinAngle(60)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := ValidatePolygon(spike, WithPolygonMinAngle(10)); err == nil {
+		t.Fatal("expected the spike to fail a 10 degree minimum")
+	}
+}
+
+func TestMinEdgeLengthAndClearance(t *testing.T) {
+	square := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	notched := []types.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 9.5, Y: 10}, {X: 0, Y: 10}}
+	if err := ValidatePolygon(square, WithPolygonMinEdgeLength(1)); err != nil {
+		t.Fatal(err)
+	}
+	var feature FeatureError
+	if err := ValidatePolygon(notched, WithPolygonMinEdgeLength(1)); !errors.As(err, &feature) || feature.Segments[0] != 2 {
+		t.Fatalf("expected segment 2 to be too short, got %v", err)
+	}
+
+	hole := []types.Point{{X: 1, Y: 1}, {X: 1, Y: 9}, {X: 9.5, Y: 9}, {X: 9, Y: 1}}
+	d, at := Clearance(hole, square, types.DefaultEpsilon())
+	if math.Abs(d-0.5) > 1e-12 || at != [2]int{1, 1} {
+		t.Fatalf("expected clearance 0.5 between segments 1 and 1, got %v at %v", d, at)
+	}
+	if err := ValidateClearance(hole, square, 1, types.DefaultEpsilon()); !errors.As(err, &feature) || feature.Check != CheckMinClearance {
+		t.Fatalf("expected a clearance error, got %v", err)
+	}
+	if err := ValidateClearance(hole, square, 0.5, types.DefaultEpsilon()); err != nil {
+		t.Fatalf("expected a clearance of exactly 0.5 to pass, got %v", err)
+	}
+}
@@ -11,7 +11,10 @@ import (
 
 // PolygonConfig holds validation options for a polygon.
 type PolygonConfig struct {
-	Epsilon   float64 // Geometric tolerance
+	Epsilon   float64       // Absolute geometric tolerance, used when Tolerance is zero
+	Tolerance types.Epsilon // Scale-relative tolerance, replaces Epsilon when set
+	Exact     bool          // Decide intersections with exact arithmetic
+
 	MinArea   float64 // Minimum allowed area (0 = no limit)
 	MinWidth  float64 // Minimum bounding box width (0 = no limit)
 	MinHeight float64 // Minimum bounding box height (0 = no limit)
@@ -19,6 +22,10 @@ type PolygonConfig struct {
 	MaxWidth  float64 // Maximum bounding box width (0 = no limit)
 	MaxHeight float64 // Maximum bounding box height (0 = no limit)
 
+	MinEdgeLength  float64 // Minimum segment length (0 = no limit)
+	MinAngle       float64 // Minimum interior angle in degrees (0 = no limit)
+	MinFeatureSize float64 // Minimum distance between facing segments (0 = no limit)
+
 	AllowSelfIntersection bool // Allow self-intersecting polygons
 	RequireCCW            bool // Require counter-clockwise winding
 	RequireCW             bool // Require clockwise winding
@@ -34,6 +41,36 @@ func WithPolygonEpsilon(eps float64) PolygonOption {
 	}
 }
 
+// WithPolygonTolerance sets a scale-relative geometric tolerance, which takes
+// precedence over WithPolygonEpsilon.
+func WithPolygonTolerance(eps types.Epsilon) PolygonOption {
+	return func(c *PolygonConfig) {
+		c.Tolerance = types.NewEpsilon(eps.Abs, eps.Rel)
+	}
+}
+
+// tolerance returns Tolerance when set, otherwise Epsilon as an absolute
+// tolerance.
+func (c PolygonConfig) tolerance() types.Epsilon {
+	if c.Tolerance != (types.Epsilon{}) {
+		return c.Tolerance
+	}
+	return types.NewEpsilon(c.Epsilon, 0)
+}
+
+// mode returns the predicate mode the checks use.
+func (c PolygonConfig) mode() predicates.Mode {
+	return predicates.Mode{Epsilon: c.tolerance(), Exact: c.Exact}
+}
+
+// WithPolygonExact decides self-intersections and feature contacts with
+// exact arithmetic instead of a tolerance.
+func WithPolygonExact(exact bool) PolygonOption {
+	return func(c *PolygonConfig) {
+		c.Exact = exact
+	}
+}
+
 // WithPolygonMinArea sets the minimum allowed area.
 //
 // This checks the absolute value of the polygon's area, so it works for both
@@ -85,6 +122,41 @@ func WithPolygonMaxHeight(height float64) PolygonOption {
 	}
 }
 
+// WithPolygonMinEdgeLength sets the minimum segment length.
+func WithPolygonMinEdgeLength(length float64) PolygonOption {
+	return func(c *PolygonConfig) {
+		c.MinEdgeLength = length
+	}
+}
+
+// WithPolygonMinAngle sets the minimum interior angle in degrees.
+//
+// Interior angles follow the polygon's winding, so this rejects spikes on
+// either CCW or CW polygons while reflex corners (over 180 degrees) pass.
+func WithPolygonMinAngle(degrees float64) PolygonOption {
+	return func(c *PolygonConfig) {
+		c.MinAngle = degrees
+	}
+}
+
+// WithPolygonMinFeatureSize sets the minimum distance between segments that
+// face each other across the interior of the polygon (see MinFeatureSize).
+//
+// Unlike WithPolygonMinWidth, which measures the bounding box, this catches
+// thin parts of the polygon itself: a long diagonal sliver one unit wide
+// fails a minimum feature size of 10 however large its bounding box is.
+// Neighbouring segments along the boundary are not compared, so sampling a
+// smooth outline finely does not lower the measured size.
+//
+// Example:
+//
+//	err := validation.ValidatePolygon(poly, validation.WithPolygonMinFeatureSize(10))
+func WithPolygonMinFeatureSize(size float64) PolygonOption {
+	return func(c *PolygonConfig) {
+		c.MinFeatureSize = size
+	}
+}
+
 // WithAllowSelfIntersection allows self-intersecting polygons.
 func WithAllowSelfIntersection(allow bool) PolygonOption {
 	return func(c *PolygonConfig) {
@@ -116,6 +188,9 @@ func DefaultPolygonConfig() PolygonConfig {
 		MaxArea:               0,
 		MaxWidth:              0,
 		MaxHeight:             0,
+		MinEdgeLength:         0,
+		MinAngle:              0,
+		MinFeatureSize:        0,
 		AllowSelfIntersection: false,
 		RequireCCW:            false,
 		RequireCW:             false,
@@ -148,7 +223,7 @@ func ValidatePolygon(poly []types.Point, opts ...PolygonOption) error {
 
 	// Check for self-intersection
 	if !cfg.AllowSelfIntersection {
-		if predicates.PolygonSelfIntersects(poly, cfg.Epsilon) {
+		if cfg.mode().PolygonSelfIntersects(poly) {
 			return fmt.Errorf("polygon self-intersects")
 		}
 	}
@@ -194,6 +269,11 @@ func ValidatePolygon(poly []types.Point, opts ...PolygonOption) error {
 		return fmt.Errorf("polygon height %.6g exceeds maximum %.6g", height, cfg.MaxHeight)
 	}
 
+	// Check true thickness constraints
+	if found := validateFeatures(poly, cfg); len(found) > 0 {
+		return found[0]
+	}
+
 	return nil
 }
 
@@ -332,7 +412,7 @@ func ValidatePolygonDetailed(poly []types.Point, opts ...PolygonOption) PolygonV
 	result.Bounds = predicates.PolygonBounds(poly)
 	result.Width = result.Bounds.Max.X - result.Bounds.Min.X
 	result.Height = result.Bounds.Max.Y - result.Bounds.Min.Y
-	result.SelfIntersects = predicates.PolygonSelfIntersects(poly, cfg.Epsilon)
+	result.SelfIntersects = cfg.mode().PolygonSelfIntersects(poly)
 
 	// Run validation
 	result.Error = ValidatePolygon(poly, opts...)
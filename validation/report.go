@@ -0,0 +1,339 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iceisfun/gomesh/predicates"
+	"github.com/iceisfun/gomesh/types"
+)
+
+// Severity ranks a validation finding.
+type Severity int
+
+const (
+	// SeverityWarning marks a problem the enabled checks allow, such as a
+	// self-intersection with WithAllowSelfIntersection.
+	SeverityWarning Severity = iota
+
+	// SeverityError marks a failed check that ValidatePolygon or
+	// ValidateTriangle would reject.
+	SeverityError
+)
+
+// String returns "warning" or "error".
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// MarshalText encodes the severity by name, so it reads naturally in JSON.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name written by MarshalText.
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "warning":
+		*s = SeverityWarning
+	case "error":
+		*s = SeverityError
+	default:
+		return fmt.Errorf("validation: unknown severity %q", text)
+	}
+	return nil
+}
+
+// Check names the validation check behind a finding.
+type Check string
+
+const (
+	CheckVertexCount      Check = "vertex_count"
+	CheckSelfIntersection Check = "self_intersection"
+	CheckZeroLengthEdge   Check = "zero_length_edge"
+	CheckMinArea          Check = "min_area"
+	CheckMaxArea          Check = "max_area"
+	CheckWinding          Check = "winding"
+	CheckMinWidth         Check = "min_width"
+	CheckMaxWidth         Check = "max_width"
+	CheckMinHeight        Check = "min_height"
+	CheckMaxHeight        Check = "max_height"
+	CheckMinEdgeLength    Check = "min_edge_length"
+	CheckMinAngle         Check = "min_angle"
+	CheckMinFeatureSize   Check = "min_feature_size"
+	CheckMinClearance     Check = "min_clearance"
+
+	CheckDegenerate        Check = "degenerate"
+	CheckDuplicate         Check = "duplicate"
+	CheckOpposingDuplicate Check = "opposing_duplicate"
+	CheckVertexInside      Check = "vertex_inside"
+	CheckEdgeIntersection  Check = "edge_intersection"
+)
+
+// Measurement is a measured value next to the limit it was checked against.
+type Measurement struct {
+	Value float64 `json:"value"`
+	Limit float64 `json:"limit"`
+}
+
+// Finding is one problem reported by a collect-all validation.
+//
+// Only the fields that apply to Check are set. Segments indexes polygon
+// segments, where segment i runs from point i to point i+1; a
+// self-intersection lists the two crossing segments and the crossing Point.
+// Vertices and Edges name mesh elements for triangle checks.
+type Finding struct {
+	Check       Check            `json:"check"`
+	Severity    Severity         `json:"severity"`
+	Message     string           `json:"message"`
+	Measurement *Measurement     `json:"measurement,omitempty"`
+	Segments    []int            `json:"segments,omitempty"`
+	Vertices    []types.VertexID `json:"vertices,omitempty"`
+	Edges       []types.Edge     `json:"edges,omitempty"`
+	Point       *types.Point     `json:"point,omitempty"`
+}
+
+// Report collects the findings of every enabled check. Valid is true when
+// no finding has SeverityError.
+type Report struct {
+	Valid    bool      `json:"valid"`
+	Findings []Finding `json:"findings"`
+}
+
+func (r *Report) add(f Finding) {
+	r.Findings = append(r.Findings, f)
+	if f.Severity == SeverityError {
+		r.Valid = false
+	}
+}
+
+// ValidatePolygonAll runs every check ValidatePolygon would run, without
+// stopping at the first failure, and reports all findings.
+//
+// Every crossing pair of segments is listed, together with zero-length
+// segments as warnings. A polygon with fewer than three vertices gets a
+// single vertex count finding.
+//
+// Example:
+//
+//	report := validation.ValidatePolygonAll(poly, validation.WithPolygonMinArea(50))
+//	data, _ := json.Marshal(report)
+func ValidatePolygonAll(poly []types.Point, opts ...PolygonOption) Report {
+	cfg := DefaultPolygonConfig()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	report := Report{Valid: true, Findings: []Finding{}}
+	mode := cfg.mode()
+
+	n := len(poly)
+	if n < 3 {
+		report.add(Finding{
+			Check:       CheckVertexCount,
+			Severity:    SeverityError,
+			Message:     fmt.Sprintf("polygon must have at least 3 vertices, got %d", n),
+			Measurement: &Measurement{Value: float64(n), Limit: 3},
+		})
+		return report
+	}
+
+	// Same pairs as predicates.PolygonSelfIntersects
+	severity := SeverityError
+	if cfg.AllowSelfIntersection {
+		severity = SeverityWarning
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			a1, a2 := poly[i], poly[(i+1)%n]
+			b1, b2 := poly[j], poly[(j+1)%n]
+			if intersects, proper := mode.SegmentsIntersect(a1, a2, b1, b2); !intersects || !proper {
+				continue
+			}
+			point, _ := mode.SegmentIntersectionPoint(a1, a2, b1, b2)
+			report.add(Finding{
+				Check:    CheckSelfIntersection,
+				Severity: severity,
+				Message:  fmt.Sprintf("segments %d and %d cross at (%g, %g)", i, j, point.X, point.Y),
+				Segments: []int{i, j},
+				Point:    &point,
+			})
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		p, q := poly[i], poly[(i+1)%n]
+		if d := mode.Distance(p, q); predicates.Dist2(p, q) <= d*d {
+			report.add(Finding{
+				Check:    CheckZeroLengthEdge,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("segment %d has zero length", i),
+				Segments: []int{i},
+			})
+		}
+	}
+
+	area := predicates.PolygonArea(poly)
+	absArea := math.Abs(area)
+	bounds := predicates.PolygonBounds(poly)
+	width := bounds.Max.X - bounds.Min.X
+	height := bounds.Max.Y - bounds.Min.Y
+
+	limits := []struct {
+		check    Check
+		name     string
+		value    float64
+		limit    float64
+		violated bool
+	}{
+		{CheckMinArea, "area", absArea, cfg.MinArea, cfg.MinArea > 0 && absArea < cfg.MinArea},
+		{CheckMaxArea, "area", absArea, cfg.MaxArea, cfg.MaxArea > 0 && absArea > cfg.MaxArea},
+		{CheckMinWidth, "width", width, cfg.MinWidth, cfg.MinWidth > 0 && width < cfg.MinWidth},
+		{CheckMaxWidth, "width", width, cfg.MaxWidth, cfg.MaxWidth > 0 && width > cfg.MaxWidth},
+		{CheckMinHeight, "height", height, cfg.MinHeight, cfg.MinHeight > 0 && height < cfg.MinHeight},
+		{CheckMaxHeight, "height", height, cfg.MaxHeight, cfg.MaxHeight > 0 && height > cfg.MaxHeight},
+	}
+	for _, l := range limits {
+		if !l.violated {
+			continue
+		}
+		relation := "is less than minimum"
+		if l.value > l.limit {
+			relation = "exceeds maximum"
+		}
+		report.add(Finding{
+			Check:       l.check,
+			Severity:    SeverityError,
+			Message:     fmt.Sprintf("polygon %s %.6g %s %.6g", l.name, l.value, relation, l.limit),
+			Measurement: &Measurement{Value: l.value, Limit: l.limit},
+		})
+	}
+
+	for _, f := range validateFeatures(poly, cfg) {
+		report.add(Finding{
+			Check:       f.Check,
+			Severity:    SeverityError,
+			Message:     f.Error(),
+			Measurement: &Measurement{Value: f.Value, Limit: f.Limit},
+			Segments:    f.Segments,
+		})
+	}
+
+	if cfg.RequireCCW && area < 0 {
+		report.add(Finding{
+			Check:       CheckWinding,
+			Severity:    SeverityError,
+			Message:     "polygon has clockwise winding, but counter-clockwise is required",
+			Measurement: &Measurement{Value: area, Limit: 0},
+		})
+	}
+	if cfg.RequireCW && area > 0 {
+		report.add(Finding{
+			Check:       CheckWinding,
+			Severity:    SeverityError,
+			Message:     "polygon has counter-clockwise winding, but clockwise is required",
+			Measurement: &Measurement{Value: area, Limit: 0},
+		})
+	}
+
+	return report
+}
+
+// ValidatePolygonLoopAll is ValidatePolygonAll for a polygon loop.
+func ValidatePolygonLoopAll(vp types.VertexProvider, loop types.PolygonLoop, opts ...PolygonOption) Report {
+	return ValidatePolygonAll(loop.ToPoints(vp), opts...)
+}
+
+// ValidateTriangleAll runs every check enabled in cfg, without stopping at
+// the first failure, and reports all findings: each vertex inside the
+// triangle and each conflicting mesh edge gets its own finding.
+func ValidateTriangleAll(tri types.Triangle, a, b, c types.Point, cfg Config, mesh MeshProvider) Report {
+	report := Report{Valid: true, Findings: []Finding{}}
+
+	mode := cfg.mode()
+	area := predicates.Area2(a, b, c)
+	if mode.Orient(a, b, c) == 0 {
+		report.add(Finding{
+			Check:       CheckDegenerate,
+			Severity:    SeverityError,
+			Message:     "triangle is degenerate (collinear)",
+			Vertices:    []types.VertexID{tri.V1(), tri.V2(), tri.V3()},
+			Measurement: &Measurement{Value: math.Abs(area), Limit: mode.AreaTolerance(a, b, c)},
+		})
+	}
+
+	key := CanonicalTriangleKey(tri)
+	if existing, exists := mesh.HasTriangleWithKey(key); exists {
+		exA := mesh.GetVertex(existing.V1())
+		exB := mesh.GetVertex(existing.V2())
+		exC := mesh.GetVertex(existing.V3())
+		vertices := []types.VertexID{existing.V1(), existing.V2(), existing.V3()}
+
+		if cfg.ErrorOnDuplicateTriangle {
+			report.add(Finding{
+				Check:    CheckDuplicate,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("triangle duplicates existing triangle %v", existing),
+				Vertices: vertices,
+			})
+		} else if cfg.ErrorOnOpposingDuplicate && area*predicates.Area2(exA, exB, exC) < 0 {
+			report.add(Finding{
+				Check:    CheckOpposingDuplicate,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("triangle duplicates existing triangle %v with opposing winding", existing),
+				Vertices: vertices,
+			})
+		}
+	}
+
+	if cfg.ValidateVertexInside {
+		for i := 0; i < mesh.NumVertices(); i++ {
+			vid := types.VertexID(i)
+			if vid == tri.V1() || vid == tri.V2() || vid == tri.V3() || !mesh.IsValidVertexID(vid) {
+				continue
+			}
+			p := mesh.GetVertex(vid)
+			if mode.PointStrictlyInTriangle(p, a, b, c) {
+				report.add(Finding{
+					Check:    CheckVertexInside,
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("vertex %d at (%g, %g) lies inside triangle", vid, p.X, p.Y),
+					Vertices: []types.VertexID{vid},
+					Point:    &p,
+				})
+			}
+		}
+	}
+
+	if cfg.ValidateEdgeIntersection {
+		for _, e := range edgeIntersections(tri, a, b, c, cfg, mesh, true) {
+			point := e.Point
+			message := fmt.Sprintf("edge %d-%d intersects edge %d-%d at (%g, %g)",
+				e.Edge.V1(), e.Edge.V2(), e.Existing.V1(), e.Existing.V2(), point.X, point.Y)
+			if e.Existing == e.Edge {
+				message = fmt.Sprintf("edge %d-%d already has two triangles", e.Edge.V1(), e.Edge.V2())
+			}
+			report.add(Finding{
+				Check:    CheckEdgeIntersection,
+				Severity: SeverityError,
+				Message:  message,
+				Edges:    []types.Edge{e.Edge, e.Existing},
+				Point:    &point,
+			})
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/iceisfun/gomesh/types"
+)
+
+func TestValidatePolygonAll(t *testing.T) {
+	// A double bow tie with a repeated point, too small and too wide.
+	poly := []types.Point{
+		{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 0}, {X: 8, Y: 2}, {X: 8, Y: 0}, {X: 8, Y: 0}, {X: 4, Y: 2},
+	}
+	report := ValidatePolygonAll(poly,
+		WithPolygonMinArea(100),
+		WithPolygonMaxWidth(5),
+	)
+	if report.Valid {
+		t.Fatal("expected an invalid report")
+	}
+
+	var crossings [][]int
+	checks := make(map[Check]Finding)
+	for _, f := range report.Findings {
+		checks[f.Check] = f
+		if f.Check == CheckSelfIntersection {
+			crossings = append(crossings, f.Segments)
+		}
+	}
+	if !reflect.DeepEqual(crossings, [][]int{{1, 6}, {2, 5}}) {
+		t.Fatalf("unexpected crossing segments %v", crossings)
+	}
+	if f := checks[CheckZeroLengthEdge]; f.Severity != SeverityWarning || !reflect.DeepEqual(f.Segments, []int{4}) {
+		t.Fatalf("expected a zero length warning for segment 4, got %+v", f)
+	}
+	if f := checks[CheckMaxWidth]; f.Measurement == nil || *f.Measurement != (Measurement{Value: 8, Limit: 5}) {
+		t.Fatalf("expected width 8 against 5, got %+v", f)
+	}
+	if f := checks[CheckMinArea]; f.Measurement == nil || *f.Measurement != (Measurement{Value: 0, Limit: 100}) {
+		t.Fatalf("expected the cancelling lobes to measure area 0, got %+v", f)
+	}
+
+	cw := []types.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}
+	if report := ValidatePolygonAll(cw, WithRequireCCW(true)); report.Valid || len(report.Findings) != 1 || report.Findings[0].Check != CheckWinding {
+		t.Fatalf("expected a single winding finding, got %+v", report.Findings)
+	}
+
+	// Allowed self-intersections are downgraded to warnings.
+	report = ValidatePolygonAll(poly, WithAllowSelfIntersection(true))
+	if !report.Valid {
+		t.Fatalf("expected only warnings, got %+v", report.Findings)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, report) {
+		t.Fatalf("JSON round trip changed the report:\n%s", data)
+	}
+}
+
+func TestValidateTriangleAll(t *testing.T) {
+	// The candidate covers two loose vertices and cuts every corner off the
+	// existing triangle.
+	mesh := newMockMesh([]types.Point{
+		{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 4},
+		{X: 1, Y: 1}, {X: 3, Y: 1},
+		{X: -1, Y: 2}, {X: 5, Y: 2}, {X: 2, Y: -2},
+	})
+	mesh.addTriangle(types.Triangle{0, 1, 2})
+
+	cfg := Config{Epsilon: 1e-9, ValidateVertexInside: true, ValidateEdgeIntersection: true}
+	tri := types.Triangle{5, 7, 6}
+	report := ValidateTriangleAll(tri, mesh.GetVertex(5), mesh.GetVertex(7), mesh.GetVertex(6), cfg, mesh)
+	if report.Valid {
+		t.Fatal("expected an invalid report")
+	}
+
+	counts := make(map[Check]int)
+	for _, f := range report.Findings {
+		counts[f.Check]++
+	}
+	if counts[CheckVertexInside] != 2 || counts[CheckEdgeIntersection] != 6 {
+		t.Fatalf("unexpected findings %+v", report.Findings)
+	}
+
+	if err := ValidateTriangle(tri, mesh.GetVertex(5), mesh.GetVertex(7), mesh.GetVertex(6), cfg, mesh); err == nil {
+		t.Fatal("expected ValidateTriangle to agree that the triangle is invalid")
+	}
+}
@@ -2,7 +2,7 @@ package validation
 
 import (
 	"errors"
-	"math"
+	"fmt"
 
 	"github.com/iceisfun/gomesh/predicates"
 	"github.com/iceisfun/gomesh/types"
@@ -10,16 +10,42 @@ import (
 
 // Config captures validation options required for triangle checks.
 type Config struct {
-	Epsilon                  float64
+	// Epsilon is an absolute tolerance, used when Tolerance is zero.
+	Epsilon float64
+	// Tolerance is a scale-relative tolerance. When set it replaces Epsilon,
+	// so the checks behave the same at any coordinate magnitude.
+	Tolerance types.Epsilon
+	// Exact decides orientation and containment with exact arithmetic,
+	// ignoring Epsilon and Tolerance.
+	Exact bool
+
 	ErrorOnDuplicateTriangle bool
 	ErrorOnOpposingDuplicate bool
 	ValidateVertexInside     bool
 	ValidateEdgeIntersection bool
 }
 
+// tolerance returns the tolerance the checks use: Tolerance when set,
+// otherwise Epsilon as an absolute tolerance.
+func (c Config) tolerance() types.Epsilon {
+	if c.Tolerance != (types.Epsilon{}) {
+		return c.Tolerance
+	}
+	return types.NewEpsilon(c.Epsilon, 0)
+}
+
+// mode returns the predicate mode the checks use.
+func (c Config) mode() predicates.Mode {
+	return predicates.Mode{Epsilon: c.tolerance(), Exact: c.Exact}
+}
+
 // MeshProvider exposes the minimal mesh functionality needed for validation.
+//
+// Vertex IDs below NumVertices for which IsValidVertexID is false (for
+// example vertices removed by an edge collapse) are ignored.
 type MeshProvider interface {
 	NumVertices() int
+	IsValidVertexID(types.VertexID) bool
 	GetVertex(types.VertexID) types.Point
 	EdgeSet() map[types.Edge]struct{}
 	EdgeUsageCounts() map[types.Edge]int
@@ -39,10 +65,54 @@ var (
 	errTriangleEdgeIntersection = errors.New("validation: edge intersection")
 )
 
+// VertexInsideError reports the existing vertex that lies strictly inside
+// the triangle being validated. It matches Errors().VertexInside with
+// errors.Is.
+type VertexInsideError struct {
+	Vertex types.VertexID
+	Point  types.Point
+}
+
+func (e VertexInsideError) Error() string {
+	return fmt.Sprintf("validation: vertex %d at (%g, %g) inside triangle", e.Vertex, e.Point.X, e.Point.Y)
+}
+
+// Unwrap returns the vertex inside sentinel.
+func (e VertexInsideError) Unwrap() error {
+	return errTriangleContainsVertex
+}
+
+// EdgeIntersectionError reports the existing mesh edge that an edge of the
+// triangle being validated crosses or overlaps. It matches
+// Errors().EdgeIntersection with errors.Is.
+//
+// When Existing equals Edge, the edge is already used by two triangles and
+// Point is its midpoint.
+type EdgeIntersectionError struct {
+	Edge     types.Edge
+	Existing types.Edge
+	Point    types.Point
+	Kind     types.IntersectionType
+}
+
+func (e EdgeIntersectionError) Error() string {
+	if e.Existing == e.Edge {
+		return fmt.Sprintf("validation: edge %d-%d already has two triangles", e.Edge.V1(), e.Edge.V2())
+	}
+	return fmt.Sprintf("validation: edge %d-%d intersects edge %d-%d at (%g, %g)",
+		e.Edge.V1(), e.Edge.V2(), e.Existing.V1(), e.Existing.V2(), e.Point.X, e.Point.Y)
+}
+
+// Unwrap returns the edge intersection sentinel.
+func (e EdgeIntersectionError) Unwrap() error {
+	return errTriangleEdgeIntersection
+}
+
 // ValidateTriangle performs all enabled validation checks on a triangle.
 func ValidateTriangle(tri types.Triangle, a, b, c types.Point, cfg Config, mesh MeshProvider) error {
+	mode := cfg.mode()
 	area := predicates.Area2(a, b, c)
-	if math.Abs(area) <= cfg.Epsilon {
+	if mode.Orient(a, b, c) == 0 {
 		return errTriangleDegenerate
 	}
 
@@ -66,15 +136,14 @@ func ValidateTriangle(tri types.Triangle, a, b, c types.Point, cfg Config, mesh
 	}
 
 	if cfg.ValidateVertexInside {
-		eps := cfg.Epsilon
 		for i := 0; i < mesh.NumVertices(); i++ {
 			vid := types.VertexID(i)
-			if vid == tri.V1() || vid == tri.V2() || vid == tri.V3() {
+			if vid == tri.V1() || vid == tri.V2() || vid == tri.V3() || !mesh.IsValidVertexID(vid) {
 				continue
 			}
 			p := mesh.GetVertex(vid)
-			if predicates.PointStrictlyInTriangle(p, a, b, c, eps) {
-				return errTriangleContainsVertex
+			if mode.PointStrictlyInTriangle(p, a, b, c) {
+				return VertexInsideError{Vertex: vid, Point: p}
 			}
 		}
 	}
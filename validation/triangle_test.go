@@ -23,6 +23,10 @@ func newMockMesh(points []types.Point) *mockMesh {
 
 func (m *mockMesh) NumVertices() int { return len(m.vertices) }
 
+func (m *mockMesh) IsValidVertexID(id types.VertexID) bool {
+	return id >= 0 && int(id) < len(m.vertices)
+}
+
 func (m *mockMesh) GetVertex(id types.VertexID) types.Point { return m.vertices[id] }
 
 func (m *mockMesh) EdgeSet() map[types.Edge]struct{} { return m.edgeSet }
@@ -93,3 +97,47 @@ func TestValidateTriangleVertexInside(t *testing.T) {
 		t.Fatalf("expected vertex-inside error, got %v", err)
 	}
 }
+
+func TestValidateTriangleTolerance(t *testing.T) {
+	// A sliver 1e-7 off its base, far from the origin.
+	mesh := newMockMesh([]types.Point{{500000, 4000000}, {500100, 4000100}, {500050, 4000050 + 1e-7}})
+	tri := types.Triangle{0, 1, 2}
+	a, b, c := mesh.vertices[0], mesh.vertices[1], mesh.vertices[2]
+
+	if err := ValidateTriangle(tri, a, b, c, Config{Epsilon: 1e-9}, mesh); err != nil {
+		t.Fatalf("expected the absolute epsilon to accept the sliver, got %v", err)
+	}
+	cfg := Config{Epsilon: 1e-9, Tolerance: types.DefaultEpsilon()}
+	if err := ValidateTriangle(tri, a, b, c, cfg, mesh); !errors.Is(err, Errors().Degenerate) {
+		t.Fatalf("expected the scaled tolerance to reject the sliver, got %v", err)
+	}
+
+	// A small, well shaped triangle whose twice-area is below 1e-9.
+	small := newMockMesh([]types.Point{{0, 0}, {3e-5, 0}, {0, 3e-5}})
+	a, b, c = small.vertices[0], small.vertices[1], small.vertices[2]
+	if err := ValidateTriangle(tri, a, b, c, cfg, small); err != nil {
+		t.Fatalf("expected the scaled tolerance to accept the small triangle, got %v", err)
+	}
+}
+
+func TestValidateTriangleExact(t *testing.T) {
+	// The apex sits 1e-12 off the base: degenerate under the default
+	// tolerance, a valid (if thin) triangle in exact arithmetic.
+	mesh := newMockMesh([]types.Point{{0, 0}, {1, 1}, {0.5, 0.5 + 1e-12}, {0.5, 0.5}})
+	tri := types.Triangle{0, 1, 2}
+	a, b, c := mesh.vertices[0], mesh.vertices[1], mesh.vertices[2]
+
+	if err := ValidateTriangle(tri, a, b, c, Config{Tolerance: types.DefaultEpsilon()}, mesh); !errors.Is(err, Errors().Degenerate) {
+		t.Fatalf("expected the tolerance to reject the sliver, got %v", err)
+	}
+	cfg := Config{Tolerance: types.DefaultEpsilon(), Exact: true, ValidateVertexInside: true}
+	if err := ValidateTriangle(tri, a, b, c, cfg, mesh); err != nil {
+		t.Fatalf("expected exact validation to accept the sliver, got %v", err)
+	}
+
+	// Vertex 3 lies exactly on the base, so it is not strictly inside.
+	report := ValidateTriangleAll(tri, a, b, c, cfg, mesh)
+	if !report.Valid {
+		t.Fatalf("expected a valid report, got %+v", report.Findings)
+	}
+}